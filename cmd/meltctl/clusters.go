@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"terraform-provider-meltcloud/internal/client"
+)
+
+func clustersListCmd(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, app *app, args []string) error {
+		if err := requireArgs(args); err != nil {
+			return err
+		}
+
+		result, err := app.client.Cluster().List(ctx)
+		if err != nil {
+			return fmt.Errorf("unable to list clusters: %w", err)
+		}
+
+		t := &table{header: []string{"ID", "NAME", "VERSION", "PATCH VERSION", "CONTROL PLANE"}}
+		for _, c := range result.Clusters {
+			t.add(strconv.FormatInt(c.ID, 10), c.Name, c.UserVersion, c.PatchVersion, c.ControlPlaneStatus)
+		}
+
+		return app.print(result.Clusters, t)
+	}
+}
+
+func kubeconfigGetCmd(fs *flag.FlagSet) runFunc {
+	user := fs.Bool("user", false, "print the kubeconfig for regular (OIDC) users instead of the admin kubeconfig")
+	file := fs.String("file", "", "write the kubeconfig to this file instead of stdout")
+
+	return func(ctx context.Context, app *app, args []string) error {
+		if err := requireArgs(args, "<cluster>"); err != nil {
+			return err
+		}
+
+		cluster, err := findCluster(ctx, app.client, args[0])
+		if err != nil {
+			return err
+		}
+
+		kubeconfig := cluster.KubeConfig
+		if *user {
+			kubeconfig = cluster.KubeConfigUser
+		}
+
+		if *file != "" {
+			return os.WriteFile(*file, []byte(kubeconfig), 0600)
+		}
+
+		_, wErr := fmt.Fprint(app.out, kubeconfig)
+		return wErr
+	}
+}
+
+// findCluster resolves a cluster by its ID or (case-insensitive) name and returns the full cluster
+// including the kubeconfigs, which are not part of the list response.
+func findCluster(ctx context.Context, c *client.Client, ref string) (*client.Cluster, error) {
+	id, parseErr := strconv.ParseInt(ref, 10, 64)
+	if parseErr != nil {
+		result, err := c.Cluster().List(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list clusters: %w", err)
+		}
+
+		for _, cluster := range result.Clusters {
+			if strings.EqualFold(ref, cluster.Name) {
+				id = cluster.ID
+				break
+			}
+		}
+
+		if id == 0 {
+			return nil, fmt.Errorf("could not find cluster by name %s", ref)
+		}
+	}
+
+	result, err := c.Cluster().Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read cluster %d: %w", id, err)
+	}
+
+	return result.Cluster, nil
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"terraform-provider-meltcloud/internal/export"
+)
+
+func orgExportCmd(fs *flag.FlagSet) runFunc {
+	file := fs.String("file", "", "write the configuration to this file instead of stdout")
+
+	return func(ctx context.Context, app *app, args []string) error {
+		if err := requireArgs(args); err != nil {
+			return err
+		}
+
+		config, err := export.New(app.client).Generate(ctx)
+		if err != nil {
+			return err
+		}
+
+		header := fmt.Sprintf("# Generated by meltctl org export for organization %s.\n"+
+			"# Review the configuration, then run terraform plan to import all objects.\n\n", app.client.Organization)
+		content := append([]byte(header), config.Bytes()...)
+
+		if *file != "" {
+			return os.WriteFile(*file, content, 0644)
+		}
+
+		_, wErr := app.out.Write(content)
+		return wErr
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"terraform-provider-meltcloud/internal/client"
+	"time"
+)
+
+func imagesListCmd(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, app *app, args []string) error {
+		if err := requireArgs(args); err != nil {
+			return err
+		}
+
+		result, err := app.client.EnrollmentImage().List(ctx)
+		if err != nil {
+			return fmt.Errorf("unable to list enrollment images: %w", err)
+		}
+
+		t := &table{header: []string{"ID", "NAME", "STATUS", "EXPIRES AT", "LAST USED AT"}}
+		for _, i := range result.EnrollmentImages {
+			lastUsedAt := ""
+			if i.LastUsedAt != nil {
+				lastUsedAt = i.LastUsedAt.Format(time.RFC3339)
+			}
+			t.add(strconv.FormatInt(i.ID, 10), i.Name, i.Status, i.ExpiresAt.Format(time.RFC3339), lastUsedAt)
+		}
+
+		return app.print(result.EnrollmentImages, t)
+	}
+}
+
+func imagesDownloadCmd(fs *flag.FlagSet) runFunc {
+	arch := fs.String("arch", "amd64", "CPU architecture of the ISO: amd64 or arm64")
+	insecure := fs.Bool("http", false, "download via insecure HTTP (the image must have enable_http set)")
+	file := fs.String("file", "", "output file (default <image name>-<arch>.iso)")
+
+	return func(ctx context.Context, app *app, args []string) error {
+		if err := requireArgs(args, "<image>"); err != nil {
+			return err
+		}
+
+		image, err := findEnrollmentImage(ctx, app.client, args[0])
+		if err != nil {
+			return err
+		}
+
+		url, err := isoURL(image, *arch, *insecure)
+		if err != nil {
+			return err
+		}
+
+		output := *file
+		if output == "" {
+			output = fmt.Sprintf("%s-%s.iso", image.Name, *arch)
+		}
+
+		if dErr := app.client.EnrollmentImage().Download(ctx, url, output); dErr != nil {
+			// resty writes the body to the output file even for error responses
+			_ = os.Remove(output)
+			return fmt.Errorf("unable to download enrollment image %s: %w", image.Name, dErr)
+		}
+
+		_, wErr := fmt.Fprintf(app.out, "downloaded %s\n", output)
+		return wErr
+	}
+}
+
+func isoURL(image *client.EnrollmentImage, arch string, insecure bool) (string, error) {
+	var url string
+	switch {
+	case arch == "amd64" && insecure:
+		url = image.HTTPURLISOAMD64
+	case arch == "amd64":
+		url = image.HTTPSURLISOAMD64
+	case arch == "arm64" && insecure:
+		url = image.HTTPURLISOARM64
+	case arch == "arm64":
+		url = image.HTTPSURLISOARM64
+	default:
+		return "", fmt.Errorf("unsupported architecture %q, must be amd64 or arm64", arch)
+	}
+
+	if url == "" {
+		return "", fmt.Errorf("enrollment image %s has no download URL for %s (status: %s)", image.Name, arch, image.Status)
+	}
+
+	return url, nil
+}
+
+// findEnrollmentImage resolves an enrollment image by its ID or (case-insensitive) name.
+func findEnrollmentImage(ctx context.Context, c *client.Client, ref string) (*client.EnrollmentImage, error) {
+	if id, parseErr := strconv.ParseInt(ref, 10, 64); parseErr == nil {
+		result, err := c.EnrollmentImage().Get(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read enrollment image %d: %w", id, err)
+		}
+		return result.EnrollmentImage, nil
+	}
+
+	result, err := c.EnrollmentImage().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list enrollment images: %w", err)
+	}
+
+	for _, image := range result.EnrollmentImages {
+		if strings.EqualFold(ref, image.Name) {
+			return image, nil
+		}
+	}
+
+	return nil, fmt.Errorf("could not find enrollment image by name %s", ref)
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+	"terraform-provider-meltcloud/internal/client"
+)
+
+func machinesListCmd(fs *flag.FlagSet) runFunc {
+	pool := fs.Int64("pool", 0, "only list machines assigned to this machine pool ID")
+
+	return func(ctx context.Context, app *app, args []string) error {
+		if err := requireArgs(args); err != nil {
+			return err
+		}
+
+		result, err := app.client.Machine().List(ctx)
+		if err != nil {
+			return fmt.Errorf("unable to list machines: %w", err)
+		}
+
+		machines := make([]*client.Machine, 0, len(result.Machines))
+		t := &table{header: []string{"ID", "UUID", "NAME", "MACHINE POOL", "STATUS"}}
+		for _, m := range result.Machines {
+			if *pool != 0 && m.MachinePoolID != *pool {
+				continue
+			}
+
+			machinePool := ""
+			if m.MachinePoolID != 0 {
+				machinePool = strconv.FormatInt(m.MachinePoolID, 10)
+			}
+
+			machines = append(machines, m)
+			t.add(strconv.FormatInt(m.ID, 10), m.UUID.String(), m.Name, machinePool, m.Status)
+		}
+
+		return app.print(machines, t)
+	}
+}
@@ -0,0 +1,177 @@
+// meltctl is a command-line client for quick operational access to meltcloud. It uses the same API client
+// and credential resolution (flags, then MELTCLOUD_* environment variables) as the Terraform provider.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"terraform-provider-meltcloud/internal/client"
+)
+
+// runFunc executes a command with the remaining positional arguments.
+type runFunc func(ctx context.Context, app *app, args []string) error
+
+// command is a "<group> <name>" subcommand. setup registers the command specific flags and returns the
+// function that runs the command once all flags are parsed.
+type command struct {
+	group string
+	name  string
+	args  string
+	desc  string
+	setup func(fs *flag.FlagSet) runFunc
+}
+
+var commands = []*command{
+	{group: "clusters", name: "ls", desc: "List all clusters", setup: clustersListCmd},
+	{group: "kubeconfig", name: "get", args: "<cluster>", desc: "Print the kubeconfig of a cluster (by ID or name)", setup: kubeconfigGetCmd},
+	{group: "machines", name: "ls", desc: "List machines, optionally filtered by machine pool", setup: machinesListCmd},
+	{group: "operations", name: "get", args: "<id>", desc: "Show the status of an operation", setup: operationsGetCmd},
+	{group: "operations", name: "wait", args: "<id>", desc: "Wait until an operation has finished", setup: operationsWaitCmd},
+	{group: "images", name: "ls", desc: "List all enrollment images", setup: imagesListCmd},
+	{group: "images", name: "download", args: "<image>", desc: "Download the ISO of an enrollment image (by ID or name)", setup: imagesDownloadCmd},
+	{group: "org", name: "export", desc: "Generate Terraform configuration with import blocks for all objects of the organization", setup: orgExportCmd},
+}
+
+// app carries the state shared by all commands.
+type app struct {
+	client *client.Client
+	out    io.Writer
+	format string
+}
+
+type globalOptions struct {
+	config client.Config
+	output string
+	debug  bool
+}
+
+func (o *globalOptions) register(fs *flag.FlagSet) {
+	fs.StringVar(&o.config.Endpoint, "endpoint", o.config.Endpoint, "URL of the meltcloud API (env "+client.EnvEndpoint+", default "+client.DefaultEndpoint+")")
+	fs.StringVar(&o.config.Organization, "organization", o.config.Organization, "UUID of the meltcloud Organization (env "+client.EnvOrganization+")")
+	fs.StringVar(&o.config.APIKey, "api-key", o.config.APIKey, "API Key permitted for the organization (env "+client.EnvAPIKey+")")
+	fs.StringVar(&o.config.CACertFile, "ca-cert", o.config.CACertFile, "path to a CA certificate file to verify the API server (env "+client.EnvCACert+")")
+	fs.BoolVar(&o.config.SkipTLSVerify, "skip-tls-verify", o.config.SkipTLSVerify, "skip TLS certificate verification (env "+client.EnvSkipTLSVerify+")")
+	fs.StringVar(&o.output, "o", o.output, "output format: table, json or yaml")
+	fs.BoolVar(&o.debug, "debug", o.debug, "print API requests and responses")
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintf(os.Stderr, "meltctl: %s\n", err)
+		}
+		stop()
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) error {
+	opts := &globalOptions{output: "table"}
+
+	global := flag.NewFlagSet("meltctl", flag.ContinueOnError)
+	global.SetOutput(stderr)
+	opts.register(global)
+	global.Usage = func() { usage(global) }
+
+	if err := global.Parse(args); err != nil {
+		return err
+	}
+
+	rest := global.Args()
+	if len(rest) < 2 {
+		global.Usage()
+		return flag.ErrHelp
+	}
+
+	cmd := findCommand(rest[0], rest[1])
+	if cmd == nil {
+		global.Usage()
+		return fmt.Errorf("unknown command %q", strings.Join(rest[:2], " "))
+	}
+
+	fs := flag.NewFlagSet("meltctl "+cmd.group+" "+cmd.name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	opts.register(fs)
+	runCmd := cmd.setup(fs)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: meltctl %s %s [flags] %s\n\n%s\n\nFlags:\n", cmd.group, cmd.name, cmd.args, cmd.desc)
+		fs.PrintDefaults()
+	}
+
+	positional, err := parseInterspersed(fs, rest[2:])
+	if err != nil {
+		return err
+	}
+
+	switch opts.output {
+	case "table", "json", "yaml":
+	default:
+		return fmt.Errorf("unsupported output format %q, must be one of table, json or yaml", opts.output)
+	}
+
+	opts.config.LoadEnv()
+	apiClient, err := client.NewFromConfig(&opts.config)
+	if err != nil {
+		return err
+	}
+	apiClient.SetDebug(opts.debug)
+
+	return runCmd(ctx, &app{client: apiClient, out: stdout, format: opts.output}, positional)
+}
+
+func findCommand(group string, name string) *command {
+	for _, c := range commands {
+		if c.group == group && c.name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// parseInterspersed parses flags that appear anywhere between the positional arguments.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func usage(fs *flag.FlagSet) {
+	w := fs.Output()
+	fmt.Fprintf(w, "Usage: meltctl [flags] <group> <command> [flags] [args]\n\nCommands:\n")
+
+	sorted := make([]*command, len(commands))
+	copy(sorted, commands)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].group < sorted[j].group })
+	for _, c := range sorted {
+		fmt.Fprintf(w, "  %-30s %s\n", strings.TrimSpace(c.group+" "+c.name+" "+c.args), c.desc)
+	}
+
+	fmt.Fprintf(w, "\nFlags:\n")
+	fs.PrintDefaults()
+}
+
+func requireArgs(args []string, names ...string) error {
+	if len(args) != len(names) {
+		return fmt.Errorf("expected arguments: %s", strings.Join(names, " "))
+	}
+	return nil
+}
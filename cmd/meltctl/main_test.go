@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseInterspersed(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           []string
+		wantPositional []string
+		wantUser       bool
+		wantFile       string
+		wantErr        bool
+	}{
+		{name: "no arguments"},
+		{name: "positional only", args: []string{"prod"}, wantPositional: []string{"prod"}},
+		{name: "flags before", args: []string{"-user", "-file", "kubeconfig", "prod"}, wantPositional: []string{"prod"}, wantUser: true, wantFile: "kubeconfig"},
+		{name: "flags after", args: []string{"prod", "-user", "--file=kubeconfig"}, wantPositional: []string{"prod"}, wantUser: true, wantFile: "kubeconfig"},
+		{name: "flags between", args: []string{"prod", "-user", "staging"}, wantPositional: []string{"prod", "staging"}, wantUser: true},
+		{name: "terminator", args: []string{"prod", "--", "-user"}, wantPositional: []string{"prod", "-user"}},
+		{name: "unknown flag", args: []string{"prod", "-unknown"}, wantErr: true},
+		{name: "missing flag value", args: []string{"prod", "-file"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			user := fs.Bool("user", false, "")
+			file := fs.String("file", "", "")
+
+			positional, err := parseInterspersed(fs, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseInterspersed(%q) error = %v, wantErr %v", tt.args, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if !reflect.DeepEqual(positional, tt.wantPositional) {
+				t.Errorf("parseInterspersed(%q) = %q, want %q", tt.args, positional, tt.wantPositional)
+			}
+			if *user != tt.wantUser || *file != tt.wantFile {
+				t.Errorf("parseInterspersed(%q) flags = %v, %q, want %v, %q", tt.args, *user, *file, tt.wantUser, tt.wantFile)
+			}
+		})
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "no command", args: []string{"clusters"}, wantErr: flag.ErrHelp.Error()},
+		{name: "unknown command", args: []string{"clusters", "rm"}, wantErr: `unknown command "clusters rm"`},
+		{name: "unsupported output format", args: []string{"clusters", "ls", "-o", "xml"}, wantErr: `unsupported output format "xml"`},
+		{name: "unknown flag", args: []string{"clusters", "ls", "-unknown"}, wantErr: "flag provided but not defined: -unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := run(context.Background(), tt.args, io.Discard, io.Discard)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("run(%q) error = %v, want %q", tt.args, err, tt.wantErr)
+			}
+			if tt.wantErr == flag.ErrHelp.Error() && !errors.Is(err, flag.ErrHelp) {
+				t.Errorf("run(%q) error = %v, want flag.ErrHelp", tt.args, err)
+			}
+		})
+	}
+}
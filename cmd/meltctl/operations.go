@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+	"terraform-provider-meltcloud/internal/client"
+)
+
+func operationsGetCmd(fs *flag.FlagSet) runFunc {
+	return func(ctx context.Context, app *app, args []string) error {
+		id, err := operationID(args)
+		if err != nil {
+			return err
+		}
+
+		result, cErr := app.client.Operation().Get(ctx, id)
+		if cErr != nil {
+			return fmt.Errorf("unable to read operation %d: %w", id, cErr)
+		}
+
+		return app.printOperation(result.Operation)
+	}
+}
+
+func operationsWaitCmd(fs *flag.FlagSet) runFunc {
+	timeout := fs.Duration("timeout", 0, "give up waiting after this duration, e.g. 30m (default no timeout)")
+
+	return func(ctx context.Context, app *app, args []string) error {
+		id, err := operationID(args)
+		if err != nil {
+			return err
+		}
+
+		if *timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, *timeout)
+			defer cancel()
+		}
+
+		result, cErr := app.client.Operation().PollUntilDone(ctx, id)
+		if result != nil {
+			if pErr := app.printOperation(result.Operation); pErr != nil {
+				return pErr
+			}
+		}
+		if cErr != nil {
+			return fmt.Errorf("waiting for operation %d failed: %w", id, cErr)
+		}
+
+		return nil
+	}
+}
+
+func operationID(args []string) (int64, error) {
+	if err := requireArgs(args, "<id>"); err != nil {
+		return 0, err
+	}
+
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid operation ID %q: %w", args[0], err)
+	}
+
+	return id, nil
+}
+
+func (a *app) printOperation(operation *client.Operation) error {
+	t := &table{header: []string{"ID", "ACTION", "STATUS"}}
+	t.add(strconv.FormatInt(operation.ID, 10), operation.Action, string(operation.Status))
+
+	return a.print(operation, t)
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPrint(t *testing.T) {
+	type pool struct {
+		ID           int64  `json:"id"`
+		Name         string `json:"name"`
+		PatchVersion string `json:"patch_version,omitempty"`
+	}
+
+	pools := []pool{{ID: 1, Name: "workers", PatchVersion: "1.31.4"}, {ID: 12, Name: "gpu"}}
+	poolTable := &table{header: []string{"ID", "NAME", "PATCH VERSION"}}
+	poolTable.add("1", "workers", "1.31.4")
+	poolTable.add("12", "gpu", "")
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{
+			format: "table",
+			want: "ID   NAME      PATCH VERSION\n" +
+				"1    workers   1.31.4\n" +
+				"12   gpu       \n",
+		},
+		{
+			format: "json",
+			want: `[
+  {
+    "id": 1,
+    "name": "workers",
+    "patch_version": "1.31.4"
+  },
+  {
+    "id": 12,
+    "name": "gpu"
+  }
+]
+`,
+		},
+		{
+			format: "yaml",
+			want: `- id: 1
+  name: workers
+  patch_version: 1.31.4
+- id: 12
+  name: gpu
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var out bytes.Buffer
+			a := &app{out: &out, format: tt.format}
+
+			if err := a.print(pools, poolTable); err != nil {
+				t.Fatalf("print() error = %v", err)
+			}
+
+			if out.String() != tt.want {
+				t.Errorf("print() =\n%s\nwant\n%s", out.String(), tt.want)
+			}
+		})
+	}
+}
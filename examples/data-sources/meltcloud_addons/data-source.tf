@@ -0,0 +1,17 @@
+data "meltcloud_addons" "example" {
+  kubernetes_version = "1.30"
+}
+
+# install the default versions of all available addons
+resource "meltcloud_cluster" "example" {
+  name    = "melt01"
+  version = "1.30"
+
+  dynamic "addon" {
+    for_each = data.meltcloud_addons.example.addons
+    content {
+      name    = addon.value.name
+      version = addon.value.default_version
+    }
+  }
+}
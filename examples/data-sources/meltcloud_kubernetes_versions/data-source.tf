@@ -0,0 +1,15 @@
+data "meltcloud_kubernetes_versions" "available" {}
+
+# create a cluster with the recommended version
+resource "meltcloud_cluster" "example" {
+  name    = "melt01"
+  version = data.meltcloud_kubernetes_versions.available.default_version
+}
+
+# versions that reach their end of support within 90 days
+output "expiring_versions" {
+  value = [
+    for v in data.meltcloud_kubernetes_versions.available.versions : v.version
+    if timecmp(v.end_of_support, timeadd(plantimestamp(), "2160h")) < 0
+  ]
+}
@@ -0,0 +1,27 @@
+# keep the cluster credentials out of the state
+resource "meltcloud_cluster" "example" {
+  name             = "melt02"
+  version          = "1.30"
+  store_kubeconfig = false
+}
+
+# fetch the admin credentials on every run instead
+ephemeral "meltcloud_cluster_credentials" "example" {
+  cluster_id = meltcloud_cluster.example.id
+}
+
+provider "helm" {
+  kubernetes {
+    host                   = ephemeral.meltcloud_cluster_credentials.example.kubeconfig.host
+    client_certificate     = base64decode(ephemeral.meltcloud_cluster_credentials.example.kubeconfig.client_certificate)
+    client_key             = base64decode(ephemeral.meltcloud_cluster_credentials.example.kubeconfig.client_key)
+    cluster_ca_certificate = base64decode(ephemeral.meltcloud_cluster_credentials.example.kubeconfig.cluster_ca_certificate)
+  }
+}
+
+# or issue a personal, read-only certificate for this run only, revoked once the run is done
+ephemeral "meltcloud_cluster_credentials" "readonly" {
+  cluster_id   = meltcloud_cluster.example.id
+  ttl          = "30m"
+  cluster_role = "view"
+}
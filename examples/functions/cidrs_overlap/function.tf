@@ -0,0 +1,14 @@
+# refuse to plan clusters whose networks overlap the node network
+resource "meltcloud_cluster" "example" {
+  name         = "example"
+  version      = "1.31"
+  pod_cidr     = var.pod_cidr
+  service_cidr = var.service_cidr
+
+  lifecycle {
+    precondition {
+      condition     = !provider::meltcloud::cidrs_overlap([var.pod_cidr, var.service_cidr, var.node_cidr])
+      error_message = "pod_cidr, service_cidr and the node network must not overlap."
+    }
+  }
+}
@@ -0,0 +1,5 @@
+# allow DNS queries to the cluster DNS service before the cluster exists
+locals {
+  service_cidr   = "10.96.0.0/16"
+  dns_service_ip = provider::meltcloud::cluster_dns_ip(local.service_cidr) # 10.96.0.10
+}
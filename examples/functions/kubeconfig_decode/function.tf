@@ -0,0 +1,16 @@
+# configure the kubernetes provider from a kubeconfig in a remote state output
+locals {
+  kubeconfig = provider::meltcloud::kubeconfig_decode(data.terraform_remote_state.platform.outputs.kubeconfig)
+}
+
+provider "kubernetes" {
+  host                   = local.kubeconfig.host
+  token                  = local.kubeconfig.token
+  client_certificate     = try(base64decode(local.kubeconfig.client_certificate), null)
+  client_key             = try(base64decode(local.kubeconfig.client_key), null)
+  cluster_ca_certificate = base64decode(local.kubeconfig.cluster_ca_certificate)
+}
+
+output "contexts" {
+  value = local.kubeconfig.contexts[*].name
+}
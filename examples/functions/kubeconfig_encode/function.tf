@@ -0,0 +1,20 @@
+# render a kubeconfig for a service account token
+resource "local_sensitive_file" "ci_kubeconfig" {
+  filename = "${path.module}/kubeconfig-ci.yaml"
+  content = provider::meltcloud::kubeconfig_encode({
+    host                   = meltcloud_cluster.example.kubeconfig.host
+    cluster_ca_certificate = meltcloud_cluster.example.kubeconfig.cluster_ca_certificate
+    token                  = kubernetes_secret_v1.ci.data["token"]
+    context_name           = "ci"
+    namespace              = "ci"
+  })
+}
+
+# or rename the context of an existing kubeconfig
+output "renamed" {
+  value = provider::meltcloud::kubeconfig_encode(merge(
+    provider::meltcloud::kubeconfig_decode(meltcloud_cluster.example.kubeconfig_raw),
+    { context_name = "production" },
+  ))
+  sensitive = true
+}
@@ -0,0 +1,7 @@
+# allocate pod and service CIDRs for each cluster from a shared supernet
+locals {
+  pool         = "10.128.0.0/9"
+  reserved     = ["10.128.0.0/16"]
+  pod_cidr     = provider::meltcloud::next_free_cidr(local.reserved, local.pool, 16)
+  service_cidr = provider::meltcloud::next_free_cidr(concat(local.reserved, [local.pod_cidr]), local.pool, 20)
+}
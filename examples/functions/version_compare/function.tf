@@ -0,0 +1,4 @@
+# only roll out a feature once the control plane runs at least 1.31
+locals {
+  gateway_api_enabled = provider::meltcloud::version_compare(meltcloud_cluster.example.patch_version, "1.31") >= 0
+}
@@ -0,0 +1,6 @@
+# pin a machine pool to the minor version the control plane actually runs
+resource "meltcloud_machine_pool" "example" {
+  cluster_id = meltcloud_cluster.example.id
+  name       = "pool1"
+  version    = provider::meltcloud::version_minor(meltcloud_cluster.example.patch_version)
+}
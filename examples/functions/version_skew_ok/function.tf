@@ -0,0 +1,13 @@
+# refuse to plan machine pool versions the control plane does not support
+resource "meltcloud_machine_pool" "example" {
+  cluster_id = meltcloud_cluster.example.id
+  name       = "pool1"
+  version    = var.machine_pool_version
+
+  lifecycle {
+    precondition {
+      condition     = provider::meltcloud::version_skew_ok(meltcloud_cluster.example.version, var.machine_pool_version)
+      error_message = "Upgrade the control plane before the machine pool, kubelets may not be newer than the control plane or more than 3 minor versions older."
+    }
+  }
+}
@@ -1,2 +1,14 @@
 # Resource can be imported by using the resource path as displayed in the URL
-terraform import meltcloud_cluster.example clusters/1
\ No newline at end of file
+terraform import meltcloud_cluster.example clusters/1
+
+# ...or by the full console URL
+terraform import meltcloud_cluster.example https://app.meltcloud.io/ui/orgs/e3a2c8b2-49a4-4b8e-8a2d-0f8e3a1c5b7d/clusters/1
+
+# ...or by name
+terraform import meltcloud_cluster.example name=prod
+
+# With Terraform 1.12+, the resource can also be imported by its identity in an import block:
+# import {
+#   to       = meltcloud_cluster.example
+#   identity = { cluster_id = 1 }
+# }
\ No newline at end of file
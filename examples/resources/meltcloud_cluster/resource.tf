@@ -13,6 +13,13 @@ resource "meltcloud_cluster" "example_custom_network" {
   dns_service_ip = "10.96.0.10"
 }
 
+# rotate the admin client certificate when it expires within 30 days
+resource "meltcloud_cluster" "example_renewal" {
+  name         = "melt04"
+  version      = "1.30"
+  renew_before = "720h"
+}
+
 # use kubeconfig to install a helm chart, for example a CNI
 provider "helm" {
   kubernetes {
@@ -36,4 +43,63 @@ resource "helm_release" "cilium" {
     name  = "ipam.mode"
     value = "kubernetes"
   }
-}
\ No newline at end of file
+}
+# hand out a kubeconfig for developers that uses a standalone kubelogin binary
+resource "meltcloud_cluster" "example_developers" {
+  name    = "melt05"
+  version = "1.30"
+
+  kubeconfig_user_exec = {
+    command = "kubelogin"
+  }
+}
+
+resource "local_sensitive_file" "developer_kubeconfig" {
+  content  = meltcloud_cluster.example_developers.kubeconfig_user_raw
+  filename = "${path.module}/kubeconfig-melt05.yaml"
+}
+
+# install addons with the cluster, replacing kube-proxy with Cilium
+resource "meltcloud_cluster" "example_addons" {
+  name             = "melt06"
+  version          = "1.30"
+  addon_kube_proxy = false
+
+  addon {
+    name    = "cilium"
+    version = "1.16.1"
+    values = yamlencode({
+      kubeProxyReplacement = true
+    })
+  }
+
+  addon {
+    name = "metrics-server"
+  }
+}
+
+# restrict the API server to the office network and serve it under a custom DNS name
+resource "meltcloud_cluster" "example_restricted" {
+  name    = "melt07"
+  version = "1.30"
+
+  api_server_allowed_cidrs = ["203.0.113.0/24"]
+  api_server_dns_name      = "k8s.example.com"
+  api_server_cert_sans     = ["k8s-internal.example.com"]
+}
+
+# log users in with the company identity provider and federate service accounts with AWS IAM
+resource "meltcloud_cluster" "example_oidc" {
+  name    = "melt08"
+  version = "1.30"
+
+  service_account_issuer = "https://oidc.example.com/melt08"
+
+  oidc {
+    issuer_url     = "https://login.microsoftonline.com/00000000-0000-0000-0000-000000000000/v2.0"
+    client_id      = "11111111-1111-1111-1111-111111111111"
+    username_claim = "email"
+    groups_claim   = "groups"
+    groups_prefix  = "oidc:"
+  }
+}
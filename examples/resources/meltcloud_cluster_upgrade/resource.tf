@@ -0,0 +1,34 @@
+resource "meltcloud_cluster" "example" {
+  name           = "melt02"
+  version        = "1.34"
+  pod_cidr       = "10.36.0.0/16"
+  service_cidr   = "10.96.0.0/16"
+  dns_service_ip = "10.96.0.10"
+
+  # the version is managed by meltcloud_cluster_upgrade
+  lifecycle {
+    ignore_changes = [version]
+  }
+}
+
+resource "meltcloud_machine_pool" "example" {
+  cluster_id = meltcloud_cluster.example.id
+
+  name    = "pool1"
+  version = "1.34"
+
+  lifecycle {
+    ignore_changes = [version]
+  }
+}
+
+# upgrade the control plane, then all pools two at a time
+resource "meltcloud_cluster_upgrade" "example" {
+  cluster_id = meltcloud_cluster.example.id
+  version    = "1.35"
+  batch_size = 2
+}
+
+output "upgraded_pools" {
+  value = [for pool in meltcloud_cluster_upgrade.example.pools : "${pool.name}: ${pool.status}"]
+}
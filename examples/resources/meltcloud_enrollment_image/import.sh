@@ -1,2 +1,11 @@
 # Resource can be imported by using the resource path as displayed in the URL
-terraform import meltcloud_enrollment_image.example enrollment_images/1
\ No newline at end of file
+terraform import meltcloud_enrollment_image.example enrollment_images/1
+
+# ...or by name
+terraform import meltcloud_enrollment_image.example name=default
+
+# With Terraform 1.12+, the resource can also be imported by its identity in an import block:
+# import {
+#   to       = meltcloud_enrollment_image.example
+#   identity = { enrollment_image_id = 1 }
+# }
\ No newline at end of file
@@ -0,0 +1,16 @@
+# merge the admin kubeconfig into ~/.kube/config and switch to it
+resource "meltcloud_kubeconfig_file" "admin" {
+  cluster_id          = meltcloud_cluster.example.id
+  path                = "~/.kube/config"
+  namespace           = "default"
+  set_current_context = true
+}
+
+# write the kubeconfig for OIDC users into a separate file to hand out
+resource "meltcloud_kubeconfig_file" "user" {
+  cluster_id      = meltcloud_cluster.example.id
+  type            = "user"
+  path            = "${path.module}/kubeconfig-melt02.yaml"
+  context_name    = "melt02"
+  file_permission = "0644"
+}
@@ -1,2 +1,14 @@
 # Resource can be imported by using the resource path as displayed in the URL
-terraform import meltcloud_machine.example machines/123
\ No newline at end of file
+terraform import meltcloud_machine.example machines/123
+
+# ...or by the machine UUID
+terraform import meltcloud_machine.example 0c4e8b1a-8a3f-4d2b-9a5e-7f1c2d3e4b5a
+
+# ...or by name
+terraform import meltcloud_machine.example name=node-1
+
+# With Terraform 1.12+, the resource can also be imported by its identity in an import block:
+# import {
+#   to       = meltcloud_machine.example
+#   identity = { uuid = "0c4e8b1a-8a3f-4d2b-9a5e-7f1c2d3e4b5a" }
+# }
\ No newline at end of file
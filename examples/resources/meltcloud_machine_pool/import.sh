@@ -1,2 +1,11 @@
 # Resource can be imported by using the resource path as displayed in the URL
-terraform import meltcloud_machine_pool.example clusters/1/machine_pools/2
\ No newline at end of file
+terraform import meltcloud_machine_pool.example clusters/1/machine_pools/2
+
+# ...or by cluster name and machine pool name
+terraform import meltcloud_machine_pool.example prod/workers
+
+# With Terraform 1.12+, the resource can also be imported by its identity in an import block:
+# import {
+#   to       = meltcloud_machine_pool.example
+#   identity = { cluster_id = 1, machine_pool_id = 2 }
+# }
\ No newline at end of file
@@ -13,4 +13,23 @@ resource "meltcloud_machine_pool" "example" {
 
   name    = "pool1"
   version = "1.29"
-}
\ No newline at end of file
+}
+
+# roll out patches automatically on Sunday nights only
+resource "meltcloud_machine_pool" "patched" {
+  cluster_id = meltcloud_cluster.example.id
+
+  name    = "pool2"
+  version = "1.30"
+
+  maintenance_window {
+    day        = "sunday"
+    start_time = "02:00"
+    timezone   = "Europe/Zurich"
+  }
+
+  auto_upgrade {
+    patch = true
+    minor = false
+  }
+}
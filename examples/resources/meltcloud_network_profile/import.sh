@@ -1,2 +1,11 @@
 # Resource can be imported by using the resource path as displayed in the URL
-terraform import meltcloud_network_profile.example network_profiles/1
\ No newline at end of file
+terraform import meltcloud_network_profile.example network_profiles/1
+
+# ...or by name
+terraform import meltcloud_network_profile.example name=vlan-100
+
+# With Terraform 1.12+, the resource can also be imported by its identity in an import block:
+# import {
+#   to       = meltcloud_network_profile.example
+#   identity = { network_profile_id = 1 }
+# }
\ No newline at end of file
@@ -0,0 +1,50 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+type AddonRequest struct {
+	client *Client
+}
+
+type AddonsResult struct {
+	Addons []*Addon `json:"addons"`
+}
+
+// Addon is an addon available for installation on clusters.
+type Addon struct {
+	Name           string   `json:"name"`
+	Description    string   `json:"description"`
+	Versions       []string `json:"versions"`
+	DefaultVersion string   `json:"default_version"`
+}
+
+func (c *Client) Addon() *AddonRequest {
+	return &AddonRequest{
+		client: c,
+	}
+}
+
+// List returns the addons available for clusters of the given Kubernetes minor version, e.g. 1.30.
+func (ar *AddonRequest) List(ctx context.Context, kubernetesVersion string) (*AddonsResult, *Error) {
+	query := url.Values{"kubernetes_version": []string{kubernetesVersion}}
+	clientRequest := &ClientRequest{
+		Path:   fmt.Sprintf("%s?%s", "addons", query.Encode()),
+		Result: &AddonsResult{},
+	}
+
+	result, err := ar.client.Get(ctx, clientRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	addonsResult, ok := result.(*AddonsResult)
+	if !ok {
+		return nil, &ErrorTypeAssert
+	}
+
+	return addonsResult, nil
+}
@@ -16,6 +16,8 @@ type Client struct {
 	HttpClient   *resty.Client
 	Endpoint     string
 	Organization string
+
+	kubernetesVersions kubernetesVersionsCache
 }
 
 type ClientRequest struct {
@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"fmt"
+	"time"
 )
 
 type ClusterRequest struct {
@@ -31,6 +32,33 @@ type Cluster struct {
 	DNSServiceIP       string `json:"dns_service_ip"`
 	AddonKubeProxy     bool   `json:"addon_kube_proxy"`
 	AddonCoreDNS       bool   `json:"addon_core_dns"`
+
+	Addons []ClusterAddon `json:"addons,omitempty"`
+
+	MaintenanceWindow *MaintenanceWindow `json:"maintenance_window"`
+	AutoUpgrade       *AutoUpgrade       `json:"auto_upgrade"`
+	NextMaintenanceAt *time.Time         `json:"next_maintenance_at"`
+
+	DeletionProtection bool `json:"deletion_protection"`
+
+	// APIServerAllowedCIDRs restricts access to the API server, empty allows access from everywhere.
+	APIServerAllowedCIDRs    []string `json:"api_server_allowed_cidrs"`
+	APIServerPrivateEndpoint bool     `json:"api_server_private_endpoint"`
+	APIServerCertSANs        []string `json:"api_server_cert_sans"`
+	APIServerDNSName         string   `json:"api_server_dns_name"`
+
+	// OIDC is the identity provider of the user kubeconfig, nil if the cluster uses the meltcloud identity provider.
+	OIDC                 *ClusterOIDC `json:"oidc"`
+	ServiceAccountIssuer string       `json:"service_account_issuer"`
+}
+
+// ClusterAddon is an addon installed on a cluster, in addition to kube-proxy and CoreDNS.
+type ClusterAddon struct {
+	Name string `json:"name"`
+	// Version of the addon, empty to install the default version for the Kubernetes version of the cluster.
+	Version string `json:"version,omitempty"`
+	// Values is a YAML or JSON document configuring the addon.
+	Values string `json:"values,omitempty"`
 }
 
 type ClusterCreateInput struct {
@@ -41,10 +69,52 @@ type ClusterCreateInput struct {
 	DNSServiceIP   *string `json:"dns_service_ip,omitempty"`
 	AddonKubeProxy *bool   `json:"addon_kube_proxy,omitempty"`
 	AddonCoreDNS   *bool   `json:"addon_core_dns,omitempty"`
+
+	Addons []ClusterAddon `json:"addons,omitempty"`
+
+	MaintenanceWindow *MaintenanceWindow `json:"maintenance_window,omitempty"`
+	AutoUpgrade       *AutoUpgrade       `json:"auto_upgrade,omitempty"`
+
+	DeletionProtection *bool `json:"deletion_protection,omitempty"`
+
+	APIServerAllowedCIDRs    []string `json:"api_server_allowed_cidrs,omitempty"`
+	APIServerPrivateEndpoint bool     `json:"api_server_private_endpoint,omitempty"`
+	APIServerCertSANs        []string `json:"api_server_cert_sans,omitempty"`
+	APIServerDNSName         string   `json:"api_server_dns_name,omitempty"`
+
+	OIDC                 *ClusterOIDC `json:"oidc,omitempty"`
+	ServiceAccountIssuer string       `json:"service_account_issuer,omitempty"`
 }
 
 type ClusterUpdateInput struct {
-	UserVersion string `json:"user_version,omitempty"`
+	UserVersion    string `json:"user_version,omitempty"`
+	AddonKubeProxy *bool  `json:"addon_kube_proxy,omitempty"`
+	AddonCoreDNS   *bool  `json:"addon_core_dns,omitempty"`
+
+	// Addons replaces all addons of the cluster, nil leaves them unchanged.
+	Addons *[]ClusterAddon `json:"addons,omitempty"`
+
+	// MaintenanceWindow and AutoUpgrade replace the current settings, an empty value removes them and nil leaves them
+	// unchanged.
+	MaintenanceWindow *MaintenanceWindow `json:"maintenance_window,omitempty"`
+	AutoUpgrade       *AutoUpgrade       `json:"auto_upgrade,omitempty"`
+
+	DeletionProtection *bool `json:"deletion_protection,omitempty"`
+
+	// APIServerAllowedCIDRs and APIServerCertSANs replace the current lists, an empty list removes all entries and nil
+	// leaves them unchanged.
+	APIServerAllowedCIDRs    *[]string `json:"api_server_allowed_cidrs,omitempty"`
+	APIServerPrivateEndpoint *bool     `json:"api_server_private_endpoint,omitempty"`
+	APIServerCertSANs        *[]string `json:"api_server_cert_sans,omitempty"`
+	// APIServerDNSName is the custom DNS name of the API server, an empty string removes it and nil leaves it unchanged.
+	APIServerDNSName *string `json:"api_server_dns_name,omitempty"`
+
+	// OIDC replaces the identity provider, an empty value switches back to the meltcloud identity provider and nil
+	// leaves it unchanged.
+	OIDC *ClusterOIDC `json:"oidc,omitempty"`
+	// ServiceAccountIssuer is the issuer of service account tokens, an empty string resets it to the meltcloud issuer
+	// and nil leaves it unchanged.
+	ServiceAccountIssuer *string `json:"service_account_issuer,omitempty"`
 }
 
 func (c *Client) Cluster() *ClusterRequest {
@@ -152,3 +222,24 @@ func (mr *ClusterRequest) Delete(ctx context.Context, id int64) (*ClusterResult,
 
 	return clusterResult, nil
 }
+
+// RotateCredentials issues a new admin client certificate. The previous certificate stays valid until it expires.
+func (mr *ClusterRequest) RotateCredentials(ctx context.Context, id int64) (*ClusterResult, *Error) {
+	subPath := fmt.Sprintf("%s/%d/%s", "clusters", id, "rotate_credentials")
+	clientRequest := &ClientRequest{
+		Path:   subPath,
+		Result: &ClusterResult{},
+	}
+
+	result, err := mr.client.Post(ctx, clientRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterResult, ok := result.(*ClusterResult)
+	if !ok {
+		return nil, &ErrorTypeAssert
+	}
+
+	return clusterResult, nil
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+type ClusterCredentialRequest struct {
+	client *Client
+}
+
+type ClusterCredentialResult struct {
+	ClusterCredential *ClusterCredential `json:"cluster_credential"`
+}
+
+type ClusterCredential struct {
+	ID          int64     `json:"id"`
+	Username    string    `json:"username"`
+	Groups      []string  `json:"groups"`
+	ClusterRole string    `json:"cluster_role"`
+	KubeConfig  string    `json:"kubeconfig"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+type ClusterCredentialCreateInput struct {
+	TTLSeconds  int64    `json:"ttl_seconds"`
+	Groups      []string `json:"groups,omitempty"`
+	ClusterRole *string  `json:"cluster_role,omitempty"`
+}
+
+func (c *Client) ClusterCredential() *ClusterCredentialRequest {
+	return &ClusterCredentialRequest{
+		client: c,
+	}
+}
+
+func (mr *ClusterCredentialRequest) Create(ctx context.Context, clusterId int64, input *ClusterCredentialCreateInput) (*ClusterCredentialResult, *Error) {
+	clientRequest := &ClientRequest{
+		Path:   fmt.Sprintf("%s/%d/%s", "clusters", clusterId, "credentials"),
+		Result: &ClusterCredentialResult{},
+		Body:   input,
+	}
+
+	result, err := mr.client.Post(ctx, clientRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterCredentialResult, ok := result.(*ClusterCredentialResult)
+	if !ok {
+		return nil, &ErrorTypeAssert
+	}
+
+	return clusterCredentialResult, nil
+}
+
+// Delete revokes the credential, the API server rejects it immediately even if it has not expired yet.
+func (mr *ClusterCredentialRequest) Delete(ctx context.Context, clusterId int64, id int64) (*ClusterCredentialResult, *Error) {
+	subPath := fmt.Sprintf("%s/%d/%s/%d", "clusters", clusterId, "credentials", id)
+	clientRequest := &ClientRequest{
+		Path:   subPath,
+		Result: &ClusterCredentialResult{},
+	}
+
+	result, err := mr.client.Delete(ctx, clientRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterCredentialResult, ok := result.(*ClusterCredentialResult)
+	if !ok {
+		return nil, &ErrorTypeAssert
+	}
+
+	return clusterCredentialResult, nil
+}
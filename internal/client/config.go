@@ -0,0 +1,95 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+const (
+	DefaultEndpoint string = "https://app.meltcloud.io"
+
+	EnvEndpoint      string = "MELTCLOUD_ENDPOINT"
+	EnvOrganization  string = "MELTCLOUD_ORGANIZATION"
+	EnvAPIKey        string = "MELTCLOUD_API_KEY"
+	EnvCACert        string = "MELTCLOUD_CACERT"
+	EnvSkipTLSVerify string = "MELTCLOUD_SKIP_VERIFY"
+)
+
+// Config holds everything needed to build a Client. It is shared by the provider and meltctl so both
+// resolve credentials the same way.
+type Config struct {
+	Endpoint      string
+	Organization  string
+	APIKey        string
+	CACertFile    string
+	CACertPEM     string
+	SkipTLSVerify bool
+}
+
+// LoadEnv fills all unset fields from the MELTCLOUD_* environment variables and applies defaults.
+func (c *Config) LoadEnv() {
+	if c.Endpoint == "" {
+		c.Endpoint = os.Getenv(EnvEndpoint)
+	}
+	if c.Endpoint == "" {
+		c.Endpoint = DefaultEndpoint
+	}
+	if c.Organization == "" {
+		c.Organization = os.Getenv(EnvOrganization)
+	}
+	if c.APIKey == "" {
+		c.APIKey = os.Getenv(EnvAPIKey)
+	}
+	if c.CACertFile == "" && c.CACertPEM == "" {
+		c.CACertFile = os.Getenv(EnvCACert)
+	}
+	if !c.SkipTLSVerify {
+		c.SkipTLSVerify = EnvBool(EnvSkipTLSVerify)
+	}
+}
+
+// EnvBool reports whether the environment variable is set to "1" or "true".
+func EnvBool(envVar string) bool {
+	v := os.Getenv(envVar)
+	return v == "1" || v == "true"
+}
+
+// NewFromConfig validates the config, loads the CA certificate if needed and returns a new Client.
+func NewFromConfig(config *Config) (*Client, error) {
+	if config.APIKey == "" {
+		return nil, fmt.Errorf("api key must be set, e.g. via %s", EnvAPIKey)
+	}
+
+	if config.Organization == "" {
+		return nil, fmt.Errorf("organization must be set, e.g. via %s", EnvOrganization)
+	}
+
+	if config.CACertFile != "" && config.CACertPEM != "" {
+		return nil, errors.New("ca_cert_file and ca_cert_pem are mutually exclusive")
+	}
+
+	caPEM := config.CACertPEM
+	if config.CACertFile != "" {
+		pemBytes, err := os.ReadFile(config.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate file: %s", err)
+		}
+		caPEM = string(pemBytes)
+	}
+
+	var tlsConfig *TLSConfig
+	if caPEM != "" || config.SkipTLSVerify {
+		tlsConfig = &TLSConfig{
+			CACertPEM:     caPEM,
+			SkipTLSVerify: config.SkipTLSVerify,
+		}
+	}
+
+	endpoint := config.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+
+	return New(endpoint, config.Organization, config.APIKey, tlsConfig), nil
+}
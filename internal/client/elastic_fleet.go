@@ -14,16 +14,28 @@ type ElasticFleetResult struct {
 	Operation    *Operation    `json:"operation,omitempty"`
 }
 
+type ElasticFleetsResult struct {
+	ElasticFleets []*ElasticFleet `json:"elastic_fleets"`
+}
+
 type ElasticFleet struct {
 	ID        int64  `json:"id"`
 	Name      string `json:"name"`
 	Status    string `json:"status"`
 	ClusterID int64  `json:"cluster_id"`
+
+	DeletionProtection bool `json:"deletion_protection"`
 }
 
 type ElasticFleetCreateInput struct {
 	Name      string `json:"name"`
 	ClusterID int64  `json:"cluster_id"`
+
+	DeletionProtection *bool `json:"deletion_protection,omitempty"`
+}
+
+type ElasticFleetUpdateInput struct {
+	DeletionProtection *bool `json:"deletion_protection,omitempty"`
 }
 
 func (c *Client) ElasticFleet() *ElasticFleetRequest {
@@ -32,6 +44,25 @@ func (c *Client) ElasticFleet() *ElasticFleetRequest {
 	}
 }
 
+func (er *ElasticFleetRequest) List(ctx context.Context) (*ElasticFleetsResult, *Error) {
+	clientRequest := &ClientRequest{
+		Path:   "elastic_fleets",
+		Result: &ElasticFleetsResult{},
+	}
+
+	result, err := er.client.Get(ctx, clientRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	fleetsResult, ok := result.(*ElasticFleetsResult)
+	if !ok {
+		return nil, &ErrorTypeAssert
+	}
+
+	return fleetsResult, nil
+}
+
 func (er *ElasticFleetRequest) Get(ctx context.Context, id int64) (*ElasticFleetResult, *Error) {
 	clientRequest := &ClientRequest{
 		Path:   fmt.Sprintf("%s/%d", "elastic_fleets", id),
@@ -71,6 +102,26 @@ func (er *ElasticFleetRequest) Create(ctx context.Context, input *ElasticFleetCr
 	return fleetResult, nil
 }
 
+func (er *ElasticFleetRequest) Update(ctx context.Context, id int64, input *ElasticFleetUpdateInput) (*ElasticFleetResult, *Error) {
+	clientRequest := &ClientRequest{
+		Path:   fmt.Sprintf("%s/%d", "elastic_fleets", id),
+		Result: &ElasticFleetResult{},
+		Body:   input,
+	}
+
+	result, err := er.client.Put(ctx, clientRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	fleetResult, ok := result.(*ElasticFleetResult)
+	if !ok {
+		return nil, &ErrorTypeAssert
+	}
+
+	return fleetResult, nil
+}
+
 func (er *ElasticFleetRequest) Delete(ctx context.Context, id int64) (*ElasticFleetResult, *Error) {
 	clientRequest := &ClientRequest{
 		Path:   fmt.Sprintf("%s/%d", "elastic_fleets", id),
@@ -14,6 +14,10 @@ type ElasticNodePoolResult struct {
 	Operation       *Operation       `json:"operation,omitempty"`
 }
 
+type ElasticNodePoolsResult struct {
+	ElasticNodePools []*ElasticNodePool `json:"elastic_node_pools"`
+}
+
 type ElasticNodePool struct {
 	ID             int64  `json:"id"`
 	Name           string `json:"name"`
@@ -26,6 +30,8 @@ type ElasticNodePool struct {
 	NodeDiskGiB    int64  `json:"node_disk_gib"`
 	Version        string `json:"version"`
 	PatchVersion   string `json:"patch_version"`
+
+	DeletionProtection bool `json:"deletion_protection"`
 }
 
 type ElasticNodePoolCreateInput struct {
@@ -36,6 +42,8 @@ type ElasticNodePoolCreateInput struct {
 	NodeMemoryMiB  int64  `json:"node_memory_mib"`
 	NodeDiskGiB    int64  `json:"node_disk_gib"`
 	Version        string `json:"version"`
+
+	DeletionProtection *bool `json:"deletion_protection,omitempty"`
 }
 
 type ElasticNodePoolUpdateInput struct {
@@ -44,6 +52,8 @@ type ElasticNodePoolUpdateInput struct {
 	NodeMemoryMiB int64  `json:"node_memory_mib"`
 	NodeDiskGiB   int64  `json:"node_disk_gib"`
 	Version       string `json:"version"`
+
+	DeletionProtection *bool `json:"deletion_protection,omitempty"`
 }
 
 func (c *Client) ElasticNodePool() *ElasticNodePoolRequest {
@@ -52,6 +62,25 @@ func (c *Client) ElasticNodePool() *ElasticNodePoolRequest {
 	}
 }
 
+func (er *ElasticNodePoolRequest) List(ctx context.Context, clusterId int64) (*ElasticNodePoolsResult, *Error) {
+	clientRequest := &ClientRequest{
+		Path:   fmt.Sprintf("%s/%d/%s", "clusters", clusterId, "elastic_node_pools"),
+		Result: &ElasticNodePoolsResult{},
+	}
+
+	result, err := er.client.Get(ctx, clientRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	nodePoolsResult, ok := result.(*ElasticNodePoolsResult)
+	if !ok {
+		return nil, &ErrorTypeAssert
+	}
+
+	return nodePoolsResult, nil
+}
+
 func (er *ElasticNodePoolRequest) Get(ctx context.Context, clusterId int64, id int64) (*ElasticNodePoolResult, *Error) {
 	clientRequest := &ClientRequest{
 		Path:   fmt.Sprintf("%s/%d/%s/%d", "clusters", clusterId, "elastic_node_pools", id),
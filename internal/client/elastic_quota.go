@@ -13,6 +13,10 @@ type ElasticQuotaResult struct {
 	ElasticQuota *ElasticQuota `json:"elastic_quota"`
 }
 
+type ElasticQuotasResult struct {
+	ElasticQuotas []*ElasticQuota `json:"elastic_quotas"`
+}
+
 type ElasticQuota struct {
 	ID                        int64  `json:"id"`
 	Name                      string `json:"name"`
@@ -45,6 +49,25 @@ func (c *Client) ElasticQuota() *ElasticQuotaRequest {
 	}
 }
 
+func (er *ElasticQuotaRequest) List(ctx context.Context) (*ElasticQuotasResult, *Error) {
+	clientRequest := &ClientRequest{
+		Path:   "elastic_quotas",
+		Result: &ElasticQuotasResult{},
+	}
+
+	result, err := er.client.Get(ctx, clientRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	quotasResult, ok := result.(*ElasticQuotasResult)
+	if !ok {
+		return nil, &ErrorTypeAssert
+	}
+
+	return quotasResult, nil
+}
+
 func (er *ElasticQuotaRequest) Get(ctx context.Context, id int64) (*ElasticQuotaResult, *Error) {
 	clientRequest := &ClientRequest{
 		Path:   fmt.Sprintf("%s/%d", "elastic_quotas", id),
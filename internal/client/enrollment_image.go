@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"time"
+
+	"github.com/go-resty/resty/v2"
 )
 
 type EnrollmentImageRequest struct {
@@ -30,8 +32,8 @@ type EnrollmentImage struct {
 	EnableHTTP                bool       `json:"enable_http"`
 	HTTPURLISOAMD64           string     `json:"http_url_iso_amd64"`
 	HTTPURLISOARM64           string     `json:"http_url_iso_arm64"`
-	HTTPSURLISOAMD64          string     `json:"https_url_iso_arm64"`
-	HTTPSURLISOARM64          string     `json:"https_url_iso_amd64"`
+	HTTPSURLISOAMD64          string     `json:"https_url_iso_amd64"`
+	HTTPSURLISOARM64          string     `json:"https_url_iso_arm64"`
 	LastUsedAt                *time.Time `json:"last_used_at"`
 }
 
@@ -128,3 +130,28 @@ func (mr *EnrollmentImageRequest) Delete(ctx context.Context, id int64) (*Enroll
 
 	return clusterResult, nil
 }
+
+// Download fetches an ISO from one of the image URLs and writes it to the output file. The ISO URLs are not part of
+// the API, so the request is sent without the API key, only the TLS settings of the client are used.
+func (mr *EnrollmentImageRequest) Download(ctx context.Context, url string, output string) *Error {
+	downloadClient := resty.New().
+		SetTransport(mr.client.HttpClient.GetClient().Transport).
+		SetHeader("User-Agent", "meltcloud-go-client v1")
+
+	resp, err := downloadClient.R().
+		SetContext(ctx).
+		SetOutput(output).
+		Get(url)
+	if err != nil {
+		return &Error{Err: err}
+	}
+
+	if resp.IsError() {
+		return &Error{
+			HTTPStatusCode: resp.StatusCode(),
+			Message:        resp.Status(),
+		}
+	}
+
+	return nil
+}
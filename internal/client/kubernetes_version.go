@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type KubernetesVersionRequest struct {
+	client *Client
+}
+
+type KubernetesVersionsResult struct {
+	KubernetesVersions []*KubernetesVersion `json:"kubernetes_versions"`
+	// DefaultVersion is the minor version recommended for new clusters.
+	DefaultVersion string `json:"default_version"`
+}
+
+// KubernetesVersion is a Kubernetes minor version supported for clusters, machine pools and elastic node pools.
+type KubernetesVersion struct {
+	Version            string    `json:"version"`
+	LatestPatchVersion string    `json:"latest_patch_version"`
+	EndOfSupport       time.Time `json:"end_of_support"`
+}
+
+// kubernetesVersionsCache holds the result of ListCached for a client.
+type kubernetesVersionsCache struct {
+	sync.Mutex
+	result *KubernetesVersionsResult
+}
+
+func (c *Client) KubernetesVersion() *KubernetesVersionRequest {
+	return &KubernetesVersionRequest{
+		client: c,
+	}
+}
+
+func (kr *KubernetesVersionRequest) List(ctx context.Context) (*KubernetesVersionsResult, *Error) {
+	clientRequest := &ClientRequest{
+		Path:   "kubernetes_versions",
+		Result: &KubernetesVersionsResult{},
+	}
+
+	result, err := kr.client.Get(ctx, clientRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	kubernetesVersionsResult, ok := result.(*KubernetesVersionsResult)
+	if !ok {
+		return nil, &ErrorTypeAssert
+	}
+
+	return kubernetesVersionsResult, nil
+}
+
+// ListCached returns the supported versions like List, but requests them only once per client, e.g. once for a plan
+// with many clusters and pools. Errors are not cached.
+func (kr *KubernetesVersionRequest) ListCached(ctx context.Context) (*KubernetesVersionsResult, *Error) {
+	cache := &kr.client.kubernetesVersions
+	cache.Lock()
+	defer cache.Unlock()
+
+	if cache.result != nil {
+		return cache.result, nil
+	}
+
+	result, err := kr.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.result = result
+
+	return result, nil
+}
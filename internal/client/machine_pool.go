@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"fmt"
+	"time"
 )
 
 type MachinePoolRequest struct {
@@ -14,6 +15,10 @@ type MachinePoolResult struct {
 	Operation   *Operation   `json:"operation,omitempty"`
 }
 
+type MachinePoolsResult struct {
+	MachinePools []*MachinePool `json:"machine_pools"`
+}
+
 type MachinePool struct {
 	ID               int64  `json:"id"`
 	Name             string `json:"name"`
@@ -21,18 +26,36 @@ type MachinePool struct {
 	PatchVersion     string `json:"patch_version"`
 	Status           string `json:"status"`
 	NetworkProfileID *int64 `json:"network_profile_id"`
+
+	MaintenanceWindow *MaintenanceWindow `json:"maintenance_window"`
+	AutoUpgrade       *AutoUpgrade       `json:"auto_upgrade"`
+	NextMaintenanceAt *time.Time         `json:"next_maintenance_at"`
+
+	DeletionProtection bool `json:"deletion_protection"`
 }
 
 type MachinePoolCreateInput struct {
 	Name             string `json:"name"`
 	UserVersion      string `json:"user_version"`
 	NetworkProfileID *int64 `json:"network_profile_id"`
+
+	MaintenanceWindow *MaintenanceWindow `json:"maintenance_window,omitempty"`
+	AutoUpgrade       *AutoUpgrade       `json:"auto_upgrade,omitempty"`
+
+	DeletionProtection *bool `json:"deletion_protection,omitempty"`
 }
 
 type MachinePoolUpdateInput struct {
 	Name             string `json:"name"`
 	UserVersion      string `json:"user_version"`
 	NetworkProfileID *int64 `json:"network_profile_id"`
+
+	// MaintenanceWindow and AutoUpgrade replace the current settings, an empty value removes them and nil leaves them
+	// unchanged.
+	MaintenanceWindow *MaintenanceWindow `json:"maintenance_window,omitempty"`
+	AutoUpgrade       *AutoUpgrade       `json:"auto_upgrade,omitempty"`
+
+	DeletionProtection *bool `json:"deletion_protection,omitempty"`
 }
 
 func (c *Client) MachinePool() *MachinePoolRequest {
@@ -41,6 +64,25 @@ func (c *Client) MachinePool() *MachinePoolRequest {
 	}
 }
 
+func (mr *MachinePoolRequest) List(ctx context.Context, clusterId int64) (*MachinePoolsResult, *Error) {
+	clientRequest := &ClientRequest{
+		Path:   fmt.Sprintf("%s/%d/%s", "clusters", clusterId, "machine_pools"),
+		Result: &MachinePoolsResult{},
+	}
+
+	result, err := mr.client.Get(ctx, clientRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	machinePoolsResult, ok := result.(*MachinePoolsResult)
+	if !ok {
+		return nil, &ErrorTypeAssert
+	}
+
+	return machinePoolsResult, nil
+}
+
 func (mr *MachinePoolRequest) Get(ctx context.Context, clusterId int64, id int64) (*MachinePoolResult, *Error) {
 	subPath := fmt.Sprintf("%s/%d/%s/%d", "clusters", clusterId, "machine_pools", id)
 	clientRequest := &ClientRequest{
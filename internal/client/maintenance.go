@@ -0,0 +1,20 @@
+package client
+
+// MaintenanceWindow is the weekly time window in which meltcloud rolls out automatic upgrades. The empty value
+// removes the maintenance window on update.
+type MaintenanceWindow struct {
+	// Day of the week, e.g. saturday.
+	Day string `json:"day,omitempty"`
+	// StartTime is the start of the window in the form HH:MM.
+	StartTime     string `json:"start_time,omitempty"`
+	DurationHours int64  `json:"duration_hours,omitempty"`
+	// Timezone is an IANA time zone like Europe/Zurich.
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// AutoUpgrade configures which upgrades meltcloud rolls out automatically in the maintenance window. The empty value
+// removes the automatic upgrades on update, unlike Patch and Minor set to false.
+type AutoUpgrade struct {
+	Patch *bool `json:"patch,omitempty"`
+	Minor *bool `json:"minor,omitempty"`
+}
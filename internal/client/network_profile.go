@@ -14,6 +14,10 @@ type NetworkProfileResult struct {
 	Operation      *Operation      `json:"operation,omitempty"`
 }
 
+type NetworkProfilesResult struct {
+	NetworkProfiles []*NetworkProfile `json:"network_profiles"`
+}
+
 type NetworkProfile struct {
 	ID     int64  `json:"id"`
 	Name   string `json:"name"`
@@ -46,6 +50,25 @@ func (c *Client) NetworkProfile() *NetworkProfileRequest {
 	}
 }
 
+func (mr *NetworkProfileRequest) List(ctx context.Context) (*NetworkProfilesResult, *Error) {
+	clientRequest := &ClientRequest{
+		Path:   "network_profiles",
+		Result: &NetworkProfilesResult{},
+	}
+
+	result, err := mr.client.Get(ctx, clientRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	profilesResult, ok := result.(*NetworkProfilesResult)
+	if !ok {
+		return nil, &ErrorTypeAssert
+	}
+
+	return profilesResult, nil
+}
+
 func (mr *NetworkProfileRequest) Get(ctx context.Context, id int64) (*NetworkProfileResult, *Error) {
 	subPath := fmt.Sprintf("%s/%d", "network_profiles", id)
 	clientRequest := &ClientRequest{
@@ -0,0 +1,16 @@
+package client
+
+// ClusterOIDC is the OpenID Connect identity provider the API server of a cluster authenticates users with.
+type ClusterOIDC struct {
+	IssuerURL string `json:"issuer_url,omitempty"`
+	ClientID  string `json:"client_id,omitempty"`
+	// UsernameClaim is the claim used as the username, e.g. email.
+	UsernameClaim  string `json:"username_claim,omitempty"`
+	UsernamePrefix string `json:"username_prefix,omitempty"`
+	GroupsClaim    string `json:"groups_claim,omitempty"`
+	GroupsPrefix   string `json:"groups_prefix,omitempty"`
+	// RequiredClaims are claims that must be present in the ID token with the given value.
+	RequiredClaims map[string]string `json:"required_claims,omitempty"`
+	// CA is the PEM encoded CA certificate of the issuer, empty to use the system trust store.
+	CA string `json:"ca,omitempty"`
+}
@@ -0,0 +1,424 @@
+// Package export generates Terraform configuration for all objects of an existing organization. Every
+// resource is emitted together with an import block, so a whole organization can be adopted in one apply.
+package export
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"terraform-provider-meltcloud/internal/client"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Exporter walks an organization through the client and collects the generated blocks.
+type Exporter struct {
+	client *client.Client
+	file   *hclwrite.File
+
+	// names holds the labels already used per resource type
+	names map[string]map[string]bool
+
+	// refs maps "<resource type>/<id>" to the label of the generated resource, used to wire up references
+	refs map[string]string
+
+	clusters []*client.Cluster
+}
+
+func New(c *client.Client) *Exporter {
+	return &Exporter{
+		client: c,
+		file:   hclwrite.NewEmptyFile(),
+		names:  map[string]map[string]bool{},
+		refs:   map[string]string{},
+	}
+}
+
+// Generate exports all objects of the organization. Objects are exported in dependency order, so
+// references only point to resources that have already been generated.
+func (e *Exporter) Generate(ctx context.Context) (*hclwrite.File, error) {
+	steps := []func(ctx context.Context) error{
+		e.exportNetworkProfiles,
+		e.exportEnrollmentImages,
+		e.exportClusters,
+		e.exportMachines,
+		e.exportElasticFleets,
+		e.exportElasticQuotas,
+		e.exportElasticNodePools,
+	}
+
+	for _, step := range steps {
+		if err := step(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return e.file, nil
+}
+
+func (e *Exporter) exportNetworkProfiles(ctx context.Context) error {
+	result, err := e.client.NetworkProfile().List(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to list network profiles: %w", err)
+	}
+
+	for _, profile := range result.NetworkProfiles {
+		body := e.resource("meltcloud_network_profile", profile.Name, profile.ID, fmt.Sprintf("network_profiles/%d", profile.ID))
+		body.SetAttributeValue("name", cty.StringVal(profile.Name))
+
+		for _, link := range profile.Links {
+			body.AppendNewline()
+			linkBody := body.AppendNewBlock("link", nil).Body()
+			linkBody.SetAttributeValue("name", cty.StringVal(link.Name))
+			linkBody.SetAttributeValue("interfaces", stringList(link.Interfaces))
+			linkBody.SetAttributeValue("vlans", int64List(link.VLANs))
+			linkBody.SetAttributeValue("host_networking", cty.BoolVal(link.HostNetworking))
+			linkBody.SetAttributeValue("lacp", cty.BoolVal(link.LACP))
+			linkBody.SetAttributeValue("native_vlan", cty.BoolVal(link.NativeVLAN))
+		}
+	}
+
+	return nil
+}
+
+func (e *Exporter) exportEnrollmentImages(ctx context.Context) error {
+	result, err := e.client.EnrollmentImage().List(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to list enrollment images: %w", err)
+	}
+
+	for _, image := range result.EnrollmentImages {
+		body := e.resource("meltcloud_enrollment_image", image.Name, image.ID, fmt.Sprintf("enrollment_images/%d", image.ID))
+		body.SetAttributeValue("name", cty.StringVal(image.Name))
+		body.SetAttributeValue("expires_at", cty.StringVal(image.ExpiresAt.UTC().Format(time.RFC3339)))
+		body.SetAttributeValue("install_disk_device", cty.StringVal(image.InstallDiskDevice))
+		body.SetAttributeValue("install_disk_force_overwrite", cty.BoolVal(image.InstallDiskForceOverwrite))
+		if image.VLAN != nil {
+			body.SetAttributeValue("vlan", cty.NumberIntVal(*image.VLAN))
+		}
+		body.SetAttributeValue("enable_http", cty.BoolVal(image.EnableHTTP))
+	}
+
+	return nil
+}
+
+func (e *Exporter) exportClusters(ctx context.Context) error {
+	result, err := e.client.Cluster().List(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to list clusters: %w", err)
+	}
+
+	e.clusters = result.Clusters
+	for _, cluster := range result.Clusters {
+		body := e.resource("meltcloud_cluster", cluster.Name, cluster.ID, fmt.Sprintf("clusters/%d", cluster.ID))
+		body.SetAttributeValue("name", cty.StringVal(cluster.Name))
+		body.SetAttributeValue("version", cty.StringVal(cluster.UserVersion))
+		setOptionalString(body, "pod_cidr", cluster.PodCIDR)
+		setOptionalString(body, "service_cidr", cluster.ServiceCIDR)
+		setOptionalString(body, "dns_service_ip", cluster.DNSServiceIP)
+		body.SetAttributeValue("addon_kube_proxy", cty.BoolVal(cluster.AddonKubeProxy))
+		body.SetAttributeValue("addon_core_dns", cty.BoolVal(cluster.AddonCoreDNS))
+		setOptionalBool(body, "deletion_protection", cluster.DeletionProtection)
+		if len(cluster.APIServerAllowedCIDRs) > 0 {
+			body.SetAttributeValue("api_server_allowed_cidrs", stringList(cluster.APIServerAllowedCIDRs))
+		}
+		setOptionalBool(body, "api_server_private_endpoint", cluster.APIServerPrivateEndpoint)
+		if len(cluster.APIServerCertSANs) > 0 {
+			body.SetAttributeValue("api_server_cert_sans", stringList(cluster.APIServerCertSANs))
+		}
+		setOptionalString(body, "api_server_dns_name", cluster.APIServerDNSName)
+		setOptionalString(body, "service_account_issuer", cluster.ServiceAccountIssuer)
+
+		setMaintenanceWindow(body, cluster.MaintenanceWindow)
+		setAutoUpgrade(body, cluster.AutoUpgrade)
+		setOIDC(body, cluster.OIDC)
+
+		for _, addon := range cluster.Addons {
+			body.AppendNewline()
+			addonBody := body.AppendNewBlock("addon", nil).Body()
+			addonBody.SetAttributeValue("name", cty.StringVal(addon.Name))
+			setOptionalString(addonBody, "version", addon.Version)
+			setOptionalString(addonBody, "values", addon.Values)
+		}
+
+		if err := e.exportMachinePools(ctx, cluster); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *Exporter) exportMachinePools(ctx context.Context, cluster *client.Cluster) error {
+	result, err := e.client.MachinePool().List(ctx, cluster.ID)
+	if err != nil {
+		return fmt.Errorf("unable to list machine pools of cluster %s: %w", cluster.Name, err)
+	}
+
+	for _, pool := range result.MachinePools {
+		body := e.resource("meltcloud_machine_pool", cluster.Name+"_"+pool.Name, pool.ID, fmt.Sprintf("clusters/%d/machine_pools/%d", cluster.ID, pool.ID))
+		e.setReference(body, "cluster_id", "meltcloud_cluster", cluster.ID)
+		body.SetAttributeValue("name", cty.StringVal(pool.Name))
+		body.SetAttributeValue("version", cty.StringVal(pool.UserVersion))
+		if pool.NetworkProfileID != nil {
+			e.setReference(body, "network_profile_id", "meltcloud_network_profile", *pool.NetworkProfileID)
+		}
+		setOptionalBool(body, "deletion_protection", pool.DeletionProtection)
+
+		setMaintenanceWindow(body, pool.MaintenanceWindow)
+		setAutoUpgrade(body, pool.AutoUpgrade)
+	}
+
+	return nil
+}
+
+func (e *Exporter) exportMachines(ctx context.Context) error {
+	result, err := e.client.Machine().List(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to list machines: %w", err)
+	}
+
+	for _, machine := range result.Machines {
+		name := machine.Name
+		if name == "" {
+			name = "machine_" + machine.UUID.String()
+		}
+
+		body := e.resource("meltcloud_machine", name, machine.ID, fmt.Sprintf("machines/%d", machine.ID))
+		body.SetAttributeValue("uuid", cty.StringVal(machine.UUID.String()))
+		setOptionalString(body, "name", machine.Name)
+		if machine.MachinePoolID != 0 {
+			e.setReference(body, "machine_pool_id", "meltcloud_machine_pool", machine.MachinePoolID)
+		}
+
+		for _, label := range machine.Labels {
+			body.AppendNewline()
+			labelBody := body.AppendNewBlock("label", nil).Body()
+			labelBody.SetAttributeValue("key", cty.StringVal(label.Key))
+			labelBody.SetAttributeValue("value", cty.StringVal(label.Value))
+		}
+	}
+
+	return nil
+}
+
+func (e *Exporter) exportElasticFleets(ctx context.Context) error {
+	result, err := e.client.ElasticFleet().List(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to list elastic fleets: %w", err)
+	}
+
+	for _, fleet := range result.ElasticFleets {
+		body := e.resource("meltcloud_elastic_fleet", fleet.Name, fleet.ID, fmt.Sprintf("elastic_fleets/%d", fleet.ID))
+		body.SetAttributeValue("name", cty.StringVal(fleet.Name))
+		e.setReference(body, "cluster_id", "meltcloud_cluster", fleet.ClusterID)
+		setOptionalBool(body, "deletion_protection", fleet.DeletionProtection)
+	}
+
+	return nil
+}
+
+func (e *Exporter) exportElasticQuotas(ctx context.Context) error {
+	result, err := e.client.ElasticQuota().List(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to list elastic quotas: %w", err)
+	}
+
+	for _, quota := range result.ElasticQuotas {
+		body := e.resource("meltcloud_elastic_quota", quota.Name, quota.ID, fmt.Sprintf("elastic_quotas/%d", quota.ID))
+		body.SetAttributeValue("name", cty.StringVal(quota.Name))
+		body.SetAttributeValue("vcpus", cty.NumberIntVal(quota.VCPUs))
+		body.SetAttributeValue("disk_gib", cty.NumberIntVal(quota.DiskGiB))
+		body.SetAttributeValue("memory_mib", cty.NumberIntVal(quota.MemoryMiB))
+		e.setReference(body, "elastic_fleet_id", "meltcloud_elastic_fleet", quota.ElasticFleetID)
+		body.SetAttributeValue("consuming_organization_uuid", cty.StringVal(quota.ConsumingOrganizationUUID))
+	}
+
+	return nil
+}
+
+func (e *Exporter) exportElasticNodePools(ctx context.Context) error {
+	for _, cluster := range e.clusters {
+		result, err := e.client.ElasticNodePool().List(ctx, cluster.ID)
+		if err != nil {
+			return fmt.Errorf("unable to list elastic node pools of cluster %s: %w", cluster.Name, err)
+		}
+
+		for _, pool := range result.ElasticNodePools {
+			body := e.resource("meltcloud_elastic_node_pool", cluster.Name+"_"+pool.Name, pool.ID, fmt.Sprintf("clusters/%d/elastic_node_pools/%d", cluster.ID, pool.ID))
+			e.setReference(body, "cluster_id", "meltcloud_cluster", cluster.ID)
+			body.SetAttributeValue("name", cty.StringVal(pool.Name))
+			e.setReference(body, "elastic_quota_id", "meltcloud_elastic_quota", pool.ElasticQuotaID)
+			body.SetAttributeValue("version", cty.StringVal(pool.Version))
+			body.SetAttributeValue("node_count", cty.NumberIntVal(pool.NodeCount))
+			setOptionalBool(body, "deletion_protection", pool.DeletionProtection)
+
+			body.AppendNewline()
+			nodeConfig := body.AppendNewBlock("node_config", nil).Body()
+			nodeConfig.SetAttributeValue("vcpus", cty.NumberIntVal(pool.NodeVCPUs))
+			nodeConfig.SetAttributeValue("memory_mib", cty.NumberIntVal(pool.NodeMemoryMiB))
+			nodeConfig.SetAttributeValue("disk_gib", cty.NumberIntVal(pool.NodeDiskGiB))
+		}
+	}
+
+	return nil
+}
+
+// resource appends an import block and an empty resource block and returns the body of the resource block.
+func (e *Exporter) resource(resourceType string, name string, id int64, importID string) *hclwrite.Body {
+	label := e.label(resourceType, name)
+	e.refs[fmt.Sprintf("%s/%d", resourceType, id)] = label
+
+	root := e.file.Body()
+	if len(root.Blocks()) > 0 {
+		root.AppendNewline()
+	}
+
+	importBody := root.AppendNewBlock("import", nil).Body()
+	importBody.SetAttributeTraversal("to", hcl.Traversal{
+		hcl.TraverseRoot{Name: resourceType},
+		hcl.TraverseAttr{Name: label},
+	})
+	importBody.SetAttributeValue("id", cty.StringVal(importID))
+
+	root.AppendNewline()
+	return root.AppendNewBlock("resource", []string{resourceType, label}).Body()
+}
+
+// setReference sets the attribute to the id of the generated resource, or to the literal ID if the
+// object has not been exported (e.g. because it belongs to another organization).
+func (e *Exporter) setReference(body *hclwrite.Body, attribute string, resourceType string, id int64) {
+	label, ok := e.refs[fmt.Sprintf("%s/%d", resourceType, id)]
+	if !ok {
+		body.SetAttributeValue(attribute, cty.NumberIntVal(id))
+		return
+	}
+
+	body.SetAttributeTraversal(attribute, hcl.Traversal{
+		hcl.TraverseRoot{Name: resourceType},
+		hcl.TraverseAttr{Name: label},
+		hcl.TraverseAttr{Name: "id"},
+	})
+}
+
+var invalidLabelChars = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// label turns a name into a valid, unique resource label for the resource type.
+func (e *Exporter) label(resourceType string, name string) string {
+	base := strings.Trim(invalidLabelChars.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if base == "" || (base[0] >= '0' && base[0] <= '9') || base[0] == '-' {
+		base = "r_" + base
+	}
+
+	used, ok := e.names[resourceType]
+	if !ok {
+		used = map[string]bool{}
+		e.names[resourceType] = used
+	}
+
+	label := base
+	for i := 2; used[label]; i++ {
+		label = fmt.Sprintf("%s_%d", base, i)
+	}
+	used[label] = true
+
+	return label
+}
+
+// setMaintenanceWindow appends the maintenance_window block, which is removed on update if it is missing.
+func setMaintenanceWindow(body *hclwrite.Body, window *client.MaintenanceWindow) {
+	if window == nil {
+		return
+	}
+
+	body.AppendNewline()
+	windowBody := body.AppendNewBlock("maintenance_window", nil).Body()
+	windowBody.SetAttributeValue("day", cty.StringVal(window.Day))
+	windowBody.SetAttributeValue("start_time", cty.StringVal(window.StartTime))
+	if window.DurationHours != 0 {
+		windowBody.SetAttributeValue("duration_hours", cty.NumberIntVal(window.DurationHours))
+	}
+	setOptionalString(windowBody, "timezone", window.Timezone)
+}
+
+// setAutoUpgrade appends the auto_upgrade block, which is removed on update if it is missing.
+func setAutoUpgrade(body *hclwrite.Body, autoUpgrade *client.AutoUpgrade) {
+	if autoUpgrade == nil {
+		return
+	}
+
+	body.AppendNewline()
+	autoUpgradeBody := body.AppendNewBlock("auto_upgrade", nil).Body()
+	if autoUpgrade.Patch != nil {
+		autoUpgradeBody.SetAttributeValue("patch", cty.BoolVal(*autoUpgrade.Patch))
+	}
+	if autoUpgrade.Minor != nil {
+		autoUpgradeBody.SetAttributeValue("minor", cty.BoolVal(*autoUpgrade.Minor))
+	}
+}
+
+// setOIDC appends the oidc block, the cluster switches back to the meltcloud identity provider on update if it is
+// missing.
+func setOIDC(body *hclwrite.Body, oidc *client.ClusterOIDC) {
+	if oidc == nil {
+		return
+	}
+
+	body.AppendNewline()
+	oidcBody := body.AppendNewBlock("oidc", nil).Body()
+	oidcBody.SetAttributeValue("issuer_url", cty.StringVal(oidc.IssuerURL))
+	oidcBody.SetAttributeValue("client_id", cty.StringVal(oidc.ClientID))
+	setOptionalString(oidcBody, "username_claim", oidc.UsernameClaim)
+	setOptionalString(oidcBody, "username_prefix", oidc.UsernamePrefix)
+	setOptionalString(oidcBody, "groups_claim", oidc.GroupsClaim)
+	setOptionalString(oidcBody, "groups_prefix", oidc.GroupsPrefix)
+	if len(oidc.RequiredClaims) > 0 {
+		claims := map[string]cty.Value{}
+		for claim, value := range oidc.RequiredClaims {
+			claims[claim] = cty.StringVal(value)
+		}
+		oidcBody.SetAttributeValue("required_claims", cty.MapVal(claims))
+	}
+	setOptionalString(oidcBody, "ca", oidc.CA)
+}
+
+func setOptionalString(body *hclwrite.Body, attribute string, value string) {
+	if value != "" {
+		body.SetAttributeValue(attribute, cty.StringVal(value))
+	}
+}
+
+// setOptionalBool sets the attribute only if it is true, for attributes that default to false.
+func setOptionalBool(body *hclwrite.Body, attribute string, value bool) {
+	if value {
+		body.SetAttributeValue(attribute, cty.True)
+	}
+}
+
+func stringList(values []string) cty.Value {
+	if len(values) == 0 {
+		return cty.ListValEmpty(cty.String)
+	}
+
+	list := make([]cty.Value, 0, len(values))
+	for _, v := range values {
+		list = append(list, cty.StringVal(v))
+	}
+	return cty.ListVal(list)
+}
+
+func int64List(values []int64) cty.Value {
+	if len(values) == 0 {
+		return cty.ListValEmpty(cty.Number)
+	}
+
+	list := make([]cty.Value, 0, len(values))
+	for _, v := range values {
+		list = append(list, cty.NumberIntVal(v))
+	}
+	return cty.ListVal(list)
+}
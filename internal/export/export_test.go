@@ -0,0 +1,362 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"terraform-provider-meltcloud/internal/client"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+func TestLabel(t *testing.T) {
+	tests := []struct {
+		name         string
+		resourceType string
+		want         string
+	}{
+		{name: "melt01", resourceType: "meltcloud_cluster", want: "melt01"},
+		{name: "Melt 01", resourceType: "meltcloud_cluster", want: "melt_01"},
+		{name: "melt01", resourceType: "meltcloud_cluster", want: "melt01_2"},
+		{name: "MELT01", resourceType: "meltcloud_cluster", want: "melt01_3"},
+		{name: "melt01", resourceType: "meltcloud_machine_pool", want: "melt01"},
+		{name: "prod.eu-west/1", resourceType: "meltcloud_cluster", want: "prod_eu-west_1"},
+		{name: "  trimmed!  ", resourceType: "meltcloud_cluster", want: "trimmed"},
+		{name: "01-gpu", resourceType: "meltcloud_cluster", want: "r_01-gpu"},
+		{name: "-edge", resourceType: "meltcloud_cluster", want: "r_-edge"},
+		{name: "", resourceType: "meltcloud_machine", want: "r_"},
+		{name: "Zürich", resourceType: "meltcloud_cluster", want: "z_rich"},
+		{name: "___", resourceType: "meltcloud_machine", want: "r__2"},
+	}
+
+	e := New(nil)
+	for _, tt := range tests {
+		t.Run(tt.resourceType+"/"+tt.name, func(t *testing.T) {
+			got := e.label(tt.resourceType, tt.name)
+			if got != tt.want {
+				t.Errorf("label(%s, %q) = %q, want %q", tt.resourceType, tt.name, got, tt.want)
+			}
+			if !hclsyntax.ValidIdentifier(got) {
+				t.Errorf("label(%s, %q) = %q is not a valid identifier", tt.resourceType, tt.name, got)
+			}
+		})
+	}
+}
+
+func TestSetReference(t *testing.T) {
+	e := New(nil)
+	e.resource("meltcloud_cluster", "melt01", 1, "clusters/1")
+
+	tests := []struct {
+		name         string
+		resourceType string
+		id           int64
+		want         string
+	}{
+		{name: "exported", resourceType: "meltcloud_cluster", id: 1, want: "meltcloud_cluster.melt01.id"},
+		{name: "not exported", resourceType: "meltcloud_cluster", id: 2, want: "2"},
+		{name: "same ID of another type", resourceType: "meltcloud_network_profile", id: 1, want: "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := hclwrite.NewEmptyFile().Body()
+			e.setReference(body, "cluster_id", tt.resourceType, tt.id)
+
+			got := strings.TrimSpace(string(body.GetAttribute("cluster_id").Expr().BuildTokens(nil).Bytes()))
+			if got != tt.want {
+				t.Errorf("setReference(%s, %d) = %q, want %q", tt.resourceType, tt.id, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	vlan := int64(42)
+	profileID := int64(3)
+	enabled := true
+	disabled := false
+	machineUUID := uuid.MustParse("8e1bd1a6-5d52-4e1c-9b4a-3f7c2b6ad0f1")
+
+	responses := map[string]interface{}{
+		"network_profiles": client.NetworkProfilesResult{NetworkProfiles: []*client.NetworkProfile{
+			{ID: 3, Name: "Bonded", Links: []client.Link{{Name: "bond0", Interfaces: []string{"eth0", "eth1"}, VLANs: []int64{100}, LACP: true}}},
+		}},
+		"enrollment_images": client.EnrollmentImagesResult{EnrollmentImages: []*client.EnrollmentImage{
+			{ID: 4, Name: "default", ExpiresAt: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), InstallDiskDevice: "/dev/sda", VLAN: &vlan},
+		}},
+		"clusters": client.ClustersResult{Clusters: []*client.Cluster{
+			{
+				ID:             1,
+				Name:           "melt01",
+				UserVersion:    "1.31",
+				PodCIDR:        "10.36.0.0/16",
+				AddonKubeProxy: true,
+				AddonCoreDNS:   true,
+
+				DeletionProtection: true,
+
+				APIServerAllowedCIDRs:    []string{"192.0.2.0/24", "2001:db8::/32"},
+				APIServerPrivateEndpoint: true,
+				APIServerCertSANs:        []string{"lb.example.com", "192.0.2.10"},
+				APIServerDNSName:         "k8s.example.com",
+
+				OIDC: &client.ClusterOIDC{
+					IssuerURL:      "https://login.example.com",
+					ClientID:       "kubernetes",
+					UsernameClaim:  "email",
+					GroupsClaim:    "groups",
+					GroupsPrefix:   "oidc:",
+					RequiredClaims: map[string]string{"hd": "example.com", "aud": "kubernetes"},
+					CA:             "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n",
+				},
+				ServiceAccountIssuer: "https://oidc.example.com/melt01",
+				Addons: []client.ClusterAddon{
+					{Name: "cilium", Version: "1.16.1", Values: "ipam:\n  mode: kubernetes\n"},
+					{Name: "metrics-server"},
+				},
+				MaintenanceWindow: &client.MaintenanceWindow{Day: "saturday", StartTime: "02:00", DurationHours: 4, Timezone: "Europe/Zurich"},
+				AutoUpgrade:       &client.AutoUpgrade{Patch: &enabled, Minor: &disabled},
+			},
+			{ID: 2, Name: "Melt01", UserVersion: "1.30"},
+		}},
+		"clusters/1/machine_pools": client.MachinePoolsResult{MachinePools: []*client.MachinePool{
+			{ID: 5, Name: "workers", UserVersion: "1.31", NetworkProfileID: &profileID, AutoUpgrade: &client.AutoUpgrade{Patch: &disabled}},
+		}},
+		"clusters/2/machine_pools": client.MachinePoolsResult{},
+		"machines": client.MachinesResult{Machines: []*client.Machine{
+			{ID: 6, UUID: machineUUID, MachinePoolID: 5, Labels: []client.Label{{Key: "rack", Value: "a1"}}},
+			{ID: 7, UUID: machineUUID, Name: "spare", MachinePoolID: 99},
+		}},
+		"elastic_fleets": client.ElasticFleetsResult{ElasticFleets: []*client.ElasticFleet{
+			{ID: 8, Name: "fleet", ClusterID: 2, DeletionProtection: true},
+		}},
+		"elastic_quotas": client.ElasticQuotasResult{ElasticQuotas: []*client.ElasticQuota{
+			{ID: 9, Name: "team-a", VCPUs: 16, DiskGiB: 500, MemoryMiB: 65536, ElasticFleetID: 8, ConsumingOrganizationUUID: "a0c6d7e2-0000-0000-0000-000000000000"},
+		}},
+		"clusters/1/elastic_node_pools": client.ElasticNodePoolsResult{},
+		"clusters/2/elastic_node_pools": client.ElasticNodePoolsResult{ElasticNodePools: []*client.ElasticNodePool{
+			{ID: 10, Name: "gpu", ElasticQuotaID: 9, NodeCount: 2, NodeVCPUs: 4, NodeMemoryMiB: 8192, NodeDiskGiB: 50, Version: "1.30", DeletionProtection: true},
+		}},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, resourcePath, _ := strings.Cut(r.URL.Path, "/orgs/deadbeef/")
+		response, ok := responses[resourcePath]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	file, err := New(client.New(server.URL, "deadbeef", "dummy", nil)).Generate(context.Background())
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	got := string(file.Bytes())
+	if got != wantGenerated {
+		t.Errorf("Generate() =\n%s\nwant\n%s", got, wantGenerated)
+	}
+
+	if _, diags := hclsyntax.ParseConfig(file.Bytes(), "import.tf", hcl.InitialPos); diags.HasErrors() {
+		t.Errorf("Generate() produced invalid HCL: %s", diags)
+	}
+}
+
+const wantGenerated = `import {
+  to = meltcloud_network_profile.bonded
+  id = "network_profiles/3"
+}
+
+resource "meltcloud_network_profile" "bonded" {
+  name = "Bonded"
+
+  link {
+    name            = "bond0"
+    interfaces      = ["eth0", "eth1"]
+    vlans           = [100]
+    host_networking = false
+    lacp            = true
+    native_vlan     = false
+  }
+}
+
+import {
+  to = meltcloud_enrollment_image.default
+  id = "enrollment_images/4"
+}
+
+resource "meltcloud_enrollment_image" "default" {
+  name                         = "default"
+  expires_at                   = "2027-01-01T00:00:00Z"
+  install_disk_device          = "/dev/sda"
+  install_disk_force_overwrite = false
+  vlan                         = 42
+  enable_http                  = false
+}
+
+import {
+  to = meltcloud_cluster.melt01
+  id = "clusters/1"
+}
+
+resource "meltcloud_cluster" "melt01" {
+  name                        = "melt01"
+  version                     = "1.31"
+  pod_cidr                    = "10.36.0.0/16"
+  addon_kube_proxy            = true
+  addon_core_dns              = true
+  deletion_protection         = true
+  api_server_allowed_cidrs    = ["192.0.2.0/24", "2001:db8::/32"]
+  api_server_private_endpoint = true
+  api_server_cert_sans        = ["lb.example.com", "192.0.2.10"]
+  api_server_dns_name         = "k8s.example.com"
+  service_account_issuer      = "https://oidc.example.com/melt01"
+
+  maintenance_window {
+    day            = "saturday"
+    start_time     = "02:00"
+    duration_hours = 4
+    timezone       = "Europe/Zurich"
+  }
+
+  auto_upgrade {
+    patch = true
+    minor = false
+  }
+
+  oidc {
+    issuer_url     = "https://login.example.com"
+    client_id      = "kubernetes"
+    username_claim = "email"
+    groups_claim   = "groups"
+    groups_prefix  = "oidc:"
+    required_claims = {
+      aud = "kubernetes"
+      hd  = "example.com"
+    }
+    ca = "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n"
+  }
+
+  addon {
+    name    = "cilium"
+    version = "1.16.1"
+    values  = "ipam:\n  mode: kubernetes\n"
+  }
+
+  addon {
+    name = "metrics-server"
+  }
+}
+
+import {
+  to = meltcloud_machine_pool.melt01_workers
+  id = "clusters/1/machine_pools/5"
+}
+
+resource "meltcloud_machine_pool" "melt01_workers" {
+  cluster_id         = meltcloud_cluster.melt01.id
+  name               = "workers"
+  version            = "1.31"
+  network_profile_id = meltcloud_network_profile.bonded.id
+
+  auto_upgrade {
+    patch = false
+  }
+}
+
+import {
+  to = meltcloud_cluster.melt01_2
+  id = "clusters/2"
+}
+
+resource "meltcloud_cluster" "melt01_2" {
+  name             = "Melt01"
+  version          = "1.30"
+  addon_kube_proxy = false
+  addon_core_dns   = false
+}
+
+import {
+  to = meltcloud_machine.machine_8e1bd1a6-5d52-4e1c-9b4a-3f7c2b6ad0f1
+  id = "machines/6"
+}
+
+resource "meltcloud_machine" "machine_8e1bd1a6-5d52-4e1c-9b4a-3f7c2b6ad0f1" {
+  uuid            = "8e1bd1a6-5d52-4e1c-9b4a-3f7c2b6ad0f1"
+  machine_pool_id = meltcloud_machine_pool.melt01_workers.id
+
+  label {
+    key   = "rack"
+    value = "a1"
+  }
+}
+
+import {
+  to = meltcloud_machine.spare
+  id = "machines/7"
+}
+
+resource "meltcloud_machine" "spare" {
+  uuid            = "8e1bd1a6-5d52-4e1c-9b4a-3f7c2b6ad0f1"
+  name            = "spare"
+  machine_pool_id = 99
+}
+
+import {
+  to = meltcloud_elastic_fleet.fleet
+  id = "elastic_fleets/8"
+}
+
+resource "meltcloud_elastic_fleet" "fleet" {
+  name                = "fleet"
+  cluster_id          = meltcloud_cluster.melt01_2.id
+  deletion_protection = true
+}
+
+import {
+  to = meltcloud_elastic_quota.team-a
+  id = "elastic_quotas/9"
+}
+
+resource "meltcloud_elastic_quota" "team-a" {
+  name                        = "team-a"
+  vcpus                       = 16
+  disk_gib                    = 500
+  memory_mib                  = 65536
+  elastic_fleet_id            = meltcloud_elastic_fleet.fleet.id
+  consuming_organization_uuid = "a0c6d7e2-0000-0000-0000-000000000000"
+}
+
+import {
+  to = meltcloud_elastic_node_pool.melt01_gpu
+  id = "clusters/2/elastic_node_pools/10"
+}
+
+resource "meltcloud_elastic_node_pool" "melt01_gpu" {
+  cluster_id          = meltcloud_cluster.melt01_2.id
+  name                = "gpu"
+  elastic_quota_id    = meltcloud_elastic_quota.team-a.id
+  version             = "1.30"
+  node_count          = 2
+  deletion_protection = true
+
+  node_config {
+    vcpus      = 4
+    memory_mib = 8192
+    disk_gib   = 50
+  }
+}
+`
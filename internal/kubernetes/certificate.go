@@ -0,0 +1,48 @@
+package kubernetes
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"time"
+)
+
+// CertificateInfo are the details of a certificate relevant for tracking its expiry.
+type CertificateInfo struct {
+	Subject   string
+	NotBefore time.Time
+	NotAfter  time.Time
+	// SHA256Fingerprint is the hex encoded SHA-256 digest of the DER certificate, as shown by openssl x509 -fingerprint.
+	SHA256Fingerprint string
+}
+
+// DecodeCertificate decodes the base64 encoded PEM certificate of a *-data kubeconfig field. If the field contains a
+// bundle, the first certificate is returned.
+func DecodeCertificate(data string) (*CertificateInfo, error) {
+	pemBytes, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode certificate: %+v", err)
+	}
+
+	block, _ := pem.Decode(pemBytes)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("failed to decode certificate: no PEM certificate found")
+	}
+
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate: %+v", err)
+	}
+
+	fingerprint := sha256.Sum256(certificate.Raw)
+
+	return &CertificateInfo{
+		Subject:           certificate.Subject.String(),
+		NotBefore:         certificate.NotBefore.UTC(),
+		NotAfter:          certificate.NotAfter.UTC(),
+		SHA256Fingerprint: hex.EncodeToString(fingerprint[:]),
+	}, nil
+}
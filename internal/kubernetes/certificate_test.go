@@ -0,0 +1,102 @@
+package kubernetes
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testCertificate(t *testing.T, commonName string, notBefore time.Time, notAfter time.Time) []byte {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName, Organization: []string{"system:masters"}},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate() error = %v", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestDecodeCertificate(t *testing.T) {
+	notBefore := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	notAfter := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	admin := testCertificate(t, "kubernetes-admin", notBefore, notAfter)
+	expired := testCertificate(t, "expired", notBefore.AddDate(-2, 0, 0), notBefore.AddDate(-1, 0, 0))
+	ca := testCertificate(t, "kubernetes-ca", notBefore, notAfter.AddDate(9, 0, 0))
+
+	block, _ := pem.Decode(admin)
+	fingerprint := sha256.Sum256(block.Bytes)
+
+	encode := func(pemBytes ...[]byte) string {
+		var content []byte
+		for _, b := range pemBytes {
+			content = append(content, b...)
+		}
+		return base64.StdEncoding.EncodeToString(content)
+	}
+
+	tests := []struct {
+		name            string
+		data            string
+		wantSubject     string
+		wantNotAfter    time.Time
+		wantFingerprint string
+		wantErr         string
+	}{
+		{name: "certificate", data: encode(admin), wantSubject: "CN=kubernetes-admin,O=system:masters", wantNotAfter: notAfter, wantFingerprint: hex.EncodeToString(fingerprint[:])},
+		{name: "expired certificate", data: encode(expired), wantSubject: "CN=expired,O=system:masters", wantNotAfter: notBefore.AddDate(-1, 0, 0)},
+		{name: "bundle returns the first certificate", data: encode(admin, ca), wantSubject: "CN=kubernetes-admin,O=system:masters", wantNotAfter: notAfter},
+		{name: "not base64", data: "not base64!", wantErr: "failed to decode certificate"},
+		{name: "not PEM", data: base64.StdEncoding.EncodeToString([]byte("plain text")), wantErr: "no PEM certificate found"},
+		{name: "PEM key instead of a certificate", data: encode(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: []byte("key")})), wantErr: "no PEM certificate found"},
+		{name: "invalid DER", data: encode(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("garbage")})), wantErr: "failed to parse certificate"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeCertificate(tt.data)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("DecodeCertificate() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DecodeCertificate() error = %v", err)
+			}
+
+			if got.Subject != tt.wantSubject {
+				t.Errorf("DecodeCertificate() subject = %s, want %s", got.Subject, tt.wantSubject)
+			}
+			if !got.NotAfter.Equal(tt.wantNotAfter) || got.NotAfter.Location() != time.UTC {
+				t.Errorf("DecodeCertificate() not after = %s, want %s", got.NotAfter, tt.wantNotAfter)
+			}
+			if tt.wantFingerprint != "" && got.SHA256Fingerprint != tt.wantFingerprint {
+				t.Errorf("DecodeCertificate() fingerprint = %s, want %s", got.SHA256Fingerprint, tt.wantFingerprint)
+			}
+		})
+	}
+}
@@ -1,52 +1,122 @@
 package kubernetes
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+
 	"gopkg.in/yaml.v3"
 )
 
+// KubeConfig models a kubeconfig file as read by kubectl. Fields not modelled explicitly are kept in Extra,
+// so that a parsed kubeconfig can be written back without losing information.
 type KubeConfig struct {
-	Clusters       []clusterEntry         `yaml:"clusters"`
-	Contexts       []contextEntry         `yaml:"contexts,omitempty"`
-	Users          []userEntry            `yaml:"users"`
-	CurrentContext string                 `yaml:"current-context,omitempty"`
+	APIVersion     string                 `yaml:"apiVersion,omitempty"`
 	Kind           string                 `yaml:"kind,omitempty"`
 	Preferences    map[string]interface{} `yaml:"preferences,omitempty"`
+	Clusters       []NamedCluster         `yaml:"clusters"`
+	Contexts       []NamedContext         `yaml:"contexts,omitempty"`
+	Users          []NamedAuthInfo        `yaml:"users"`
+	CurrentContext string                 `yaml:"current-context,omitempty"`
+	Extra          map[string]interface{} `yaml:",inline"`
 }
 
-type contextEntry struct {
+type NamedContext struct {
 	Name    string  `yaml:"name"`
-	Context context `yaml:"context"`
+	Context Context `yaml:"context"`
 }
 
-type context struct {
-	Cluster   string `yaml:"cluster"`
-	User      string `yaml:"user"`
-	Namespace string `yaml:"namespace,omitempty"`
+type Context struct {
+	Cluster   string                 `yaml:"cluster"`
+	User      string                 `yaml:"user"`
+	Namespace string                 `yaml:"namespace,omitempty"`
+	Extra     map[string]interface{} `yaml:",inline"`
 }
 
-type clusterEntry struct {
+type NamedCluster struct {
 	Name    string  `yaml:"name"`
-	Cluster cluster `yaml:"cluster"`
+	Cluster Cluster `yaml:"cluster"`
+}
+
+type Cluster struct {
+	Server                   string                 `yaml:"server"`
+	TLSServerName            string                 `yaml:"tls-server-name,omitempty"`
+	InsecureSkipTLSVerify    bool                   `yaml:"insecure-skip-tls-verify,omitempty"`
+	CertificateAuthority     string                 `yaml:"certificate-authority,omitempty"`
+	CertificateAuthorityData string                 `yaml:"certificate-authority-data,omitempty"`
+	ProxyURL                 string                 `yaml:"proxy-url,omitempty"`
+	DisableCompression       bool                   `yaml:"disable-compression,omitempty"`
+	Extra                    map[string]interface{} `yaml:",inline"`
+}
+
+type NamedAuthInfo struct {
+	Name string   `yaml:"name"`
+	User AuthInfo `yaml:"user"`
+}
+
+type AuthInfo struct {
+	ClientCertificate     string                 `yaml:"client-certificate,omitempty"`
+	ClientCertificateData string                 `yaml:"client-certificate-data,omitempty"`
+	ClientKey             string                 `yaml:"client-key,omitempty"`
+	ClientKeyData         string                 `yaml:"client-key-data,omitempty"`
+	Token                 string                 `yaml:"token,omitempty"`
+	TokenFile             string                 `yaml:"tokenFile,omitempty"`
+	Username              string                 `yaml:"username,omitempty"`
+	Password              string                 `yaml:"password,omitempty"`
+	AuthProvider          *AuthProviderConfig    `yaml:"auth-provider,omitempty"`
+	Exec                  *ExecConfig            `yaml:"exec,omitempty"`
+	Extra                 map[string]interface{} `yaml:",inline"`
+}
+
+// AuthProviderConfig is the deprecated in-tree auth provider (e.g. oidc), still found in older kubeconfigs.
+type AuthProviderConfig struct {
+	Name   string            `yaml:"name"`
+	Config map[string]string `yaml:"config,omitempty"`
 }
 
-type cluster struct {
-	ClusterAuthorityData string `yaml:"certificate-authority-data"`
-	Server               string `yaml:"server"`
+// ExecConfig is a client-go credential plugin, e.g. kubectl oidc-login.
+type ExecConfig struct {
+	APIVersion         string       `yaml:"apiVersion"`
+	Command            string       `yaml:"command"`
+	Args               []string     `yaml:"args,omitempty"`
+	Env                []ExecEnvVar `yaml:"env,omitempty"`
+	InstallHint        string       `yaml:"installHint,omitempty"`
+	ProvideClusterInfo bool         `yaml:"provideClusterInfo,omitempty"`
+	InteractiveMode    string       `yaml:"interactiveMode,omitempty"`
 }
 
-type userEntry struct {
-	Name string `yaml:"name"`
-	User user   `yaml:"user"`
+type ExecEnvVar struct {
+	Name  string `yaml:"name"`
+	Value string `yaml:"value"`
 }
 
-type user struct {
-	ClientCertificateData string `yaml:"client-certificate-data"`
-	Token                 string `yaml:"token"`
-	ClientKeyData         string `yaml:"client-key-data"`
+// ResolvedContext is the cluster and user a context points to.
+type ResolvedContext struct {
+	Name        string
+	Namespace   string
+	ClusterName string
+	Cluster     Cluster
+	UserName    string
+	User        AuthInfo
 }
 
+// ParseKubeConfig parses and validates a kubeconfig.
 func ParseKubeConfig(config string) (*KubeConfig, error) {
+	kubeConfig, err := UnmarshalKubeConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := kubeConfig.Validate(); err != nil {
+		return nil, err
+	}
+
+	return kubeConfig, nil
+}
+
+// UnmarshalKubeConfig parses a kubeconfig without validating it. Like kubectl, it accepts a current-context or
+// contexts referencing entries that do not exist, e.g. after kubectl config delete-context.
+func UnmarshalKubeConfig(config string) (*KubeConfig, error) {
 	var kubeConfig KubeConfig
 
 	if err := yaml.Unmarshal([]byte(config), &kubeConfig); err != nil {
@@ -55,3 +125,158 @@ func ParseKubeConfig(config string) (*KubeConfig, error) {
 
 	return &kubeConfig, nil
 }
+
+// Validate checks that names are unique and that all contexts only reference clusters and users that exist.
+func (k *KubeConfig) Validate() error {
+	var errs []error
+
+	clusters := map[string]bool{}
+	for i, c := range k.Clusters {
+		if c.Name == "" {
+			errs = append(errs, fmt.Errorf("clusters[%d]: name is empty", i))
+		} else if clusters[c.Name] {
+			errs = append(errs, fmt.Errorf("clusters[%d]: duplicate cluster %q", i, c.Name))
+		}
+		if c.Cluster.Server == "" {
+			errs = append(errs, fmt.Errorf("clusters[%d]: server is empty", i))
+		}
+		clusters[c.Name] = true
+	}
+
+	users := map[string]bool{}
+	for i, u := range k.Users {
+		if u.Name == "" {
+			errs = append(errs, fmt.Errorf("users[%d]: name is empty", i))
+		} else if users[u.Name] {
+			errs = append(errs, fmt.Errorf("users[%d]: duplicate user %q", i, u.Name))
+		}
+		if u.User.Exec != nil && u.User.Exec.Command == "" {
+			errs = append(errs, fmt.Errorf("users[%d]: exec command is empty", i))
+		}
+		users[u.Name] = true
+	}
+
+	contexts := map[string]bool{}
+	for i, c := range k.Contexts {
+		if c.Name == "" {
+			errs = append(errs, fmt.Errorf("contexts[%d]: name is empty", i))
+		} else if contexts[c.Name] {
+			errs = append(errs, fmt.Errorf("contexts[%d]: duplicate context %q", i, c.Name))
+		}
+		if !clusters[c.Context.Cluster] {
+			errs = append(errs, fmt.Errorf("contexts[%d]: cluster %q not found", i, c.Context.Cluster))
+		}
+		if !users[c.Context.User] {
+			errs = append(errs, fmt.Errorf("contexts[%d]: user %q not found", i, c.Context.User))
+		}
+		contexts[c.Name] = true
+	}
+
+	if k.CurrentContext != "" && !contexts[k.CurrentContext] {
+		errs = append(errs, fmt.Errorf("current-context %q not found", k.CurrentContext))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid kubeconfig: %w", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// Resolve returns the cluster and user of the given context, or of the current-context if name is empty.
+// Kubeconfigs without contexts resolve if they contain exactly one cluster and one user.
+func (k *KubeConfig) Resolve(name string) (*ResolvedContext, error) {
+	if name == "" {
+		name = k.CurrentContext
+	}
+
+	if name == "" {
+		switch {
+		case len(k.Contexts) == 1:
+			name = k.Contexts[0].Name
+		case len(k.Contexts) == 0 && len(k.Clusters) == 1 && len(k.Users) == 1:
+			return &ResolvedContext{
+				ClusterName: k.Clusters[0].Name,
+				Cluster:     k.Clusters[0].Cluster,
+				UserName:    k.Users[0].Name,
+				User:        k.Users[0].User,
+			}, nil
+		case len(k.Clusters) == 0 || len(k.Users) == 0:
+			return nil, fmt.Errorf("kubeconfig does not contain any clusters or users")
+		default:
+			return nil, fmt.Errorf("kubeconfig has no current-context and multiple contexts, clusters or users to choose from")
+		}
+	}
+
+	for _, c := range k.Contexts {
+		if c.Name != name {
+			continue
+		}
+
+		cluster := k.cluster(c.Context.Cluster)
+		user := k.user(c.Context.User)
+		if cluster == nil || user == nil {
+			return nil, fmt.Errorf("context %q references a cluster or user that does not exist", name)
+		}
+
+		return &ResolvedContext{
+			Name:        c.Name,
+			Namespace:   c.Context.Namespace,
+			ClusterName: c.Context.Cluster,
+			Cluster:     *cluster,
+			UserName:    c.Context.User,
+			User:        *user,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("context %q not found in kubeconfig", name)
+}
+
+// Marshal serializes the kubeconfig back to YAML.
+func (k *KubeConfig) Marshal() (string, error) {
+	if k.APIVersion == "" {
+		k.APIVersion = "v1"
+	}
+	if k.Kind == "" {
+		k.Kind = "Config"
+	}
+
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(k); err != nil {
+		return "", fmt.Errorf("failed to marshal kubeconfig with error %+v", err)
+	}
+
+	return out.String(), nil
+}
+
+// BearerToken returns the static token of the user, falling back to the id-token of an oidc auth-provider.
+func (a *AuthInfo) BearerToken() string {
+	if a.Token != "" {
+		return a.Token
+	}
+
+	if a.AuthProvider != nil && a.AuthProvider.Name == "oidc" {
+		return a.AuthProvider.Config["id-token"]
+	}
+
+	return ""
+}
+
+func (k *KubeConfig) cluster(name string) *Cluster {
+	if i := k.clusterIndex(name); i >= 0 {
+		return &k.Clusters[i].Cluster
+	}
+
+	return nil
+}
+
+func (k *KubeConfig) user(name string) *AuthInfo {
+	if i := k.userIndex(name); i >= 0 {
+		return &k.Users[i].User
+	}
+
+	return nil
+}
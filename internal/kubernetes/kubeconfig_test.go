@@ -0,0 +1,253 @@
+package kubernetes
+
+import (
+	"strings"
+	"testing"
+)
+
+const testKubeConfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: melt01
+  cluster:
+    server: https://melt01.example.com
+    certificate-authority-data: Y2E=
+- name: other
+  cluster:
+    server: https://other.example.com
+users:
+- name: melt01-admin
+  user:
+    token: secret
+- name: other-user
+  user:
+    exec:
+      apiVersion: client.authentication.k8s.io/v1
+      command: kubectl
+      args: [oidc-login, get-token]
+contexts:
+- name: melt01
+  context:
+    cluster: melt01
+    user: melt01-admin
+    namespace: kube-system
+- name: other
+  context:
+    cluster: other
+    user: other-user
+current-context: other
+`
+
+func TestParseKubeConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr string
+	}{
+		{name: "valid", config: testKubeConfig},
+		{name: "invalid YAML", config: "clusters: [", wantErr: "failed to unmarshal YAML"},
+		{name: "empty cluster name", config: `
+clusters:
+- cluster: {server: https://a}
+users: []
+`, wantErr: "clusters[0]: name is empty"},
+		{name: "duplicate cluster", config: `
+clusters:
+- {name: a, cluster: {server: https://a}}
+- {name: a, cluster: {server: https://b}}
+users: []
+`, wantErr: `clusters[1]: duplicate cluster "a"`},
+		{name: "empty server", config: `
+clusters:
+- {name: a, cluster: {}}
+users: []
+`, wantErr: "clusters[0]: server is empty"},
+		{name: "duplicate user", config: `
+clusters: []
+users:
+- {name: u, user: {token: a}}
+- {name: u, user: {token: b}}
+`, wantErr: `users[1]: duplicate user "u"`},
+		{name: "empty exec command", config: `
+clusters: []
+users:
+- {name: u, user: {exec: {apiVersion: client.authentication.k8s.io/v1}}}
+`, wantErr: "users[0]: exec command is empty"},
+		{name: "duplicate context", config: `
+clusters:
+- {name: a, cluster: {server: https://a}}
+users:
+- {name: u, user: {token: a}}
+contexts:
+- {name: c, context: {cluster: a, user: u}}
+- {name: c, context: {cluster: a, user: u}}
+`, wantErr: `contexts[1]: duplicate context "c"`},
+		{name: "context with missing cluster", config: `
+clusters: []
+users:
+- {name: u, user: {token: a}}
+contexts:
+- {name: c, context: {cluster: a, user: u}}
+`, wantErr: `contexts[0]: cluster "a" not found`},
+		{name: "context with missing user", config: `
+clusters:
+- {name: a, cluster: {server: https://a}}
+users: []
+contexts:
+- {name: c, context: {cluster: a, user: u}}
+`, wantErr: `contexts[0]: user "u" not found`},
+		{name: "missing current-context", config: `
+clusters: []
+users: []
+current-context: c
+`, wantErr: `current-context "c" not found`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseKubeConfig(tt.config)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ParseKubeConfig() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseKubeConfig() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUnmarshalKubeConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr string
+	}{
+		{name: "valid", config: testKubeConfig},
+		{name: "invalid YAML", config: "clusters: [", wantErr: "failed to unmarshal YAML"},
+		{name: "deleted current-context", config: `
+clusters:
+- {name: a, cluster: {server: https://a}}
+users:
+- {name: u, user: {token: a}}
+current-context: deleted
+`},
+		{name: "context with missing cluster and user", config: `
+clusters: []
+users: []
+contexts:
+- {name: c, context: {cluster: a, user: u}}
+`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := UnmarshalKubeConfig(tt.config)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("UnmarshalKubeConfig() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("UnmarshalKubeConfig() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestKubeConfigResolve(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      string
+		context     string
+		wantName    string
+		wantCluster string
+		wantUser    string
+		wantErr     bool
+	}{
+		{name: "by name", config: testKubeConfig, context: "melt01", wantName: "melt01", wantCluster: "melt01", wantUser: "melt01-admin"},
+		{name: "current-context", config: testKubeConfig, wantName: "other", wantCluster: "other", wantUser: "other-user"},
+		{name: "not found", config: testKubeConfig, context: "missing", wantErr: true},
+		{name: "single context", config: `
+clusters:
+- {name: a, cluster: {server: https://a}}
+- {name: b, cluster: {server: https://b}}
+users:
+- {name: u, user: {token: a}}
+contexts:
+- {name: c, context: {cluster: b, user: u}}
+`, wantName: "c", wantCluster: "b", wantUser: "u"},
+		{name: "no contexts with one cluster and user", config: `
+clusters:
+- {name: a, cluster: {server: https://a}}
+users:
+- {name: u, user: {token: a}}
+`, wantCluster: "a", wantUser: "u"},
+		{name: "no contexts with multiple clusters", config: `
+clusters:
+- {name: a, cluster: {server: https://a}}
+- {name: b, cluster: {server: https://b}}
+users:
+- {name: u, user: {token: a}}
+`, wantErr: true},
+		{name: "multiple contexts without current-context", config: `
+clusters:
+- {name: a, cluster: {server: https://a}}
+users:
+- {name: u, user: {token: a}}
+contexts:
+- {name: c, context: {cluster: a, user: u}}
+- {name: d, context: {cluster: a, user: u}}
+`, wantErr: true},
+		{name: "empty", config: "clusters: []\nusers: []\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kubeConfig, err := ParseKubeConfig(tt.config)
+			if err != nil {
+				t.Fatalf("ParseKubeConfig() error = %v", err)
+			}
+
+			got, err := kubeConfig.Resolve(tt.context)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Resolve(%q) error = %v, wantErr %v", tt.context, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Name != tt.wantName || got.ClusterName != tt.wantCluster || got.UserName != tt.wantUser {
+				t.Errorf("Resolve(%q) = %s/%s/%s, want %s/%s/%s", tt.context,
+					got.Name, got.ClusterName, got.UserName, tt.wantName, tt.wantCluster, tt.wantUser)
+			}
+		})
+	}
+}
+
+func TestKubeConfigMarshal(t *testing.T) {
+	kubeConfig, err := ParseKubeConfig(testKubeConfig)
+	if err != nil {
+		t.Fatalf("ParseKubeConfig() error = %v", err)
+	}
+
+	out, err := kubeConfig.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	roundTrip, err := ParseKubeConfig(out)
+	if err != nil {
+		t.Fatalf("ParseKubeConfig() of marshalled kubeconfig error = %v", err)
+	}
+
+	resolved, err := roundTrip.Resolve("melt01")
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if resolved.Namespace != "kube-system" || resolved.Cluster.CertificateAuthorityData != "Y2E=" || resolved.User.BearerToken() != "secret" {
+		t.Errorf("Marshal() lost fields, resolved %+v", resolved)
+	}
+}
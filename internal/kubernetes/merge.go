@@ -0,0 +1,172 @@
+package kubernetes
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Entries are a cluster, user and the context binding them, as merged into a kubeconfig file.
+type Entries struct {
+	ContextName string
+	ClusterName string
+	UserName    string
+	Namespace   string
+	Cluster     Cluster
+	User        AuthInfo
+}
+
+// Upsert adds the entries to the kubeconfig, replacing existing clusters, users and contexts of the same name.
+func (k *KubeConfig) Upsert(e Entries) {
+	namedCluster := NamedCluster{Name: e.ClusterName, Cluster: e.Cluster}
+	if i := k.clusterIndex(e.ClusterName); i >= 0 {
+		k.Clusters[i] = namedCluster
+	} else {
+		k.Clusters = append(k.Clusters, namedCluster)
+	}
+
+	namedUser := NamedAuthInfo{Name: e.UserName, User: e.User}
+	if i := k.userIndex(e.UserName); i >= 0 {
+		k.Users[i] = namedUser
+	} else {
+		k.Users = append(k.Users, namedUser)
+	}
+
+	namedContext := NamedContext{Name: e.ContextName, Context: Context{Cluster: e.ClusterName, User: e.UserName, Namespace: e.Namespace}}
+	if i := k.contextIndex(e.ContextName); i >= 0 {
+		k.Contexts[i] = namedContext
+	} else {
+		k.Contexts = append(k.Contexts, namedContext)
+	}
+}
+
+// Remove deletes the context and, unless still referenced by another context, its cluster and user.
+// The current-context is cleared if it pointed to the removed context.
+func (k *KubeConfig) Remove(contextName string, clusterName string, userName string) {
+	if i := k.contextIndex(contextName); i >= 0 {
+		k.Contexts = append(k.Contexts[:i], k.Contexts[i+1:]...)
+	}
+
+	if k.CurrentContext == contextName {
+		k.CurrentContext = ""
+	}
+
+	clusterInUse, userInUse := false, false
+	for _, c := range k.Contexts {
+		clusterInUse = clusterInUse || c.Context.Cluster == clusterName
+		userInUse = userInUse || c.Context.User == userName
+	}
+
+	if i := k.clusterIndex(clusterName); i >= 0 && !clusterInUse {
+		k.Clusters = append(k.Clusters[:i], k.Clusters[i+1:]...)
+	}
+
+	if i := k.userIndex(userName); i >= 0 && !userInUse {
+		k.Users = append(k.Users[:i], k.Users[i+1:]...)
+	}
+}
+
+// Conflicts returns the clusters, users and contexts of the same name as the entries that Upsert would overwrite
+// with a different value, e.g. `context "melt01"`. Names of previously written entries in ours are not reported.
+func (k *KubeConfig) Conflicts(e Entries, ours Entries) []string {
+	var conflicts []string
+
+	if i := k.clusterIndex(e.ClusterName); e.ClusterName != ours.ClusterName && i >= 0 && !sameYAML(k.Clusters[i].Cluster, e.Cluster) {
+		conflicts = append(conflicts, fmt.Sprintf("cluster %q", e.ClusterName))
+	}
+
+	if i := k.userIndex(e.UserName); e.UserName != ours.UserName && i >= 0 && !sameYAML(k.Users[i].User, e.User) {
+		conflicts = append(conflicts, fmt.Sprintf("user %q", e.UserName))
+	}
+
+	context := Context{Cluster: e.ClusterName, User: e.UserName, Namespace: e.Namespace}
+	if i := k.contextIndex(e.ContextName); e.ContextName != ours.ContextName && i >= 0 && !sameYAML(k.Contexts[i].Context, context) {
+		conflicts = append(conflicts, fmt.Sprintf("context %q", e.ContextName))
+	}
+
+	return conflicts
+}
+
+// Entries returns the context with its cluster and user, ok is false if one of them does not exist.
+func (k *KubeConfig) Entries(contextName string, clusterName string, userName string) (e Entries, ok bool) {
+	contextIndex, clusterIndex, userIndex := k.contextIndex(contextName), k.clusterIndex(clusterName), k.userIndex(userName)
+	if contextIndex < 0 || clusterIndex < 0 || userIndex < 0 {
+		return Entries{}, false
+	}
+
+	context := k.Contexts[contextIndex].Context
+	if context.Cluster != clusterName || context.User != userName {
+		return Entries{}, false
+	}
+
+	return Entries{
+		ContextName: contextName,
+		ClusterName: clusterName,
+		UserName:    userName,
+		Namespace:   context.Namespace,
+		Cluster:     k.Clusters[clusterIndex].Cluster,
+		User:        k.Users[userIndex].User,
+	}, true
+}
+
+// Checksum returns the SHA256 of the cluster and user of the entries, to detect changed server addresses or
+// credentials without storing them.
+func (e Entries) Checksum() string {
+	content, _ := yaml.Marshal(struct {
+		Cluster Cluster  `yaml:"cluster"`
+		User    AuthInfo `yaml:"user"`
+	}{e.Cluster, e.User})
+
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// HasContext returns whether a context with the given name exists.
+func (k *KubeConfig) HasContext(name string) bool {
+	return k.contextIndex(name) >= 0
+}
+
+// IsEmpty returns whether the kubeconfig has no clusters, users or contexts left.
+func (k *KubeConfig) IsEmpty() bool {
+	return len(k.Clusters) == 0 && len(k.Users) == 0 && len(k.Contexts) == 0
+}
+
+func (k *KubeConfig) clusterIndex(name string) int {
+	for i := range k.Clusters {
+		if k.Clusters[i].Name == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (k *KubeConfig) userIndex(name string) int {
+	for i := range k.Users {
+		if k.Users[i].Name == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (k *KubeConfig) contextIndex(name string) int {
+	for i := range k.Contexts {
+		if k.Contexts[i].Name == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// sameYAML compares values by their YAML representation, so that nil and empty Extra maps are equal.
+func sameYAML(a interface{}, b interface{}) bool {
+	aYAML, aErr := yaml.Marshal(a)
+	bYAML, bErr := yaml.Marshal(b)
+
+	return aErr == nil && bErr == nil && string(aYAML) == string(bYAML)
+}
@@ -0,0 +1,302 @@
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+)
+
+func testEntries(name string, server string) Entries {
+	return Entries{
+		ContextName: name,
+		ClusterName: name,
+		UserName:    name + "-admin",
+		Cluster:     Cluster{Server: server},
+		User:        AuthInfo{Token: "secret"},
+	}
+}
+
+func entryNames(k *KubeConfig) (clusters []string, users []string, contexts []string) {
+	for _, c := range k.Clusters {
+		clusters = append(clusters, c.Name)
+	}
+	for _, u := range k.Users {
+		users = append(users, u.Name)
+	}
+	for _, c := range k.Contexts {
+		contexts = append(contexts, c.Name)
+	}
+
+	return clusters, users, contexts
+}
+
+func TestKubeConfigUpsert(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       string
+		entries      Entries
+		wantClusters []string
+		wantUsers    []string
+		wantContexts []string
+	}{
+		{
+			name:         "empty",
+			config:       "clusters: []\nusers: []\n",
+			entries:      testEntries("melt01", "https://melt01"),
+			wantClusters: []string{"melt01"},
+			wantUsers:    []string{"melt01-admin"},
+			wantContexts: []string{"melt01"},
+		},
+		{
+			name:         "preserves foreign entries",
+			config:       testKubeConfig,
+			entries:      testEntries("melt02", "https://melt02"),
+			wantClusters: []string{"melt01", "other", "melt02"},
+			wantUsers:    []string{"melt01-admin", "other-user", "melt02-admin"},
+			wantContexts: []string{"melt01", "other", "melt02"},
+		},
+		{
+			name:         "replaces entries of the same name in place",
+			config:       testKubeConfig,
+			entries:      testEntries("melt01", "https://melt01.new"),
+			wantClusters: []string{"melt01", "other"},
+			wantUsers:    []string{"melt01-admin", "other-user"},
+			wantContexts: []string{"melt01", "other"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kubeConfig, err := ParseKubeConfig(tt.config)
+			if err != nil {
+				t.Fatalf("ParseKubeConfig() error = %v", err)
+			}
+			before, _ := ParseKubeConfig(tt.config)
+
+			kubeConfig.Upsert(tt.entries)
+
+			clusters, users, contexts := entryNames(kubeConfig)
+			if !reflect.DeepEqual(clusters, tt.wantClusters) || !reflect.DeepEqual(users, tt.wantUsers) || !reflect.DeepEqual(contexts, tt.wantContexts) {
+				t.Errorf("Upsert() = %v/%v/%v, want %v/%v/%v", clusters, users, contexts, tt.wantClusters, tt.wantUsers, tt.wantContexts)
+			}
+			if kubeConfig.CurrentContext != before.CurrentContext {
+				t.Errorf("Upsert() changed current-context to %q", kubeConfig.CurrentContext)
+			}
+			if err := kubeConfig.Validate(); err != nil {
+				t.Errorf("Upsert() produced an invalid kubeconfig: %v", err)
+			}
+
+			resolved, err := kubeConfig.Resolve(tt.entries.ContextName)
+			if err != nil {
+				t.Fatalf("Resolve() error = %v", err)
+			}
+			if resolved.Cluster.Server != tt.entries.Cluster.Server {
+				t.Errorf("Resolve() server = %q, want %q", resolved.Cluster.Server, tt.entries.Cluster.Server)
+			}
+
+			if other := kubeConfig.cluster("other"); before.cluster("other") != nil && !reflect.DeepEqual(other, before.cluster("other")) {
+				t.Errorf("Upsert() modified foreign cluster: %+v", other)
+			}
+			if other := kubeConfig.user("other-user"); before.user("other-user") != nil && !reflect.DeepEqual(other, before.user("other-user")) {
+				t.Errorf("Upsert() modified foreign user: %+v", other)
+			}
+		})
+	}
+}
+
+func TestKubeConfigRemove(t *testing.T) {
+	tests := []struct {
+		name               string
+		config             string
+		context            string
+		cluster            string
+		user               string
+		wantClusters       []string
+		wantUsers          []string
+		wantContexts       []string
+		wantCurrentContext string
+	}{
+		{
+			name:               "preserves foreign entries",
+			config:             testKubeConfig,
+			context:            "melt01",
+			cluster:            "melt01",
+			user:               "melt01-admin",
+			wantClusters:       []string{"other"},
+			wantUsers:          []string{"other-user"},
+			wantContexts:       []string{"other"},
+			wantCurrentContext: "other",
+		},
+		{
+			name:         "clears current-context",
+			config:       testKubeConfig,
+			context:      "other",
+			cluster:      "other",
+			user:         "other-user",
+			wantClusters: []string{"melt01"},
+			wantUsers:    []string{"melt01-admin"},
+			wantContexts: []string{"melt01"},
+		},
+		{
+			name: "keeps cluster and user referenced by another context",
+			config: `
+clusters:
+- {name: a, cluster: {server: https://a}}
+users:
+- {name: u, user: {token: a}}
+contexts:
+- {name: c, context: {cluster: a, user: u}}
+- {name: d, context: {cluster: a, user: u, namespace: kube-system}}
+current-context: d
+`,
+			context:            "c",
+			cluster:            "a",
+			user:               "u",
+			wantClusters:       []string{"a"},
+			wantUsers:          []string{"u"},
+			wantContexts:       []string{"d"},
+			wantCurrentContext: "d",
+		},
+		{
+			name:               "missing entries",
+			config:             testKubeConfig,
+			context:            "missing",
+			cluster:            "missing",
+			user:               "missing",
+			wantClusters:       []string{"melt01", "other"},
+			wantUsers:          []string{"melt01-admin", "other-user"},
+			wantContexts:       []string{"melt01", "other"},
+			wantCurrentContext: "other",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kubeConfig, err := ParseKubeConfig(tt.config)
+			if err != nil {
+				t.Fatalf("ParseKubeConfig() error = %v", err)
+			}
+
+			kubeConfig.Remove(tt.context, tt.cluster, tt.user)
+
+			clusters, users, contexts := entryNames(kubeConfig)
+			if !reflect.DeepEqual(clusters, tt.wantClusters) || !reflect.DeepEqual(users, tt.wantUsers) || !reflect.DeepEqual(contexts, tt.wantContexts) {
+				t.Errorf("Remove() = %v/%v/%v, want %v/%v/%v", clusters, users, contexts, tt.wantClusters, tt.wantUsers, tt.wantContexts)
+			}
+			if kubeConfig.CurrentContext != tt.wantCurrentContext {
+				t.Errorf("Remove() current-context = %q, want %q", kubeConfig.CurrentContext, tt.wantCurrentContext)
+			}
+			if err := kubeConfig.Validate(); err != nil {
+				t.Errorf("Remove() produced an invalid kubeconfig: %v", err)
+			}
+		})
+	}
+}
+
+func TestKubeConfigConflicts(t *testing.T) {
+	identical := testEntries("melt01", "https://melt01.example.com")
+	identical.Namespace = "kube-system"
+	identical.Cluster.CertificateAuthorityData = "Y2E="
+
+	changed := identical
+	changed.Cluster.Server = "https://melt01.new"
+
+	tests := []struct {
+		name    string
+		entries Entries
+		ours    Entries
+		want    []string
+	}{
+		{name: "new names", entries: testEntries("melt02", "https://melt02")},
+		{name: "identical entries", entries: identical},
+		{name: "different cluster", entries: changed, want: []string{`cluster "melt01"`}},
+		{name: "different cluster written by us", entries: changed, ours: identical},
+		{name: "all different", entries: Entries{
+			ContextName: "other",
+			ClusterName: "other",
+			UserName:    "other-user",
+			Namespace:   "default",
+			Cluster:     Cluster{Server: "https://melt01"},
+			User:        AuthInfo{Token: "secret"},
+		}, want: []string{`cluster "other"`, `user "other-user"`, `context "other"`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kubeConfig, err := ParseKubeConfig(testKubeConfig)
+			if err != nil {
+				t.Fatalf("ParseKubeConfig() error = %v", err)
+			}
+
+			if got := kubeConfig.Conflicts(tt.entries, tt.ours); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Conflicts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKubeConfigEntries(t *testing.T) {
+	written := testEntries("melt01", "https://melt01.example.com")
+	written.Namespace = "kube-system"
+	written.Cluster.CertificateAuthorityData = "Y2E="
+
+	tests := []struct {
+		name        string
+		contextName string
+		clusterName string
+		userName    string
+		want        Entries
+		wantOK      bool
+	}{
+		{name: "written entries", contextName: "melt01", clusterName: "melt01", userName: "melt01-admin", want: written, wantOK: true},
+		{name: "missing context", contextName: "melt02", clusterName: "melt01", userName: "melt01-admin"},
+		{name: "missing user", contextName: "melt01", clusterName: "melt01", userName: "melt02-admin"},
+		{name: "context of other entries", contextName: "melt01", clusterName: "other", userName: "other-user"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kubeConfig, err := ParseKubeConfig(testKubeConfig)
+			if err != nil {
+				t.Fatalf("ParseKubeConfig() error = %v", err)
+			}
+
+			got, ok := kubeConfig.Entries(tt.contextName, tt.clusterName, tt.userName)
+			if ok != tt.wantOK || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Entries() = %+v, %t, want %+v, %t", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestEntriesChecksum(t *testing.T) {
+	entries := testEntries("melt01", "https://melt01.example.com")
+
+	renamed := testEntries("melt02", "https://melt01.example.com")
+	if entries.Checksum() != renamed.Checksum() {
+		t.Errorf("Checksum() differs for entries with the same cluster and user under other names")
+	}
+
+	rotated := entries
+	rotated.User = AuthInfo{ClientCertificateData: "bmV3", ClientKeyData: "a2V5"}
+	if entries.Checksum() == rotated.Checksum() {
+		t.Errorf("Checksum() is the same after the credentials changed")
+	}
+
+	// entries read back from a written file have the checksum of the written entries
+	kubeConfig := &KubeConfig{}
+	kubeConfig.Upsert(entries)
+	content, err := kubeConfig.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	read, err := UnmarshalKubeConfig(content)
+	if err != nil {
+		t.Fatalf("UnmarshalKubeConfig() error = %v", err)
+	}
+
+	readEntries, ok := read.Entries(entries.ContextName, entries.ClusterName, entries.UserName)
+	if !ok || readEntries.Checksum() != entries.Checksum() {
+		t.Errorf("Checksum() of the read entries = %s, want %s", readEntries.Checksum(), entries.Checksum())
+	}
+}
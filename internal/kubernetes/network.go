@@ -0,0 +1,129 @@
+package kubernetes
+
+import (
+	"fmt"
+	"math/big"
+	"net/netip"
+)
+
+// clusterDNSIPOffset is the offset of the cluster DNS service IP in the service CIDR, meltcloud derives
+// dns_service_ip like kubeadm, e.g. 10.96.0.10 for 10.96.0.0/16.
+const clusterDNSIPOffset = 10
+
+// Nodes get a /24 (IPv4) or /64 (IPv6) of the pod CIDR, so it must be at least as large as a single node range.
+const (
+	MaxPodCIDRPrefixLength4 = 24
+	MaxPodCIDRPrefixLength6 = 64
+)
+
+// The service CIDR must be large enough to contain the cluster DNS service IP.
+const (
+	MaxServiceCIDRPrefixLength4 = 28
+	MaxServiceCIDRPrefixLength6 = 124
+)
+
+// ParseCIDR parses a CIDR like 10.96.0.0/16, host bits are cleared.
+func ParseCIDR(cidr string) (netip.Prefix, error) {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid CIDR %q: %s", cidr, err)
+	}
+
+	return prefix.Masked(), nil
+}
+
+// ClusterDNSIP returns the default cluster DNS service IP of the service CIDR.
+func ClusterDNSIP(serviceCIDR netip.Prefix) (netip.Addr, error) {
+	value := new(big.Int).Add(addrToBig(serviceCIDR.Masked().Addr()), big.NewInt(clusterDNSIPOffset))
+	if value.Cmp(lastAddrBig(serviceCIDR)) > 0 {
+		// checked before the conversion, the sum overflows the address width at the end of the address space
+		return netip.Addr{}, fmt.Errorf("service CIDR %s is too small to contain the cluster DNS service IP", serviceCIDR)
+	}
+
+	return addrFromBig(value, serviceCIDR.Addr().Is4()), nil
+}
+
+// OverlappingCIDRs returns the indexes of the first two overlapping CIDRs, ok is false if none overlap.
+func OverlappingCIDRs(cidrs []netip.Prefix) (i int, j int, ok bool) {
+	for i = range cidrs {
+		for j = i + 1; j < len(cidrs); j++ {
+			if cidrs[i].Overlaps(cidrs[j]) {
+				return i, j, true
+			}
+		}
+	}
+
+	return 0, 0, false
+}
+
+// NextFreeCIDR returns the first CIDR of the given prefix length in pool that does not overlap any of the used CIDRs.
+func NextFreeCIDR(used []netip.Prefix, pool netip.Prefix, bits int) (netip.Prefix, error) {
+	if bits < pool.Bits() || bits > pool.Addr().BitLen() {
+		return netip.Prefix{}, fmt.Errorf("prefix length must be between %d and %d for pool %s", pool.Bits(), pool.Addr().BitLen(), pool)
+	}
+
+	is4 := pool.Addr().Is4()
+	start := addrToBig(pool.Addr())
+	poolEnd := lastAddrBig(pool)
+	blockSize := new(big.Int).Lsh(big.NewInt(1), uint(pool.Addr().BitLen()-bits))
+
+	candidate := new(big.Int).Set(start)
+	for new(big.Int).Add(candidate, blockSize).Cmp(new(big.Int).Add(poolEnd, big.NewInt(1))) <= 0 {
+		prefix := netip.PrefixFrom(addrFromBig(candidate, is4), bits)
+
+		var overlapping *netip.Prefix
+		for k := range used {
+			if used[k].Overlaps(prefix) {
+				overlapping = &used[k]
+				break
+			}
+		}
+
+		if overlapping == nil {
+			return prefix, nil
+		}
+
+		// continue with the first aligned block after the overlapping CIDR
+		next := new(big.Int).Sub(new(big.Int).Add(lastAddrBig(*overlapping), big.NewInt(1)), start)
+		next.Add(next, new(big.Int).Sub(blockSize, big.NewInt(1)))
+		next.Div(next, blockSize)
+		next.Mul(next, blockSize)
+		next.Add(next, start)
+
+		if next.Cmp(candidate) <= 0 {
+			next.Add(candidate, blockSize)
+		}
+		candidate = next
+	}
+
+	return netip.Prefix{}, fmt.Errorf("no free /%d left in %s", bits, pool)
+}
+
+func addrToBig(addr netip.Addr) *big.Int {
+	bytes := addr.AsSlice()
+	return new(big.Int).SetBytes(bytes)
+}
+
+// addrFromBig converts value to an address, callers must make sure value fits the address width.
+func addrFromBig(value *big.Int, is4 bool) netip.Addr {
+	size := 16
+	if is4 {
+		size = 4
+	}
+	if value.Sign() < 0 || value.BitLen() > size*8 {
+		return netip.Addr{}
+	}
+
+	bytes := make([]byte, size)
+	value.FillBytes(bytes)
+
+	addr, _ := netip.AddrFromSlice(bytes)
+	return addr
+}
+
+func lastAddrBig(prefix netip.Prefix) *big.Int {
+	hostBits := uint(prefix.Addr().BitLen() - prefix.Bits())
+	size := new(big.Int).Lsh(big.NewInt(1), hostBits)
+
+	return new(big.Int).Sub(new(big.Int).Add(addrToBig(prefix.Masked().Addr()), size), big.NewInt(1))
+}
@@ -0,0 +1,147 @@
+package kubernetes
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func TestParseCIDR(t *testing.T) {
+	tests := []struct {
+		cidr    string
+		want    string
+		wantErr bool
+	}{
+		{cidr: "10.96.0.0/16", want: "10.96.0.0/16"},
+		{cidr: "10.96.12.1/16", want: "10.96.0.0/16"},
+		{cidr: "fd00:10:96::1/112", want: "fd00:10:96::/112"},
+		{cidr: "10.96.0.0", wantErr: true},
+		{cidr: "10.96.0.0/33", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			got, err := ParseCIDR(tt.cidr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCIDR(%q) error = %v, wantErr %v", tt.cidr, err, tt.wantErr)
+			}
+			if !tt.wantErr && got.String() != tt.want {
+				t.Errorf("ParseCIDR(%q) = %s, want %s", tt.cidr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClusterDNSIP(t *testing.T) {
+	tests := []struct {
+		serviceCIDR string
+		want        string
+		wantErr     bool
+	}{
+		{serviceCIDR: "10.96.0.0/16", want: "10.96.0.10"},
+		{serviceCIDR: "10.96.0.0/28", want: "10.96.0.10"},
+		{serviceCIDR: "10.96.0.16/28", want: "10.96.0.26"},
+		{serviceCIDR: "10.96.0.0/29", wantErr: true},
+		{serviceCIDR: "fd00:10:96::/112", want: "fd00:10:96::a"},
+		{serviceCIDR: "fd00:10:96::/125", wantErr: true},
+		{serviceCIDR: "255.255.255.240/28", want: "255.255.255.250"},
+		{serviceCIDR: "255.255.255.248/29", wantErr: true},
+		{serviceCIDR: "255.255.255.255/32", wantErr: true},
+		{serviceCIDR: "ffff:ffff:ffff:ffff:ffff:ffff:ffff:fff0/124", want: "ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffa"},
+		{serviceCIDR: "ffff:ffff:ffff:ffff:ffff:ffff:ffff:fff8/125", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.serviceCIDR, func(t *testing.T) {
+			got, err := ClusterDNSIP(netip.MustParsePrefix(tt.serviceCIDR))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ClusterDNSIP(%s) error = %v, wantErr %v", tt.serviceCIDR, err, tt.wantErr)
+			}
+			if !tt.wantErr && got.String() != tt.want {
+				t.Errorf("ClusterDNSIP(%s) = %s, want %s", tt.serviceCIDR, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOverlappingCIDRs(t *testing.T) {
+	tests := []struct {
+		name   string
+		cidrs  []string
+		wantI  int
+		wantJ  int
+		wantOK bool
+	}{
+		{name: "empty"},
+		{name: "disjoint", cidrs: []string{"10.36.0.0/16", "10.96.0.0/16", "192.168.0.0/24"}},
+		{name: "adjacent", cidrs: []string{"10.0.0.0/25", "10.0.0.128/25"}},
+		{name: "contained", cidrs: []string{"10.36.0.0/16", "10.0.0.0/8"}, wantI: 0, wantJ: 1, wantOK: true},
+		{name: "first pair", cidrs: []string{"10.36.0.0/16", "10.96.0.0/16", "10.96.128.0/17", "10.36.0.0/24"}, wantI: 0, wantJ: 3, wantOK: true},
+		{name: "IPv6", cidrs: []string{"fd00::/48", "fd00:0:0:1::/64"}, wantI: 0, wantJ: 1, wantOK: true},
+		{name: "IPv4 and IPv6", cidrs: []string{"10.0.0.0/8", "fd00::/8"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prefixes := make([]netip.Prefix, 0, len(tt.cidrs))
+			for _, cidr := range tt.cidrs {
+				prefixes = append(prefixes, netip.MustParsePrefix(cidr))
+			}
+
+			i, j, ok := OverlappingCIDRs(prefixes)
+			if ok != tt.wantOK || (ok && (i != tt.wantI || j != tt.wantJ)) {
+				t.Errorf("OverlappingCIDRs(%v) = %d, %d, %t, want %d, %d, %t", tt.cidrs, i, j, ok, tt.wantI, tt.wantJ, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestNextFreeCIDR(t *testing.T) {
+	tests := []struct {
+		name    string
+		used    []string
+		pool    string
+		bits    int
+		want    string
+		wantErr bool
+	}{
+		{name: "empty pool", pool: "10.0.0.0/8", bits: 16, want: "10.0.0.0/16"},
+		{name: "first block used", used: []string{"10.0.0.0/16"}, pool: "10.0.0.0/8", bits: 16, want: "10.1.0.0/16"},
+		{name: "gap between used", used: []string{"10.0.0.0/16", "10.2.0.0/16"}, pool: "10.0.0.0/8", bits: 16, want: "10.1.0.0/16"},
+		{name: "used range smaller than the block", used: []string{"10.0.1.0/24"}, pool: "10.0.0.0/16", bits: 22, want: "10.0.4.0/22"},
+		{name: "used range not aligned to the block", used: []string{"10.0.0.0/23", "10.0.2.0/24"}, pool: "10.0.0.0/16", bits: 22, want: "10.0.4.0/22"},
+		{name: "used range larger than the block", used: []string{"10.0.0.0/14"}, pool: "10.0.0.0/8", bits: 16, want: "10.4.0.0/16"},
+		{name: "used range outside the pool", used: []string{"192.168.0.0/16", "10.1.0.0/16"}, pool: "10.0.0.0/8", bits: 16, want: "10.0.0.0/16"},
+		{name: "used range starting before the pool", used: []string{"10.0.0.0/9"}, pool: "10.64.0.0/10", bits: 16, wantErr: true},
+		{name: "used range covering the pool", used: []string{"10.0.0.0/8"}, pool: "10.64.0.0/10", bits: 16, wantErr: true},
+		{name: "pool not aligned to the block", pool: "10.0.0.128/25", bits: 26, want: "10.0.0.128/26"},
+		{name: "pool exhausted", used: []string{"10.0.0.0/25", "10.0.0.128/25"}, pool: "10.0.0.0/24", bits: 25, wantErr: true},
+		{name: "last block free", used: []string{"10.0.0.0/25", "10.0.0.128/26"}, pool: "10.0.0.0/24", bits: 26, want: "10.0.0.192/26"},
+		{name: "block larger than the pool", pool: "10.0.0.0/16", bits: 8, wantErr: true},
+		{name: "prefix length too long", pool: "10.0.0.0/16", bits: 33, wantErr: true},
+		{name: "IPv6", used: []string{"fd00::/64"}, pool: "fd00::/48", bits: 64, want: "fd00:0:0:1::/64"},
+		{name: "IPv6 unaligned used range", used: []string{"fd00::/112", "fd00::1:0/112"}, pool: "fd00::/64", bits: 108, want: "fd00::10:0/108"},
+		{name: "IPv6 used range outside the pool", used: []string{"fd01::/64"}, pool: "fd00::/48", bits: 64, want: "fd00::/64"},
+		{name: "last block of the address space", used: []string{"255.255.255.0/25"}, pool: "255.255.255.0/24", bits: 25, want: "255.255.255.128/25"},
+		{name: "address space exhausted", used: []string{"255.255.255.0/25", "255.255.255.128/25"}, pool: "255.255.255.0/24", bits: 26, wantErr: true},
+		{name: "single address at the end of the address space", used: []string{"255.255.255.254/32"}, pool: "255.255.255.254/31", bits: 32, want: "255.255.255.255/32"},
+		{name: "IPv6 address space exhausted", used: []string{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:ff00/120"}, pool: "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ff00/120", bits: 125, wantErr: true},
+		{name: "IPv4 used ranges in an IPv6 pool", used: []string{"10.0.0.0/8"}, pool: "fd00::/48", bits: 64, want: "fd00::/64"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			used := make([]netip.Prefix, 0, len(tt.used))
+			for _, cidr := range tt.used {
+				used = append(used, netip.MustParsePrefix(cidr))
+			}
+
+			got, err := NextFreeCIDR(used, netip.MustParsePrefix(tt.pool), tt.bits)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NextFreeCIDR(%v, %s, %d) = %s, %v, wantErr %v", tt.used, tt.pool, tt.bits, got, err, tt.wantErr)
+			}
+			if !tt.wantErr && got.String() != tt.want {
+				t.Errorf("NextFreeCIDR(%v, %s, %d) = %s, want %s", tt.used, tt.pool, tt.bits, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// OIDCLoginCommand is the default command of the kubelogin credential plugin, invoked as kubectl oidc-login.
+const OIDCLoginCommand = "kubectl"
+
+// OIDCConfig are the OIDC settings of a kubeconfig user, as passed to kubelogin or the oidc auth-provider.
+type OIDCConfig struct {
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	// ExtraScopes are requested in addition to openid.
+	ExtraScopes []string
+	// ExtraArgs are all other flags passed to kubelogin with their values, e.g. --grant-type password.
+	ExtraArgs []string
+}
+
+// OIDC returns the OIDC settings of the user, nil if the user does not authenticate with OIDC. Both kubelogin exec
+// plugins (kubectl oidc-login get-token or kubelogin get-token) and the legacy oidc auth-provider are supported.
+func (a *AuthInfo) OIDC() *OIDCConfig {
+	if a.AuthProvider != nil && a.AuthProvider.Name == "oidc" {
+		oidc := &OIDCConfig{
+			IssuerURL:    a.AuthProvider.Config["idp-issuer-url"],
+			ClientID:     a.AuthProvider.Config["client-id"],
+			ClientSecret: a.AuthProvider.Config["client-secret"],
+		}
+		if scopes := a.AuthProvider.Config["extra-scopes"]; scopes != "" {
+			oidc.ExtraScopes = strings.Split(scopes, ",")
+		}
+
+		return oidc
+	}
+
+	if a.Exec == nil {
+		return nil
+	}
+
+	oidc := &OIDCConfig{}
+	flags := false
+	for i := 0; i < len(a.Exec.Args); i++ {
+		arg := a.Exec.Args[i]
+		if !strings.HasPrefix(arg, "--") {
+			// skip the leading subcommands like oidc-login and get-token, later arguments are the values of flags
+			// without =, e.g. --grant-type password
+			if flags {
+				oidc.ExtraArgs = append(oidc.ExtraArgs, arg)
+			}
+			continue
+		}
+		flags = true
+
+		name, value, hasValue := strings.Cut(strings.TrimPrefix(arg, "--"), "=")
+		if !hasValue && isOIDCFlag(name) && i+1 < len(a.Exec.Args) {
+			i++
+			value = a.Exec.Args[i]
+		}
+
+		switch name {
+		case "oidc-issuer-url":
+			oidc.IssuerURL = value
+		case "oidc-client-id":
+			oidc.ClientID = value
+		case "oidc-client-secret":
+			oidc.ClientSecret = value
+		case "oidc-extra-scope":
+			oidc.ExtraScopes = append(oidc.ExtraScopes, strings.Split(value, ",")...)
+		default:
+			oidc.ExtraArgs = append(oidc.ExtraArgs, arg)
+		}
+	}
+
+	if oidc.IssuerURL == "" {
+		return nil
+	}
+
+	return oidc
+}
+
+// LoginArgs returns the kubelogin get-token arguments for these settings, without the oidc-login subcommand.
+func (o *OIDCConfig) LoginArgs() []string {
+	args := []string{"get-token", "--oidc-issuer-url=" + o.IssuerURL, "--oidc-client-id=" + o.ClientID}
+
+	if o.ClientSecret != "" {
+		args = append(args, "--oidc-client-secret="+o.ClientSecret)
+	}
+
+	for _, scope := range o.ExtraScopes {
+		args = append(args, "--oidc-extra-scope="+scope)
+	}
+
+	return append(args, o.ExtraArgs...)
+}
+
+// OIDCLoginExec returns an exec plugin stanza running kubelogin with these settings. With the default command kubectl,
+// the oidc-login subcommand is added, any other command is expected to be a standalone kubelogin binary.
+// If args is not empty, it is used as is instead.
+func (o *OIDCConfig) OIDCLoginExec(command string, args []string, env map[string]string) *ExecConfig {
+	if command == "" {
+		command = OIDCLoginCommand
+	}
+
+	if len(args) == 0 {
+		args = o.LoginArgs()
+		if command == OIDCLoginCommand {
+			args = append([]string{"oidc-login"}, args...)
+		}
+	}
+
+	exec := &ExecConfig{
+		APIVersion:      "client.authentication.k8s.io/v1",
+		Command:         command,
+		Args:            args,
+		InteractiveMode: "IfAvailable",
+	}
+
+	names := make([]string, 0, len(env))
+	for name := range env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		exec.Env = append(exec.Env, ExecEnvVar{Name: name, Value: env[name]})
+	}
+
+	return exec
+}
+
+// SetExec replaces the credentials of the user with the exec plugin.
+func (k *KubeConfig) SetExec(userName string, exec *ExecConfig) error {
+	user := k.user(userName)
+	if user == nil {
+		return fmt.Errorf("user %q not found in kubeconfig", userName)
+	}
+
+	*user = AuthInfo{Exec: exec}
+
+	return nil
+}
+
+func isOIDCFlag(name string) bool {
+	switch name {
+	case "oidc-issuer-url", "oidc-client-id", "oidc-client-secret", "oidc-extra-scope":
+		return true
+	}
+
+	return false
+}
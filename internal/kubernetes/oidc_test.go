@@ -0,0 +1,100 @@
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAuthInfoOIDC(t *testing.T) {
+	tests := []struct {
+		name string
+		user AuthInfo
+		want *OIDCConfig
+	}{
+		{name: "token", user: AuthInfo{Token: "secret"}},
+		{name: "exec without issuer", user: AuthInfo{Exec: &ExecConfig{Command: "aws", Args: []string{"eks", "get-token"}}}},
+		{
+			name: "kubectl oidc-login",
+			user: AuthInfo{Exec: &ExecConfig{Command: "kubectl", Args: []string{
+				"oidc-login", "get-token", "--oidc-issuer-url=https://issuer", "--oidc-client-id=melt", "--oidc-extra-scope=email,groups",
+			}}},
+			want: &OIDCConfig{IssuerURL: "https://issuer", ClientID: "melt", ExtraScopes: []string{"email", "groups"}},
+		},
+		{
+			name: "values separated by spaces",
+			user: AuthInfo{Exec: &ExecConfig{Command: "kubelogin", Args: []string{
+				"get-token", "--oidc-issuer-url", "https://issuer", "--oidc-client-id", "melt", "--oidc-client-secret", "s3cret",
+				"--oidc-extra-scope", "email", "--oidc-extra-scope", "groups",
+			}}},
+			want: &OIDCConfig{IssuerURL: "https://issuer", ClientID: "melt", ClientSecret: "s3cret", ExtraScopes: []string{"email", "groups"}},
+		},
+		{
+			name: "extra flags with values separated by spaces",
+			user: AuthInfo{Exec: &ExecConfig{Command: "kubectl", Args: []string{
+				"oidc-login", "get-token", "--oidc-issuer-url=https://issuer", "--grant-type", "password",
+				"--certificate-authority", "/etc/ca.pem", "--skip-open-browser", "--oidc-client-id", "melt", "--listen-address=127.0.0.1:8000",
+			}}},
+			want: &OIDCConfig{IssuerURL: "https://issuer", ClientID: "melt", ExtraArgs: []string{
+				"--grant-type", "password", "--certificate-authority", "/etc/ca.pem", "--skip-open-browser", "--listen-address=127.0.0.1:8000",
+			}},
+		},
+		{
+			name: "auth-provider",
+			user: AuthInfo{AuthProvider: &AuthProviderConfig{Name: "oidc", Config: map[string]string{
+				"idp-issuer-url": "https://issuer", "client-id": "melt", "client-secret": "s3cret", "extra-scopes": "email,groups",
+			}}},
+			want: &OIDCConfig{IssuerURL: "https://issuer", ClientID: "melt", ClientSecret: "s3cret", ExtraScopes: []string{"email", "groups"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.user.OIDC(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("OIDC() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOIDCConfigOIDCLoginExec(t *testing.T) {
+	oidc := &OIDCConfig{IssuerURL: "https://issuer", ClientID: "melt", ExtraArgs: []string{"--grant-type", "password"}}
+
+	tests := []struct {
+		name     string
+		command  string
+		args     []string
+		wantArgs []string
+	}{
+		{
+			name:     "kubectl",
+			wantArgs: []string{"oidc-login", "get-token", "--oidc-issuer-url=https://issuer", "--oidc-client-id=melt", "--grant-type", "password"},
+		},
+		{
+			name:     "kubelogin",
+			command:  "kubelogin",
+			wantArgs: []string{"get-token", "--oidc-issuer-url=https://issuer", "--oidc-client-id=melt", "--grant-type", "password"},
+		},
+		{
+			name:     "custom args",
+			command:  "kubelogin",
+			args:     []string{"get-token", "--token-cache-dir=/tmp"},
+			wantArgs: []string{"get-token", "--token-cache-dir=/tmp"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exec := oidc.OIDCLoginExec(tt.command, tt.args, nil)
+			if !reflect.DeepEqual(exec.Args, tt.wantArgs) {
+				t.Errorf("OIDCLoginExec() args = %v, want %v", exec.Args, tt.wantArgs)
+			}
+
+			// the rendered exec plugin is read back with the same settings
+			if tt.args == nil {
+				if got := (&AuthInfo{Exec: exec}).OIDC(); !reflect.DeepEqual(got, oidc) {
+					t.Errorf("OIDC() of the rendered exec = %+v, want %+v", got, oidc)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package kubernetes
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// MaxKubeletSkew is the number of minor versions a kubelet may be older than the kube-apiserver,
+// see https://kubernetes.io/releases/version-skew-policy/#kubelet.
+const MaxKubeletSkew = 3
+
+// versionPattern matches 1.30, v1.30.2 and 1.30.2-meltcloud.1, pre-release and build suffixes are ignored.
+var versionPattern = regexp.MustCompile(`^v?(\d+)\.(\d+)(?:\.(\d+))?(?:[-+].*)?$`)
+
+// Version is a Kubernetes version. A minor version like 1.30 has a Patch of 0.
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+func ParseVersion(version string) (*Version, error) {
+	matches := versionPattern.FindStringSubmatch(version)
+	if matches == nil {
+		return nil, fmt.Errorf("invalid Kubernetes version %q, expected a version like 1.30 or 1.30.2", version)
+	}
+
+	var numbers [3]int
+	for i, match := range matches[1:4] {
+		if match == "" {
+			continue
+		}
+
+		number, err := strconv.Atoi(match)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Kubernetes version %q: %w", version, err)
+		}
+		numbers[i] = number
+	}
+
+	return &Version{Major: numbers[0], Minor: numbers[1], Patch: numbers[2]}, nil
+}
+
+// Compare returns -1 if v is older than other, 1 if it is newer and 0 if both are equal.
+func (v *Version) Compare(other *Version) int {
+	for _, d := range []int{v.Major - other.Major, v.Minor - other.Minor, v.Patch - other.Patch} {
+		if d < 0 {
+			return -1
+		}
+		if d > 0 {
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// MinorVersion returns the version without the patch, e.g. 1.30.
+func (v *Version) MinorVersion() string {
+	return fmt.Sprintf("%d.%d", v.Major, v.Minor)
+}
+
+// MinorsBehind returns how many minor versions v is older than other, negative if it is newer.
+// Versions of different major versions are never comparable this way, this returns an error.
+func (v *Version) MinorsBehind(other *Version) (int, error) {
+	if v.Major != other.Major {
+		return 0, fmt.Errorf("versions %s and %s have different major versions", v.MinorVersion(), other.MinorVersion())
+	}
+
+	return other.Minor - v.Minor, nil
+}
+
+// CheckKubeletSkew returns an error if a kubelet of the given version may not run against a control plane of the
+// given version: the kubelet must not be newer than the kube-apiserver and at most MaxKubeletSkew minor versions older.
+func CheckKubeletSkew(controlPlane *Version, kubelet *Version) error {
+	behind, err := kubelet.MinorsBehind(controlPlane)
+	if err != nil {
+		return err
+	}
+
+	if behind < 0 {
+		return fmt.Errorf("kubelet %s is newer than the control plane %s", kubelet.MinorVersion(), controlPlane.MinorVersion())
+	}
+
+	if behind > MaxKubeletSkew {
+		return fmt.Errorf("kubelet %s is %d minor versions older than the control plane %s, at most %d are supported",
+			kubelet.MinorVersion(), behind, controlPlane.MinorVersion(), MaxKubeletSkew)
+	}
+
+	return nil
+}
+
+// CheckUpgrade returns an error if a component may not be changed from one version to another: downgrades and
+// upgrades skipping a minor version are not supported. Patch versions are ignored.
+func CheckUpgrade(from *Version, to *Version) error {
+	behind, err := from.MinorsBehind(to)
+	if err != nil {
+		return err
+	}
+
+	if behind < 0 {
+		return fmt.Errorf("downgrading from %s to %s is not supported", from.MinorVersion(), to.MinorVersion())
+	}
+
+	if behind > 1 {
+		return fmt.Errorf("upgrading from %s to %s skips minor versions, upgrade one minor version at a time", from.MinorVersion(), to.MinorVersion())
+	}
+
+	return nil
+}
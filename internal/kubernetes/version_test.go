@@ -0,0 +1,142 @@
+package kubernetes
+
+import (
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		want    Version
+		wantErr bool
+	}{
+		{version: "1.30", want: Version{Major: 1, Minor: 30}},
+		{version: "v1.30.2", want: Version{Major: 1, Minor: 30, Patch: 2}},
+		{version: "1.30.2-meltcloud.1", want: Version{Major: 1, Minor: 30, Patch: 2}},
+		{version: "1.30.2+build", want: Version{Major: 1, Minor: 30, Patch: 2}},
+		{version: "", wantErr: true},
+		{version: "1", wantErr: true},
+		{version: "1.x", wantErr: true},
+		{version: "version 1.30", wantErr: true},
+		{version: "1.30.2.1", wantErr: true},
+		{version: "1.99999999999999999999", wantErr: true},
+		{version: "99999999999999999999.30", wantErr: true},
+		{version: "1.30.99999999999999999999", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, err := ParseVersion(tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseVersion(%q) error = %v, wantErr %v", tt.version, err, tt.wantErr)
+			}
+			if !tt.wantErr && *got != tt.want {
+				t.Errorf("ParseVersion(%q) = %+v, want %+v", tt.version, *got, tt.want)
+			}
+		})
+	}
+}
+
+func mustParseVersion(t *testing.T, version string) *Version {
+	t.Helper()
+
+	v, err := ParseVersion(version)
+	if err != nil {
+		t.Fatalf("ParseVersion(%q) error = %v", version, err)
+	}
+
+	return v
+}
+
+func TestVersionCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "1.30", b: "1.30.0", want: 0},
+		{a: "1.30.1", b: "1.30.2", want: -1},
+		{a: "1.31", b: "1.30.9", want: 1},
+		{a: "1.9", b: "1.10", want: -1},
+		{a: "2.0", b: "1.30", want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"_"+tt.b, func(t *testing.T) {
+			if got := mustParseVersion(t, tt.a).Compare(mustParseVersion(t, tt.b)); got != tt.want {
+				t.Errorf("Compare(%s, %s) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersionMinorsBehind(t *testing.T) {
+	tests := []struct {
+		v, other string
+		want     int
+		wantErr  bool
+	}{
+		{v: "1.30.5", other: "1.30.1", want: 0},
+		{v: "1.28", other: "1.31", want: 3},
+		{v: "1.31", other: "1.30", want: -1},
+		{v: "1.30", other: "2.0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.v+"_"+tt.other, func(t *testing.T) {
+			got, err := mustParseVersion(t, tt.v).MinorsBehind(mustParseVersion(t, tt.other))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MinorsBehind(%s, %s) error = %v, wantErr %v", tt.v, tt.other, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("MinorsBehind(%s, %s) = %d, want %d", tt.v, tt.other, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckKubeletSkew(t *testing.T) {
+	tests := []struct {
+		controlPlane, kubelet string
+		wantErr               bool
+	}{
+		{controlPlane: "1.30", kubelet: "1.30"},
+		{controlPlane: "1.30.1", kubelet: "1.30.5"},
+		{controlPlane: "1.31", kubelet: "1.28"},
+		{controlPlane: "1.32", kubelet: "1.28", wantErr: true},
+		{controlPlane: "1.30", kubelet: "1.31", wantErr: true},
+		{controlPlane: "2.0", kubelet: "1.30", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.controlPlane+"_"+tt.kubelet, func(t *testing.T) {
+			err := CheckKubeletSkew(mustParseVersion(t, tt.controlPlane), mustParseVersion(t, tt.kubelet))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckKubeletSkew(%s, %s) error = %v, wantErr %v", tt.controlPlane, tt.kubelet, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckUpgrade(t *testing.T) {
+	tests := []struct {
+		from, to string
+		wantErr  bool
+	}{
+		{from: "1.30", to: "1.30"},
+		{from: "1.30.1", to: "1.30.5"},
+		{from: "1.30.5", to: "1.30.1"},
+		{from: "1.30", to: "1.31"},
+		{from: "1.30", to: "1.32", wantErr: true},
+		{from: "1.31", to: "1.30", wantErr: true},
+		{from: "1.30", to: "2.0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+"_"+tt.to, func(t *testing.T) {
+			err := CheckUpgrade(mustParseVersion(t, tt.from), mustParseVersion(t, tt.to))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckUpgrade(%s, %s) error = %v, wantErr %v", tt.from, tt.to, err, tt.wantErr)
+			}
+		})
+	}
+}
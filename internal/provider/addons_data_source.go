@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-meltcloud/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AddonsDataSource{}
+
+func NewAddonsDataSource() datasource.DataSource {
+	return &AddonsDataSource{}
+}
+
+// AddonsDataSource defines the data source implementation.
+type AddonsDataSource struct {
+	client *client.Client
+}
+
+// AddonsDataSourceModel describes the data source data model.
+type AddonsDataSourceModel struct {
+	KubernetesVersion types.String           `tfsdk:"kubernetes_version"`
+	Addons            []AddonDataSourceModel `tfsdk:"addons"`
+}
+
+type AddonDataSourceModel struct {
+	Name           types.String `tfsdk:"name"`
+	Description    types.String `tfsdk:"description"`
+	Versions       types.List   `tfsdk:"versions"`
+	DefaultVersion types.String `tfsdk:"default_version"`
+}
+
+func (d *AddonsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_addons"
+}
+
+func (d *AddonsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Addons available for the `addon` blocks of a `meltcloud_cluster`, with the versions supported on a Kubernetes version.",
+
+		Attributes: map[string]schema.Attribute{
+			"kubernetes_version": schema.StringAttribute{
+				MarkdownDescription: "Kubernetes minor version of the cluster, e.g. `1.30`",
+				Required:            true,
+			},
+			"addons": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name of the addon, as used in the `addon` blocks of a `meltcloud_cluster`",
+						},
+						"description": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Description of the addon",
+						},
+						"versions": schema.ListAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "Versions of the addon supported on the Kubernetes version",
+						},
+						"default_version": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Version installed if an `addon` block does not specify one",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *AddonsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *AddonsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AddonsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := d.client.Addon().List(ctx, data.KubernetesVersion.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read addons for Kubernetes version %s, got error: %s", data.KubernetesVersion.ValueString(), err))
+		return
+	}
+
+	data.Addons = []AddonDataSourceModel{}
+	for _, addon := range result.Addons {
+		versionsList, diags := types.ListValueFrom(ctx, types.StringType, addon.Versions)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+
+		data.Addons = append(data.Addons, AddonDataSourceModel{
+			Name:           types.StringValue(addon.Name),
+			Description:    types.StringValue(addon.Description),
+			Versions:       versionsList,
+			DefaultVersion: types.StringValue(addon.DefaultVersion),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"terraform-provider-meltcloud/internal/kubernetes"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &CIDRsOverlapFunction{}
+
+func NewCIDRsOverlapFunction() function.Function {
+	return &CIDRsOverlapFunction{}
+}
+
+// CIDRsOverlapFunction defines the function implementation.
+type CIDRsOverlapFunction struct{}
+
+func (f *CIDRsOverlapFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "cidrs_overlap"
+}
+
+func (f *CIDRsOverlapFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Check whether CIDRs overlap",
+		MarkdownDescription: "Returns whether any two of the given CIDRs overlap, e.g. the `pod_cidr` and `service_cidr` of a cluster or " +
+			"the CIDRs of several clusters that must be routable from the same network. IPv4 and IPv6 CIDRs never overlap.",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:        "cidrs",
+				Description: "List of CIDRs, e.g. [\"10.36.0.0/16\", \"10.96.0.0/16\"]",
+				ElementType: types.StringType,
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *CIDRsOverlapFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var cidrs []string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &cidrs))
+	if resp.Error != nil {
+		return
+	}
+
+	prefixes, err := parseCIDRs(cidrs)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	_, _, overlap := kubernetes.OverlappingCIDRs(prefixes)
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, overlap))
+}
+
+func parseCIDRs(cidrs []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(cidrs))
+	for i, cidr := range cidrs {
+		prefix, err := kubernetes.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %s", i, err)
+		}
+		prefixes = append(prefixes, prefix)
+	}
+
+	return prefixes, nil
+}
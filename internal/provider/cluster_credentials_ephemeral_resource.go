@@ -0,0 +1,363 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"terraform-provider-meltcloud/internal/client"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &ClusterCredentialsEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &ClusterCredentialsEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithClose = &ClusterCredentialsEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithRenew = &ClusterCredentialsEphemeralResource{}
+
+const (
+	clusterCredentialsTypeAdmin = "admin"
+	clusterCredentialsTypeUser  = "user"
+
+	// clusterCredentialPrivateKey stores the issued short-lived credential between Open, Renew and Close.
+	clusterCredentialPrivateKey = "cluster_credential"
+)
+
+// durationPattern matches Go durations with at least one of the hour, minute and second units, e.g. "1h30m".
+var durationPattern = regexp.MustCompile(`^(\d+h(\d+m)?(\d+s)?|\d+m(\d+s)?|\d+s)$`)
+
+// clusterCredentialPrivateData is the private data needed to revoke a short-lived credential in Close, and to warn
+// about its expiry in Renew.
+type clusterCredentialPrivateData struct {
+	ClusterID    int64     `json:"cluster_id"`
+	CredentialID int64     `json:"credential_id"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+func NewClusterCredentialsEphemeralResource() ephemeral.EphemeralResource {
+	return &ClusterCredentialsEphemeralResource{}
+}
+
+// ClusterCredentialsEphemeralResource defines the ephemeral resource implementation.
+type ClusterCredentialsEphemeralResource struct {
+	client *client.Client
+}
+
+// ClusterCredentialsEphemeralResourceModel describes the ephemeral resource data model.
+type ClusterCredentialsEphemeralResourceModel struct {
+	ClusterID     types.Int64              `tfsdk:"cluster_id"`
+	Type          types.String             `tfsdk:"type"`
+	TTL           types.String             `tfsdk:"ttl"`
+	Groups        types.List               `tfsdk:"groups"`
+	ClusterRole   types.String             `tfsdk:"cluster_role"`
+	ExpiresAt     timetypes.RFC3339        `tfsdk:"expires_at"`
+	KubeConfigRaw types.String             `tfsdk:"kubeconfig_raw"`
+	KubeConfig    *KubeConfigResourceModel `tfsdk:"kubeconfig"`
+}
+
+func (r *ClusterCredentialsEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_credentials"
+}
+
+func (r *ClusterCredentialsEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Credentials of a [Cluster](https://docs.meltcloud.io/tasks/clusters/create), fetched on every run without being persisted in the Terraform state or plan. " +
+			"Use it to configure the `kubernetes` or `helm` provider together with `store_kubeconfig = false` on the `meltcloud_cluster` resource. Requires Terraform 1.10 or later.",
+
+		Attributes: map[string]schema.Attribute{
+			"cluster_id": schema.Int64Attribute{
+				MarkdownDescription: clusterResourceAttributes()["id"].GetMarkdownDescription(),
+				Required:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Which credentials to return: `admin` for the admin kubeconfig with a client certificate, `user` for the kubeconfig of regular (OIDC) users. Defaults to `admin`.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(clusterCredentialsTypeAdmin, clusterCredentialsTypeUser),
+				},
+			},
+			"ttl": schema.StringAttribute{
+				MarkdownDescription: "Lifetime of a short-lived admin credential as a duration (e.g. `30m`, `2h`). If set, a personal client certificate is issued " +
+					"for this run instead of returning the shared admin kubeconfig, and revoked again once Terraform no longer needs it. " +
+					"The credential cannot be extended, choose a `ttl` longer than the Terraform run. A run that outlasts it logs a warning shortly before it expires. Only supported with `type = \"admin\"`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(durationPattern, "must be a duration like 30m or 2h"),
+				},
+			},
+			"groups": schema.ListAttribute{
+				MarkdownDescription: "Kubernetes groups of the short-lived credential, used to scope its permissions via RBAC. Defaults to `system:masters` if neither `groups` nor `cluster_role` is set.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.AlsoRequires(path.MatchRoot("ttl")),
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"cluster_role": schema.StringAttribute{
+				MarkdownDescription: "Name of a ClusterRole (e.g. `view` or `edit`) bound to the short-lived credential for its lifetime.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("ttl")),
+				},
+			},
+			"expires_at": schema.StringAttribute{
+				MarkdownDescription: "Expiry of the short-lived credential. Null for the long-lived kubeconfigs.",
+				CustomType:          timetypes.RFC3339Type{},
+				Computed:            true,
+			},
+			"kubeconfig_raw": schema.StringAttribute{
+				Description: "Kubeconfig file",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"kubeconfig": schema.SingleNestedAttribute{
+				Description: "Kubeconfig values",
+				Attributes: map[string]schema.Attribute{
+					"host": schema.StringAttribute{
+						Computed: true,
+					},
+					"username": schema.StringAttribute{
+						Computed: true,
+					},
+					"password": schema.StringAttribute{
+						Computed:  true,
+						Sensitive: true,
+					},
+					"client_certificate": schema.StringAttribute{
+						Computed: true,
+					},
+					"client_key": schema.StringAttribute{
+						Computed:  true,
+						Sensitive: true,
+					},
+					"cluster_ca_certificate": schema.StringAttribute{
+						Computed: true,
+					},
+					"token": schema.StringAttribute{
+						Computed:  true,
+						Sensitive: true,
+					},
+					"proxy_url": schema.StringAttribute{
+						Computed: true,
+					},
+					"tls_server_name": schema.StringAttribute{
+						Computed: true,
+					},
+					"exec": schema.SingleNestedAttribute{
+						Description: "Credential plugin to run to obtain credentials, null if the kubeconfig uses static credentials",
+						Attributes: map[string]schema.Attribute{
+							"api_version": schema.StringAttribute{
+								Computed: true,
+							},
+							"command": schema.StringAttribute{
+								Computed: true,
+							},
+							"args": schema.ListAttribute{
+								ElementType: types.StringType,
+								Computed:    true,
+							},
+							"env": schema.MapAttribute{
+								ElementType: types.StringType,
+								Computed:    true,
+							},
+						},
+						Computed: true,
+					},
+				},
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (r *ClusterCredentialsEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ClusterCredentialsEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data ClusterCredentialsEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Type.IsNull() {
+		data.Type = types.StringValue(clusterCredentialsTypeAdmin)
+	}
+
+	if !data.TTL.IsNull() {
+		r.openShortLived(ctx, &data, resp)
+		return
+	}
+
+	data.ExpiresAt = timetypes.NewRFC3339Null()
+
+	result, err := r.client.Cluster().Get(ctx, data.ClusterID.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read cluster, got error: %s", err))
+		return
+	}
+
+	kubeconfig := result.Cluster.KubeConfig
+	if data.Type.ValueString() == clusterCredentialsTypeUser {
+		kubeconfig = result.Cluster.KubeConfigUser
+	}
+
+	if kubeconfig == "" {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Cluster %s has no %s kubeconfig (yet)", result.Cluster.Name, data.Type.ValueString()))
+		return
+	}
+
+	kubeConfigResourceModel, kErr := getKubeConfigResourceModel(kubeconfig)
+	if kErr != nil {
+		resp.Diagnostics.AddError("Client Error", kErr.Error())
+		return
+	}
+
+	data.KubeConfigRaw = types.StringValue(kubeconfig)
+	data.KubeConfig = kubeConfigResourceModel
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+func (r *ClusterCredentialsEphemeralResource) openShortLived(ctx context.Context, data *ClusterCredentialsEphemeralResourceModel, resp *ephemeral.OpenResponse) {
+	if data.Type.ValueString() != clusterCredentialsTypeAdmin {
+		resp.Diagnostics.AddAttributeError(path.Root("ttl"), "Invalid Attribute Combination", "Short-lived credentials can only be issued with type = \"admin\".")
+		return
+	}
+
+	ttl, tErr := time.ParseDuration(data.TTL.ValueString())
+	if tErr != nil || ttl < time.Minute {
+		resp.Diagnostics.AddAttributeError(path.Root("ttl"), "Invalid Attribute Value", fmt.Sprintf("ttl must be a duration of at least one minute, got: %s", data.TTL.ValueString()))
+		return
+	}
+
+	clusterCredentialCreateInput := &client.ClusterCredentialCreateInput{
+		TTLSeconds:  int64(ttl.Seconds()),
+		ClusterRole: data.ClusterRole.ValueStringPointer(),
+	}
+	resp.Diagnostics.Append(data.Groups.ElementsAs(ctx, &clusterCredentialCreateInput.Groups, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := r.client.ClusterCredential().Create(ctx, data.ClusterID.ValueInt64(), clusterCredentialCreateInput)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to issue cluster credential, got error: %s", err))
+		return
+	}
+
+	// Close is not called if Open fails, revoke the credential right away if it cannot be handed out
+	kubeConfigResourceModel, kErr := getKubeConfigResourceModel(result.ClusterCredential.KubeConfig)
+	if kErr != nil {
+		resp.Diagnostics.AddError("Client Error", kErr.Error())
+		resp.Diagnostics.Append(r.revoke(ctx, data.ClusterID.ValueInt64(), result.ClusterCredential.ID)...)
+		return
+	}
+
+	privateData, jErr := json.Marshal(clusterCredentialPrivateData{
+		ClusterID:    data.ClusterID.ValueInt64(),
+		CredentialID: result.ClusterCredential.ID,
+		ExpiresAt:    result.ClusterCredential.ExpiresAt,
+	})
+	if jErr != nil {
+		resp.Diagnostics.AddError("Internal Error", fmt.Sprintf("Unable to store cluster credential for revocation, got error: %s", jErr))
+		resp.Diagnostics.Append(r.revoke(ctx, data.ClusterID.ValueInt64(), result.ClusterCredential.ID)...)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, clusterCredentialPrivateKey, privateData)...)
+	if resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(r.revoke(ctx, data.ClusterID.ValueInt64(), result.ClusterCredential.ID)...)
+		return
+	}
+
+	// Terraform calls Renew shortly before the credential expires, if the run takes that long
+	resp.RenewAt = result.ClusterCredential.ExpiresAt.Add(-ttl / 10)
+
+	data.ExpiresAt = timetypes.NewRFC3339TimeValue(result.ClusterCredential.ExpiresAt.UTC())
+	data.KubeConfigRaw = types.StringValue(result.ClusterCredential.KubeConfig)
+	data.KubeConfig = kubeConfigResourceModel
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, data)...)
+}
+
+// Renew is called when a run outlasts the short-lived credential. Client certificates cannot be extended, so it only
+// warns that the remaining operations fail once the credential expires. Terraform writes the warning to its log.
+func (r *ClusterCredentialsEphemeralResource) Renew(ctx context.Context, req ephemeral.RenewRequest, resp *ephemeral.RenewResponse) {
+	privateBytes, diags := req.Private.GetKey(ctx, clusterCredentialPrivateKey)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() || privateBytes == nil {
+		return
+	}
+
+	var privateData clusterCredentialPrivateData
+	if err := json.Unmarshal(privateBytes, &privateData); err != nil {
+		resp.Diagnostics.AddError("Internal Error", fmt.Sprintf("Unable to read cluster credential, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.AddWarning("Cluster Credential Expires Soon",
+		fmt.Sprintf("The short-lived cluster credential expires at %s, operations on the cluster after its expiry fail with Unauthorized. "+
+			"Set a longer ttl for runs of this length.", privateData.ExpiresAt.UTC().Format(time.RFC3339)))
+}
+
+// Close revokes a short-lived credential issued in Open. Long-lived kubeconfigs are left untouched.
+func (r *ClusterCredentialsEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	privateBytes, diags := req.Private.GetKey(ctx, clusterCredentialPrivateKey)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() || privateBytes == nil {
+		return
+	}
+
+	var privateData clusterCredentialPrivateData
+	if err := json.Unmarshal(privateBytes, &privateData); err != nil {
+		resp.Diagnostics.AddError("Internal Error", fmt.Sprintf("Unable to read cluster credential for revocation, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(r.revoke(ctx, privateData.ClusterID, privateData.CredentialID)...)
+}
+
+// revoke revokes a short-lived credential, a credential that is already gone is not an error.
+func (r *ClusterCredentialsEphemeralResource) revoke(ctx context.Context, clusterID int64, credentialID int64) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	_, err := r.client.ClusterCredential().Delete(ctx, clusterID, credentialID)
+	if err != nil && err.HTTPStatusCode != 404 {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to revoke cluster credential, got error: %s", err))
+	}
+
+	return diags
+}
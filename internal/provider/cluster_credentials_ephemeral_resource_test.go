@@ -0,0 +1,334 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"terraform-provider-meltcloud/internal/client"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// clusterCredentialsTestServer runs the ephemeral resource through the provider server, so that the private data
+// and the renewal time are passed between Open and Close like Terraform does.
+type clusterCredentialsTestServer struct {
+	tfprotov6.ProviderServer
+
+	schema ephemeral.SchemaResponse
+}
+
+func newClusterCredentialsTestServer(t *testing.T, api *testAPI) *clusterCredentialsTestServer {
+	t.Helper()
+
+	ctx := context.Background()
+
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatalf("NewProtocol6WithError() error = %v", err)
+	}
+
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("GetProviderSchema() error = %v", err)
+	}
+
+	providerType := schemaResp.Provider.ValueType().(tftypes.Object)
+	providerConfig := map[string]tftypes.Value{}
+	for name, attributeType := range providerType.AttributeTypes {
+		providerConfig[name] = tftypes.NewValue(attributeType, nil)
+	}
+	providerConfig["endpoint"] = tftypes.NewValue(tftypes.String, api.URL)
+	providerConfig["organization"] = tftypes.NewValue(tftypes.String, testOrganization)
+	providerConfig["api_key"] = tftypes.NewValue(tftypes.String, "dummy")
+
+	config, err := tfprotov6.NewDynamicValue(providerType, tftypes.NewValue(providerType, providerConfig))
+	if err != nil {
+		t.Fatalf("NewDynamicValue() error = %v", err)
+	}
+
+	configureResp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{Config: &config})
+	if err != nil || len(configureResp.Diagnostics) > 0 {
+		t.Fatalf("ConfigureProvider() error = %v, diagnostics = %v", err, configureResp.Diagnostics)
+	}
+
+	s := &clusterCredentialsTestServer{ProviderServer: server}
+	(&ClusterCredentialsEphemeralResource{}).Schema(ctx, ephemeral.SchemaRequest{}, &s.schema)
+
+	return s
+}
+
+// open opens the ephemeral resource with config and returns the response along with its decoded result, nil if Open
+// failed.
+func (s *clusterCredentialsTestServer) open(t *testing.T, config ClusterCredentialsEphemeralResourceModel) (*tfprotov6.OpenEphemeralResourceResponse, *ClusterCredentialsEphemeralResourceModel) {
+	t.Helper()
+
+	ctx := context.Background()
+	typ := s.schema.Schema.Type().TerraformType(ctx)
+
+	configData := tfsdk.EphemeralResultData{Schema: s.schema.Schema, Raw: tftypes.NewValue(typ, nil)}
+	if diags := configData.Set(ctx, &config); diags.HasError() {
+		t.Fatalf("Set() diagnostics = %v", diags)
+	}
+
+	dynamicConfig, err := tfprotov6.NewDynamicValue(typ, configData.Raw)
+	if err != nil {
+		t.Fatalf("NewDynamicValue() error = %v", err)
+	}
+
+	resp, err := s.OpenEphemeralResource(ctx, &tfprotov6.OpenEphemeralResourceRequest{
+		TypeName: "meltcloud_cluster_credentials",
+		Config:   &dynamicConfig,
+	})
+	if err != nil {
+		t.Fatalf("OpenEphemeralResource() error = %v", err)
+	}
+
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			return resp, nil
+		}
+	}
+
+	raw, err := resp.Result.Unmarshal(typ)
+	if err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	var result ClusterCredentialsEphemeralResourceModel
+	if diags := (tfsdk.EphemeralResultData{Schema: s.schema.Schema, Raw: raw}).Get(ctx, &result); diags.HasError() {
+		t.Fatalf("Get() diagnostics = %v", diags)
+	}
+
+	return resp, &result
+}
+
+func (s *clusterCredentialsTestServer) close(t *testing.T, private []byte) *tfprotov6.CloseEphemeralResourceResponse {
+	t.Helper()
+
+	resp, err := s.CloseEphemeralResource(context.Background(), &tfprotov6.CloseEphemeralResourceRequest{
+		TypeName: "meltcloud_cluster_credentials",
+		Private:  private,
+	})
+	if err != nil {
+		t.Fatalf("CloseEphemeralResource() error = %v", err)
+	}
+
+	return resp
+}
+
+func newClusterCredentialsConfig(clusterID int64) ClusterCredentialsEphemeralResourceModel {
+	return ClusterCredentialsEphemeralResourceModel{
+		ClusterID:     types.Int64Value(clusterID),
+		Type:          types.StringNull(),
+		TTL:           types.StringNull(),
+		Groups:        types.ListNull(types.StringType),
+		ClusterRole:   types.StringNull(),
+		ExpiresAt:     timetypes.NewRFC3339Null(),
+		KubeConfigRaw: types.StringNull(),
+	}
+}
+
+func protoDiagnosticSummaries(diags []*tfprotov6.Diagnostic) []string {
+	var summaries []string
+	for _, d := range diags {
+		summaries = append(summaries, d.Summary+": "+d.Detail)
+	}
+	return summaries
+}
+
+func TestClusterCredentialsEphemeralResourceOpen(t *testing.T) {
+	api := newTestAPI(t)
+	clusterID := api.addCluster("prod")
+	s := newClusterCredentialsTestServer(t, api)
+
+	resp, result := s.open(t, newClusterCredentialsConfig(clusterID))
+	if result == nil {
+		t.Fatalf("Open() diagnostics = %v", protoDiagnosticSummaries(resp.Diagnostics))
+	}
+
+	if result.Type.ValueString() != clusterCredentialsTypeAdmin {
+		t.Errorf("Open() type = %s, want %s", result.Type, clusterCredentialsTypeAdmin)
+	}
+	if !result.ExpiresAt.IsNull() {
+		t.Errorf("Open() expires_at = %s, want null", result.ExpiresAt)
+	}
+	if result.KubeConfigRaw.ValueString() != testKubeConfig {
+		t.Errorf("Open() kubeconfig_raw = %q, want %q", result.KubeConfigRaw.ValueString(), testKubeConfig)
+	}
+	if result.KubeConfig == nil || result.KubeConfig.Host.ValueString() != "https://test.k8s.meltcloud.io" || result.KubeConfig.Token.ValueString() != "dummy" {
+		t.Errorf("Open() kubeconfig = %+v, want the host and token of the admin kubeconfig", result.KubeConfig)
+	}
+	if !resp.RenewAt.IsZero() || resp.Private != nil {
+		t.Errorf("Open() renew at = %s, private = %s, want neither for the long-lived kubeconfig", resp.RenewAt, resp.Private)
+	}
+
+	// nothing to revoke for the long-lived kubeconfig
+	closeResp := s.close(t, resp.Private)
+	if len(closeResp.Diagnostics) > 0 || len(api.revoked) > 0 {
+		t.Errorf("Close() diagnostics = %v, revoked = %v, want none", protoDiagnosticSummaries(closeResp.Diagnostics), api.revoked)
+	}
+}
+
+func TestClusterCredentialsEphemeralResourceOpenErrors(t *testing.T) {
+	api := newTestAPI(t)
+	clusterID := api.addCluster("prod")
+	s := newClusterCredentialsTestServer(t, api)
+
+	userConfig := newClusterCredentialsConfig(clusterID)
+	userConfig.Type = types.StringValue(clusterCredentialsTypeUser)
+
+	tests := []struct {
+		name      string
+		config    ClusterCredentialsEphemeralResourceModel
+		wantError string
+	}{
+		{name: "unknown cluster", config: newClusterCredentialsConfig(404), wantError: "Unable to read cluster"},
+		{name: "no user kubeconfig", config: userConfig, wantError: "Cluster prod has no user kubeconfig (yet)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, result := s.open(t, tt.config)
+			if result != nil {
+				t.Fatalf("Open() = %+v, want error %q", result, tt.wantError)
+			}
+
+			summaries := protoDiagnosticSummaries(resp.Diagnostics)
+			if len(summaries) != 1 || !strings.Contains(summaries[0], tt.wantError) {
+				t.Errorf("Open() diagnostics = %v, want error %q", summaries, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestClusterCredentialsEphemeralResourceShortLived(t *testing.T) {
+	api := newTestAPI(t)
+	clusterID := api.addCluster("prod")
+	s := newClusterCredentialsTestServer(t, api)
+
+	config := newClusterCredentialsConfig(clusterID)
+	config.TTL = types.StringValue("1h")
+	config.ClusterRole = types.StringValue("view")
+
+	resp, result := s.open(t, config)
+	if result == nil {
+		t.Fatalf("Open() diagnostics = %v", protoDiagnosticSummaries(resp.Diagnostics))
+	}
+
+	if len(api.credentials) != 1 {
+		t.Fatalf("Open() issued %d credentials, want 1", len(api.credentials))
+	}
+	var credential *client.ClusterCredential
+	for _, c := range api.credentials {
+		credential = c
+	}
+
+	if credential.ClusterRole != "view" {
+		t.Errorf("Open() issued a credential with cluster role %q, want %q", credential.ClusterRole, "view")
+	}
+
+	expiresAt, diags := result.ExpiresAt.ValueRFC3339Time()
+	if diags.HasError() || !expiresAt.Equal(credential.ExpiresAt) {
+		t.Errorf("Open() expires_at = %s, want %s", result.ExpiresAt, credential.ExpiresAt)
+	}
+	if wantRenewAt := credential.ExpiresAt.Add(-6 * time.Minute); !resp.RenewAt.Equal(wantRenewAt) {
+		t.Errorf("Open() renew at = %s, want %s", resp.RenewAt, wantRenewAt)
+	}
+
+	renewResp, err := s.RenewEphemeralResource(context.Background(), &tfprotov6.RenewEphemeralResourceRequest{
+		TypeName: "meltcloud_cluster_credentials",
+		Private:  resp.Private,
+	})
+	if err != nil {
+		t.Fatalf("RenewEphemeralResource() error = %v", err)
+	}
+	wantWarning := "expires at " + credential.ExpiresAt.Format(time.RFC3339)
+	if summaries := protoDiagnosticSummaries(renewResp.Diagnostics); len(summaries) != 1 || !strings.Contains(summaries[0], wantWarning) {
+		t.Errorf("Renew() diagnostics = %v, want a warning containing %q", summaries, wantWarning)
+	}
+
+	// the private data of Open identifies the credential to revoke
+	closeResp := s.close(t, resp.Private)
+	if len(closeResp.Diagnostics) > 0 {
+		t.Errorf("Close() diagnostics = %v", protoDiagnosticSummaries(closeResp.Diagnostics))
+	}
+	if !reflect.DeepEqual(api.revoked, []int64{credential.ID}) {
+		t.Errorf("Close() revoked %v, want [%d]", api.revoked, credential.ID)
+	}
+
+	// a credential that is already revoked is not an error
+	if closeResp := s.close(t, resp.Private); len(closeResp.Diagnostics) > 0 {
+		t.Errorf("Close() of a revoked credential diagnostics = %v", protoDiagnosticSummaries(closeResp.Diagnostics))
+	}
+}
+
+func TestClusterCredentialsEphemeralResourceShortLivedErrors(t *testing.T) {
+	api := newTestAPI(t)
+	clusterID := api.addCluster("prod")
+	s := newClusterCredentialsTestServer(t, api)
+
+	userConfig := newClusterCredentialsConfig(clusterID)
+	userConfig.Type = types.StringValue(clusterCredentialsTypeUser)
+	userConfig.TTL = types.StringValue("1h")
+
+	shortConfig := newClusterCredentialsConfig(clusterID)
+	shortConfig.TTL = types.StringValue("30s")
+
+	tests := []struct {
+		name      string
+		config    ClusterCredentialsEphemeralResourceModel
+		wantError string
+	}{
+		{name: "user credential", config: userConfig, wantError: "can only be issued with type = \"admin\""},
+		{name: "ttl below one minute", config: shortConfig, wantError: "at least one minute"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, result := s.open(t, tt.config)
+			if result != nil {
+				t.Fatalf("Open() = %+v, want error %q", result, tt.wantError)
+			}
+
+			summaries := protoDiagnosticSummaries(resp.Diagnostics)
+			if len(summaries) != 1 || !strings.Contains(summaries[0], tt.wantError) {
+				t.Errorf("Open() diagnostics = %v, want error %q", summaries, tt.wantError)
+			}
+		})
+	}
+
+	if len(api.credentials) > 0 {
+		t.Errorf("Open() issued %d credentials, want none", len(api.credentials))
+	}
+}
+
+func TestClusterCredentialsEphemeralResourceRevokeOnOpenFailure(t *testing.T) {
+	api := newTestAPI(t)
+	clusterID := api.addCluster("prod")
+	api.credentialKubeConfig = "not a kubeconfig"
+	s := newClusterCredentialsTestServer(t, api)
+
+	config := newClusterCredentialsConfig(clusterID)
+	config.TTL = types.StringValue("1h")
+
+	resp, result := s.open(t, config)
+	if result != nil {
+		t.Fatalf("Open() = %+v, want error", result)
+	}
+	if summaries := protoDiagnosticSummaries(resp.Diagnostics); len(summaries) != 1 || !strings.Contains(summaries[0], "failed to parse kubeconfig") {
+		t.Errorf("Open() diagnostics = %v, want a kubeconfig parse error", summaries)
+	}
+
+	// Close is not called after a failed Open, the credential must be revoked right away
+	if len(api.revoked) != 1 || len(api.credentials) != 0 {
+		t.Errorf("Open() revoked %v, %d credentials left, want the issued credential revoked", api.revoked, len(api.credentials))
+	}
+}
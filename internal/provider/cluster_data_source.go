@@ -9,8 +9,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"strings"
 	"terraform-provider-meltcloud/internal/client"
-	"terraform-provider-meltcloud/internal/kubernetes"
 
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -29,28 +29,35 @@ type ClusterDataSource struct {
 }
 
 type ClusterDataSourceModel struct {
-	ID                 types.Int64                `tfsdk:"id"`
-	Name               types.String               `tfsdk:"name"`
-	Version            types.String               `tfsdk:"version"`
-	ControlPlaneStatus types.String               `tfsdk:"control_plane_status"`
-	PatchVersion       types.String               `tfsdk:"patch_version"`
-	PodCIDR            types.String               `tfsdk:"pod_cidr"`
-	ServiceCIDR        types.String               `tfsdk:"service_cidr"`
-	DNSServiceIP       types.String               `tfsdk:"dns_service_ip"`
-	AddonKubeProxy     types.Bool                 `tfsdk:"addon_kube_proxy"`
-	AddonCoreDNS       types.Bool                 `tfsdk:"addon_core_dns"`
-	KubeConfigRaw      types.String               `tfsdk:"kubeconfig_raw"`
-	KubeConfig         *KubeConfigDataSourceModel `tfsdk:"kubeconfig"`
-	KubeConfigUserRaw  types.String               `tfsdk:"kubeconfig_user_raw"`
-}
+	ID                 types.Int64              `tfsdk:"id"`
+	Name               types.String             `tfsdk:"name"`
+	Version            types.String             `tfsdk:"version"`
+	ControlPlaneStatus types.String             `tfsdk:"control_plane_status"`
+	PatchVersion       types.String             `tfsdk:"patch_version"`
+	PodCIDR            types.String             `tfsdk:"pod_cidr"`
+	ServiceCIDR        types.String             `tfsdk:"service_cidr"`
+	DNSServiceIP       types.String             `tfsdk:"dns_service_ip"`
+	AddonKubeProxy     types.Bool               `tfsdk:"addon_kube_proxy"`
+	AddonCoreDNS       types.Bool               `tfsdk:"addon_core_dns"`
+	KubeConfigRaw      types.String             `tfsdk:"kubeconfig_raw"`
+	KubeConfig         *KubeConfigResourceModel `tfsdk:"kubeconfig"`
+	KubeConfigUserRaw  types.String             `tfsdk:"kubeconfig_user_raw"`
+	KubeConfigUser     *KubeConfigUserModel     `tfsdk:"kubeconfig_user"`
+	KubeConfigUserExec *KubeConfigUserExecModel `tfsdk:"kubeconfig_user_exec"`
+
+	ClientCertificateExpiresAt    timetypes.RFC3339 `tfsdk:"client_certificate_expires_at"`
+	ClusterCACertificateSHA256    types.String      `tfsdk:"cluster_ca_certificate_sha256"`
+	ClusterCACertificateExpiresAt timetypes.RFC3339 `tfsdk:"cluster_ca_certificate_expires_at"`
+
+	Addons []ClusterAddonResourceModel `tfsdk:"addons"`
 
-type KubeConfigDataSourceModel struct {
-	Host                 types.String `tfsdk:"host"`
-	Username             types.String `tfsdk:"username"`
-	Password             types.String `tfsdk:"password"`
-	ClientCertificate    types.String `tfsdk:"client_certificate"`
-	ClientKey            types.String `tfsdk:"client_key"`
-	ClusterCACertificate types.String `tfsdk:"cluster_ca_certificate"`
+	APIServerAllowedCIDRs    types.List   `tfsdk:"api_server_allowed_cidrs"`
+	APIServerPrivateEndpoint types.Bool   `tfsdk:"api_server_private_endpoint"`
+	APIServerCertSANs        types.List   `tfsdk:"api_server_cert_sans"`
+	APIServerDNSName         types.String `tfsdk:"api_server_dns_name"`
+
+	OIDC                 *ClusterOIDCModel `tfsdk:"oidc"`
+	ServiceAccountIssuer types.String      `tfsdk:"service_account_issuer"`
 }
 
 func (d *ClusterDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -110,6 +117,87 @@ func (d *ClusterDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 				MarkdownDescription: clusterResourceAttributes()["addon_core_dns"].GetMarkdownDescription(),
 				Computed:            true,
 			},
+			"api_server_allowed_cidrs": schema.ListAttribute{
+				MarkdownDescription: clusterResourceAttributes()["api_server_allowed_cidrs"].GetMarkdownDescription(),
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"api_server_private_endpoint": schema.BoolAttribute{
+				MarkdownDescription: clusterResourceAttributes()["api_server_private_endpoint"].GetMarkdownDescription(),
+				Computed:            true,
+			},
+			"api_server_cert_sans": schema.ListAttribute{
+				MarkdownDescription: clusterResourceAttributes()["api_server_cert_sans"].GetMarkdownDescription(),
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"api_server_dns_name": schema.StringAttribute{
+				MarkdownDescription: clusterResourceAttributes()["api_server_dns_name"].GetMarkdownDescription(),
+				Computed:            true,
+			},
+			"oidc": schema.SingleNestedAttribute{
+				MarkdownDescription: "OpenID Connect identity provider the API server authenticates users with, null if users log in with their meltcloud account",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"issuer_url": schema.StringAttribute{
+						MarkdownDescription: "HTTPS URL of the issuer",
+						Computed:            true,
+					},
+					"client_id": schema.StringAttribute{
+						MarkdownDescription: "Client ID the ID tokens must be issued for",
+						Computed:            true,
+					},
+					"username_claim": schema.StringAttribute{
+						MarkdownDescription: "Claim used as the Kubernetes username",
+						Computed:            true,
+					},
+					"username_prefix": schema.StringAttribute{
+						MarkdownDescription: "Prefix added to usernames",
+						Computed:            true,
+					},
+					"groups_claim": schema.StringAttribute{
+						MarkdownDescription: "Claim with the groups of the user",
+						Computed:            true,
+					},
+					"groups_prefix": schema.StringAttribute{
+						MarkdownDescription: "Prefix added to groups",
+						Computed:            true,
+					},
+					"required_claims": schema.MapAttribute{
+						MarkdownDescription: "Claims that must be present in the ID token with the given value",
+						ElementType:         types.StringType,
+						Computed:            true,
+					},
+					"ca": schema.StringAttribute{
+						MarkdownDescription: "PEM encoded CA certificate of the issuer",
+						Computed:            true,
+					},
+				},
+			},
+			"service_account_issuer": schema.StringAttribute{
+				MarkdownDescription: "Issuer of the service account tokens (`iss` claim) configured for workload identity federation, null if the tokens are issued by meltcloud",
+				Computed:            true,
+			},
+			"addons": schema.ListNestedAttribute{
+				MarkdownDescription: "Addons installed on the cluster, in addition to kube-proxy and CoreDNS",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: clusterAddonResourceAttributes()["name"].GetMarkdownDescription(),
+						},
+						"version": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Version of the addon",
+						},
+						"values": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: clusterAddonResourceAttributes()["values"].GetMarkdownDescription(),
+						},
+					},
+				},
+			},
 			"kubeconfig": schema.SingleNestedAttribute{
 				MarkdownDescription: clusterResourceAttributes()["kubeconfig"].GetMarkdownDescription(),
 				Attributes: map[string]schema.Attribute{
@@ -137,6 +225,36 @@ func (d *ClusterDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 						Computed:  true,
 						Sensitive: true,
 					},
+					"token": schema.StringAttribute{
+						Computed:  true,
+						Sensitive: true,
+					},
+					"proxy_url": schema.StringAttribute{
+						Computed: true,
+					},
+					"tls_server_name": schema.StringAttribute{
+						Computed: true,
+					},
+					"exec": schema.SingleNestedAttribute{
+						Description: "Credential plugin to run to obtain credentials, null if the kubeconfig uses static credentials",
+						Attributes: map[string]schema.Attribute{
+							"api_version": schema.StringAttribute{
+								Computed: true,
+							},
+							"command": schema.StringAttribute{
+								Computed: true,
+							},
+							"args": schema.ListAttribute{
+								ElementType: types.StringType,
+								Computed:    true,
+							},
+							"env": schema.MapAttribute{
+								ElementType: types.StringType,
+								Computed:    true,
+							},
+						},
+						Computed: true,
+					},
 				},
 				Computed:  true,
 				Sensitive: true,
@@ -151,6 +269,84 @@ func (d *ClusterDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 				Computed:            true,
 				Sensitive:           true,
 			},
+			"kubeconfig_user": schema.SingleNestedAttribute{
+				MarkdownDescription: clusterResourceAttributes()["kubeconfig_user"].GetMarkdownDescription(),
+				Attributes: map[string]schema.Attribute{
+					"host": schema.StringAttribute{
+						Computed: true,
+					},
+					"cluster_ca_certificate": schema.StringAttribute{
+						Computed: true,
+					},
+					"issuer_url": schema.StringAttribute{
+						Computed: true,
+					},
+					"client_id": schema.StringAttribute{
+						Computed: true,
+					},
+					"scopes": schema.ListAttribute{
+						Description: "Scopes requested in addition to openid",
+						ElementType: types.StringType,
+						Computed:    true,
+					},
+					"exec": schema.SingleNestedAttribute{
+						Description: "Credential plugin obtaining the OIDC token",
+						Attributes: map[string]schema.Attribute{
+							"api_version": schema.StringAttribute{
+								Computed: true,
+							},
+							"command": schema.StringAttribute{
+								Computed: true,
+							},
+							"args": schema.ListAttribute{
+								ElementType: types.StringType,
+								Computed:    true,
+							},
+							"env": schema.MapAttribute{
+								ElementType: types.StringType,
+								Computed:    true,
+							},
+						},
+						Computed:  true,
+						Sensitive: true,
+					},
+				},
+				Computed: true,
+			},
+			"kubeconfig_user_exec": schema.SingleNestedAttribute{
+				MarkdownDescription: clusterResourceAttributes()["kubeconfig_user_exec"].GetMarkdownDescription(),
+				Attributes: map[string]schema.Attribute{
+					"command": schema.StringAttribute{
+						MarkdownDescription: "Command to run. Defaults to `kubectl`, which runs `kubectl oidc-login`.",
+						Optional:            true,
+					},
+					"args": schema.ListAttribute{
+						MarkdownDescription: "Arguments of the command, replacing the generated `get-token --oidc-issuer-url=... --oidc-client-id=...` arguments",
+						ElementType:         types.StringType,
+						Optional:            true,
+					},
+					"env": schema.MapAttribute{
+						MarkdownDescription: "Environment variables for the command",
+						ElementType:         types.StringType,
+						Optional:            true,
+					},
+				},
+				Optional: true,
+			},
+			"client_certificate_expires_at": schema.StringAttribute{
+				MarkdownDescription: clusterResourceAttributes()["client_certificate_expires_at"].GetMarkdownDescription(),
+				CustomType:          timetypes.RFC3339Type{},
+				Computed:            true,
+			},
+			"cluster_ca_certificate_sha256": schema.StringAttribute{
+				MarkdownDescription: clusterResourceAttributes()["cluster_ca_certificate_sha256"].GetMarkdownDescription(),
+				Computed:            true,
+			},
+			"cluster_ca_certificate_expires_at": schema.StringAttribute{
+				MarkdownDescription: clusterResourceAttributes()["cluster_ca_certificate_expires_at"].GetMarkdownDescription(),
+				CustomType:          timetypes.RFC3339Type{},
+				Computed:            true,
+			},
 		},
 	}
 }
@@ -231,28 +427,55 @@ func (d *ClusterDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	data.KubeConfigRaw = types.StringValue(cluster.KubeConfig)
 	data.KubeConfigUserRaw = types.StringValue(cluster.KubeConfigUser)
 
-	kubeConfigDataModel, kErr := d.getKubeConfigResourceModel(cluster.KubeConfig)
+	allowedCIDRs, cidrDiags := types.ListValueFrom(ctx, types.StringType, append([]string{}, cluster.APIServerAllowedCIDRs...))
+	resp.Diagnostics.Append(cidrDiags...)
+	data.APIServerAllowedCIDRs = allowedCIDRs
+	data.APIServerPrivateEndpoint = types.BoolValue(cluster.APIServerPrivateEndpoint)
+	certSANs, sanDiags := types.ListValueFrom(ctx, types.StringType, append([]string{}, cluster.APIServerCertSANs...))
+	resp.Diagnostics.Append(sanDiags...)
+	data.APIServerCertSANs = certSANs
+	data.APIServerDNSName = optionalStringValue(cluster.APIServerDNSName)
+
+	oidc, oidcDiags := oidcModel(ctx, cluster.OIDC)
+	resp.Diagnostics.Append(oidcDiags...)
+	data.OIDC = oidc
+	data.ServiceAccountIssuer = optionalStringValue(cluster.ServiceAccountIssuer)
+
+	for _, addon := range cluster.Addons {
+		values := types.StringNull()
+		if addon.Values != "" {
+			values = types.StringValue(addon.Values)
+		}
+
+		data.Addons = append(data.Addons, ClusterAddonResourceModel{
+			Name:    types.StringValue(addon.Name),
+			Version: types.StringValue(addon.Version),
+			Values:  values,
+		})
+	}
+
+	kubeConfigDataModel, kErr := getKubeConfigResourceModel(cluster.KubeConfig)
 	if kErr != nil {
 		resp.Diagnostics.AddError("Client Error", kErr.Error())
 		return
 	}
 
 	data.KubeConfig = kubeConfigDataModel
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-}
 
-func (d *ClusterDataSource) getKubeConfigResourceModel(kubeconfig string) (*KubeConfigDataSourceModel, error) {
-	kubeConfig, err := kubernetes.ParseKubeConfig(kubeconfig)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse kubeconfig error %+v", err)
+	kubeConfigUserModel, kubeConfigUserRaw, uDiags := getKubeConfigUserModel(ctx, cluster.KubeConfigUser, data.KubeConfigUserExec)
+	resp.Diagnostics.Append(uDiags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	return &KubeConfigDataSourceModel{
-		Host:                 types.StringValue(kubeConfig.Clusters[0].Cluster.Server),
-		Username:             types.StringValue(kubeConfig.Users[0].Name),
-		Password:             types.StringValue(""),
-		ClientCertificate:    types.StringValue(kubeConfig.Users[0].User.ClientCertificateData),
-		ClientKey:            types.StringValue(kubeConfig.Users[0].User.ClientKeyData),
-		ClusterCACertificate: types.StringValue(kubeConfig.Clusters[0].Cluster.ClusterAuthorityData),
-	}, nil
+	data.KubeConfigUser = kubeConfigUserModel
+	data.KubeConfigUserRaw = kubeConfigUserRaw
+
+	certificates, diags := getClusterCertificates(cluster.KubeConfig)
+	resp.Diagnostics.Append(diags...)
+	data.ClientCertificateExpiresAt = certificates.ClientCertificateExpiresAt
+	data.ClusterCACertificateSHA256 = certificates.ClusterCACertificateSHA256
+	data.ClusterCACertificateExpiresAt = certificates.ClusterCACertificateExpiresAt
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
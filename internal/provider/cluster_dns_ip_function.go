@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"context"
+	"terraform-provider-meltcloud/internal/kubernetes"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ClusterDNSIPFunction{}
+
+func NewClusterDNSIPFunction() function.Function {
+	return &ClusterDNSIPFunction{}
+}
+
+// ClusterDNSIPFunction defines the function implementation.
+type ClusterDNSIPFunction struct{}
+
+func (f *ClusterDNSIPFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "cluster_dns_ip"
+}
+
+func (f *ClusterDNSIPFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Derive the cluster DNS service IP",
+		MarkdownDescription: "Returns the `dns_service_ip` meltcloud assigns to a cluster if none is specified: the tenth address of the service CIDR, " +
+			"e.g. `10.96.0.10` for `10.96.0.0/16`. Use it to know the DNS service IP before the cluster is created, e.g. for firewall rules.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "service_cidr",
+				Description: "CIDR for the Kubernetes Services, e.g. 10.96.0.0/16",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *ClusterDNSIPFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var serviceCIDR string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &serviceCIDR))
+	if resp.Error != nil {
+		return
+	}
+
+	prefix, err := kubernetes.ParseCIDR(serviceCIDR)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	ip, err := kubernetes.ClusterDNSIP(prefix)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, ip.String()))
+}
@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-meltcloud/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ list.ListResource = &ClusterListResource{}
+var _ list.ListResourceWithConfigure = &ClusterListResource{}
+
+func NewClusterListResource() list.ListResource {
+	return &ClusterListResource{}
+}
+
+// ClusterListResource defines the list resource implementation.
+type ClusterListResource struct {
+	client *client.Client
+}
+
+// ClusterListResourceModel describes the list resource filter model.
+type ClusterListResourceModel struct {
+	Version types.String `tfsdk:"version"`
+}
+
+func (r *ClusterListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster"
+}
+
+func (r *ClusterListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: clusterDesc,
+
+		Attributes: map[string]schema.Attribute{
+			"version": schema.StringAttribute{
+				MarkdownDescription: "Only list clusters with this Kubernetes minor version",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *ClusterListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ClusterListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var data ClusterListResourceModel
+	diags := req.Config.Get(ctx, &data)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	result, err := r.client.Cluster().List(ctx)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to list clusters, got error: %s", err))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for _, cluster := range result.Clusters {
+			if !data.Version.IsNull() && data.Version.ValueString() != cluster.UserVersion {
+				continue
+			}
+
+			if !push(r.listResult(ctx, req, cluster)) {
+				return
+			}
+		}
+	}
+}
+
+func (r *ClusterListResource) listResult(ctx context.Context, req list.ListRequest, cluster *client.Cluster) list.ListResult {
+	result := req.NewListResult(ctx)
+	result.DisplayName = cluster.Name
+
+	result.Diagnostics.Append(result.Identity.Set(ctx, ClusterResourceIdentityModel{ClusterID: types.Int64Value(cluster.ID)})...)
+
+	if req.IncludeResource {
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("id"), cluster.ID)...)
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("name"), cluster.Name)...)
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("version"), cluster.UserVersion)...)
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("patch_version"), cluster.PatchVersion)...)
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("pod_cidr"), cluster.PodCIDR)...)
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("service_cidr"), cluster.ServiceCIDR)...)
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("dns_service_ip"), cluster.DNSServiceIP)...)
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("addon_kube_proxy"), cluster.AddonKubeProxy)...)
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("addon_core_dns"), cluster.AddonCoreDNS)...)
+	}
+
+	return result
+}
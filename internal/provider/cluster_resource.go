@@ -3,23 +3,39 @@ package provider
 import (
 	"context"
 	"fmt"
-	"regexp"
-	"strconv"
+	"net/netip"
+	"reflect"
+	"sort"
 	"terraform-provider-meltcloud/internal/client"
 	"terraform-provider-meltcloud/internal/kubernetes"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gopkg.in/yaml.v3"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ClusterResource{}
 var _ resource.ResourceWithImportState = &ClusterResource{}
+var _ resource.ResourceWithIdentity = &ClusterResource{}
+var _ resource.ResourceWithModifyPlan = &ClusterResource{}
+var _ resource.ResourceWithValidateConfig = &ClusterResource{}
 
 func NewClusterResource() resource.Resource {
 	return &ClusterResource{}
@@ -44,6 +60,50 @@ type ClusterResourceModel struct {
 	KubeConfigRaw     types.String `tfsdk:"kubeconfig_raw"`
 	KubeConfig        types.Object `tfsdk:"kubeconfig"`
 	KubeConfigUserRaw types.String `tfsdk:"kubeconfig_user_raw"`
+	KubeConfigUser    types.Object `tfsdk:"kubeconfig_user"`
+	StoreKubeConfig   types.Bool   `tfsdk:"store_kubeconfig"`
+
+	ClientCertificateExpiresAt    timetypes.RFC3339 `tfsdk:"client_certificate_expires_at"`
+	ClusterCACertificateSHA256    types.String      `tfsdk:"cluster_ca_certificate_sha256"`
+	ClusterCACertificateExpiresAt timetypes.RFC3339 `tfsdk:"cluster_ca_certificate_expires_at"`
+	RenewBefore                   types.String      `tfsdk:"renew_before"`
+
+	KubeConfigUserExec *KubeConfigUserExecModel `tfsdk:"kubeconfig_user_exec"`
+
+	Addons types.List `tfsdk:"addon"`
+
+	SkipUpgradeChecks types.Bool `tfsdk:"skip_upgrade_checks"`
+
+	MaintenanceWindow *MaintenanceWindowModel `tfsdk:"maintenance_window"`
+	AutoUpgrade       *AutoUpgradeModel       `tfsdk:"auto_upgrade"`
+	NextMaintenanceAt timetypes.RFC3339       `tfsdk:"next_maintenance_at"`
+
+	DeletionProtection types.Bool `tfsdk:"deletion_protection"`
+
+	APIServerAllowedCIDRs    types.List   `tfsdk:"api_server_allowed_cidrs"`
+	APIServerPrivateEndpoint types.Bool   `tfsdk:"api_server_private_endpoint"`
+	APIServerCertSANs        types.List   `tfsdk:"api_server_cert_sans"`
+	APIServerDNSName         types.String `tfsdk:"api_server_dns_name"`
+
+	OIDC                 *ClusterOIDCModel `tfsdk:"oidc"`
+	ServiceAccountIssuer types.String      `tfsdk:"service_account_issuer"`
+}
+
+type ClusterAddonResourceModel struct {
+	Name    types.String `tfsdk:"name"`
+	Version types.String `tfsdk:"version"`
+	Values  types.String `tfsdk:"values"`
+}
+
+var clusterAddonAttributeTypes = map[string]attr.Type{
+	"name":    types.StringType,
+	"version": types.StringType,
+	"values":  types.StringType,
+}
+
+// ClusterResourceIdentityModel describes the resource identity data model.
+type ClusterResourceIdentityModel struct {
+	ClusterID types.Int64 `tfsdk:"cluster_id"`
 }
 
 type KubeConfigResourceModel struct {
@@ -53,6 +113,32 @@ type KubeConfigResourceModel struct {
 	ClientCertificate    types.String `tfsdk:"client_certificate"`
 	ClientKey            types.String `tfsdk:"client_key"`
 	ClusterCACertificate types.String `tfsdk:"cluster_ca_certificate"`
+	Token                types.String `tfsdk:"token"`
+	ProxyURL             types.String `tfsdk:"proxy_url"`
+	TLSServerName        types.String `tfsdk:"tls_server_name"`
+	Exec                 *ExecModel   `tfsdk:"exec"`
+}
+
+type KubeConfigUserModel struct {
+	Host                 types.String `tfsdk:"host"`
+	ClusterCACertificate types.String `tfsdk:"cluster_ca_certificate"`
+	IssuerURL            types.String `tfsdk:"issuer_url"`
+	ClientID             types.String `tfsdk:"client_id"`
+	Scopes               types.List   `tfsdk:"scopes"`
+	Exec                 *ExecModel   `tfsdk:"exec"`
+}
+
+type KubeConfigUserExecModel struct {
+	Command types.String `tfsdk:"command"`
+	Args    types.List   `tfsdk:"args"`
+	Env     types.Map    `tfsdk:"env"`
+}
+
+type ExecModel struct {
+	APIVersion types.String `tfsdk:"api_version"`
+	Command    types.String `tfsdk:"command"`
+	Args       types.List   `tfsdk:"args"`
+	Env        types.Map    `tfsdk:"env"`
 }
 
 func (r *ClusterResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -74,54 +160,117 @@ func clusterResourceAttributes() map[string]schema.Attribute {
 			MarkdownDescription: "Name of the cluster, not case-sensitive. Must be unique within the organization and consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com')",
 			Required:            true,
 			PlanModifiers: []planmodifier.String{
-				stringplanmodifier.RequiresReplace(),
+				requiresReplaceUnlessProtectedString("cluster"),
+			},
+			Validators: []validator.String{
+				stringvalidator.LengthAtMost(dns1123SubdomainMaxLength),
+				stringvalidator.RegexMatches(dns1123SubdomainPattern, "must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character"),
 			},
 		},
 		"version": schema.StringAttribute{
-			MarkdownDescription: "Kubernetes minor version of the cluster control plane",
+			MarkdownDescription: "Kubernetes minor version of the cluster control plane. Must be one of the versions of the `meltcloud_kubernetes_versions` data source, plans warn when it nears its end of support.",
 			Required:            true,
 		},
+		"skip_upgrade_checks": skipUpgradeChecksAttribute(),
+		"deletion_protection": deletionProtectionAttribute("cluster"),
 		"patch_version": schema.StringAttribute{
-			MarkdownDescription: "Kubernetes patch version of the cluster control plane",
+			MarkdownDescription: "Kubernetes patch version of the cluster control plane, rolled out by meltcloud according to `auto_upgrade` and `maintenance_window`",
 			Computed:            true,
 		},
+		"next_maintenance_at": nextMaintenanceAtAttribute(),
 		"pod_cidr": schema.StringAttribute{
-			MarkdownDescription: "CIDR for the Kubernetes Pods. If not specified, a default will be assigned automatically.",
-			Optional:            true,
-			Computed:            true,
+			MarkdownDescription: "CIDR for the Kubernetes Pods. If not specified, a default will be assigned automatically. " +
+				"Must be at least a /24 (IPv4) or /64 (IPv6), as each node gets a range of that size, and must not overlap `service_cidr`.",
+			Optional: true,
+			Computed: true,
 			PlanModifiers: []planmodifier.String{
-				stringplanmodifier.RequiresReplace(),
+				requiresReplaceUnlessProtectedString("cluster"),
 				stringplanmodifier.UseStateForUnknown(),
 			},
+			Validators: []validator.String{
+				cidrValidator{maxPrefixLength4: kubernetes.MaxPodCIDRPrefixLength4, maxPrefixLength6: kubernetes.MaxPodCIDRPrefixLength6},
+			},
 		},
 		"service_cidr": schema.StringAttribute{
-			MarkdownDescription: "CIDR for the Kubernetes Services. If not specified, a default will be assigned automatically.",
-			Optional:            true,
-			Computed:            true,
+			MarkdownDescription: "CIDR for the Kubernetes Services. If not specified, a default will be assigned automatically. " +
+				"Must be at least a /28 (IPv4) or /124 (IPv6).",
+			Optional: true,
+			Computed: true,
 			PlanModifiers: []planmodifier.String{
-				stringplanmodifier.RequiresReplace(),
+				requiresReplaceUnlessProtectedString("cluster"),
 				stringplanmodifier.UseStateForUnknown(),
 			},
+			Validators: []validator.String{
+				cidrValidator{maxPrefixLength4: kubernetes.MaxServiceCIDRPrefixLength4, maxPrefixLength6: kubernetes.MaxServiceCIDRPrefixLength6},
+			},
 		},
 		"dns_service_ip": schema.StringAttribute{
-			MarkdownDescription: "IP for the DNS service. If not specified, it is derived from the service CIDR automatically.",
-			Optional:            true,
-			Computed:            true,
+			MarkdownDescription: "IP for the DNS service. If not specified, it is derived from the service CIDR automatically (see the `cluster_dns_ip` function). " +
+				"Must be within `service_cidr` and must not be its network address.",
+			Optional: true,
+			Computed: true,
 			PlanModifiers: []planmodifier.String{
-				stringplanmodifier.RequiresReplace(),
+				requiresReplaceUnlessProtectedString("cluster"),
 				stringplanmodifier.UseStateForUnknown(),
 			},
+			Validators: []validator.String{
+				ipAddressValidator{},
+			},
 		},
 		"addon_kube_proxy": schema.BoolAttribute{
-			MarkdownDescription: "Enable kube-proxy Addon",
+			MarkdownDescription: "Enable kube-proxy Addon. Can be enabled on an existing cluster, but not disabled.",
 			Optional:            true,
 			Computed:            true,
+			PlanModifiers: []planmodifier.Bool{
+				boolplanmodifier.UseStateForUnknown(),
+			},
 		},
 		"addon_core_dns": schema.BoolAttribute{
-			MarkdownDescription: "Enable CoreDNS Addon",
+			MarkdownDescription: "Enable CoreDNS Addon. Can be enabled and disabled on an existing cluster.",
+			Optional:            true,
+			Computed:            true,
+			PlanModifiers: []planmodifier.Bool{
+				boolplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"api_server_allowed_cidrs": schema.ListAttribute{
+			MarkdownDescription: "CIDRs allowed to connect to the API server, e.g. the office network and the CI runners. If empty, the API server accepts connections from everywhere. " +
+				"Include the network running Terraform if other providers connect to the cluster with `kubeconfig`.",
+			ElementType: types.StringType,
+			Optional:    true,
+			Computed:    true,
+			Default:     listdefault.StaticValue(types.ListValueMust(types.StringType, []attr.Value{})),
+			Validators: []validator.List{
+				listvalidator.ValueStringsAre(cidrValidator{maxPrefixLength4: 32, maxPrefixLength6: 128}),
+			},
+		},
+		"api_server_private_endpoint": schema.BoolAttribute{
+			MarkdownDescription: "Expose the API server only on a private endpoint in the network of the machines. " +
+				"`kubeconfig.host` then points to the private endpoint, which is not reachable from outside that network. Defaults to `false`.",
+			Optional: true,
+			Computed: true,
+			Default:  booldefault.StaticBool(false),
+		},
+		"api_server_cert_sans": schema.ListAttribute{
+			MarkdownDescription: "Additional DNS names and IP addresses in the API server certificate, e.g. of a load balancer in front of the API server",
+			ElementType:         types.StringType,
 			Optional:            true,
 			Computed:            true,
+			Default:             listdefault.StaticValue(types.ListValueMust(types.StringType, []attr.Value{})),
+			Validators: []validator.List{
+				listvalidator.ValueStringsAre(subjectAlternativeNameValidator{}),
+			},
 		},
+		"api_server_dns_name": schema.StringAttribute{
+			MarkdownDescription: "Custom DNS name of the API server, e.g. `k8s.example.com`. It is added to the API server certificate and used as `kubeconfig.host`, " +
+				"the DNS record must point to the API server endpoint.",
+			Optional: true,
+			Validators: []validator.String{
+				stringvalidator.LengthAtMost(dns1123SubdomainMaxLength),
+				stringvalidator.RegexMatches(dns1123SubdomainPattern, "must be a lowercase DNS name like k8s.example.com"),
+			},
+		},
+		"service_account_issuer": serviceAccountIssuerAttribute(),
 		"kubeconfig": schema.SingleNestedAttribute{
 			Description: "Kubeconfig values for the admin user",
 			Attributes: map[string]schema.Attribute{
@@ -149,6 +298,36 @@ func clusterResourceAttributes() map[string]schema.Attribute {
 					Computed:  true,
 					Sensitive: true,
 				},
+				"token": schema.StringAttribute{
+					Computed:  true,
+					Sensitive: true,
+				},
+				"proxy_url": schema.StringAttribute{
+					Computed: true,
+				},
+				"tls_server_name": schema.StringAttribute{
+					Computed: true,
+				},
+				"exec": schema.SingleNestedAttribute{
+					Description: "Credential plugin to run to obtain credentials, null if the kubeconfig uses static credentials",
+					Attributes: map[string]schema.Attribute{
+						"api_version": schema.StringAttribute{
+							Computed: true,
+						},
+						"command": schema.StringAttribute{
+							Computed: true,
+						},
+						"args": schema.ListAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						"env": schema.MapAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+					},
+					Computed: true,
+				},
 			},
 			Computed:  true,
 			Sensitive: true,
@@ -159,21 +338,254 @@ func clusterResourceAttributes() map[string]schema.Attribute {
 			Sensitive:   true,
 		},
 		"kubeconfig_user_raw": schema.StringAttribute{
-			Description: "Kubeconfig file for the regular (OIDC) users",
-			Computed:    true,
-			Sensitive:   true,
+			MarkdownDescription: "Kubeconfig file for the regular (OIDC) users, rendered with `kubeconfig_user_exec` if set",
+			Computed:            true,
+			Sensitive:           true,
+		},
+		"kubeconfig_user": schema.SingleNestedAttribute{
+			MarkdownDescription: "OIDC settings of the kubeconfig for the regular (OIDC) users, e.g. to build developer tooling",
+			Attributes: map[string]schema.Attribute{
+				"host": schema.StringAttribute{
+					Computed: true,
+				},
+				"cluster_ca_certificate": schema.StringAttribute{
+					Computed: true,
+				},
+				"issuer_url": schema.StringAttribute{
+					Computed: true,
+				},
+				"client_id": schema.StringAttribute{
+					Computed: true,
+				},
+				"scopes": schema.ListAttribute{
+					Description: "Scopes requested in addition to openid",
+					ElementType: types.StringType,
+					Computed:    true,
+				},
+				"exec": schema.SingleNestedAttribute{
+					Description: "Credential plugin obtaining the OIDC token",
+					Attributes: map[string]schema.Attribute{
+						"api_version": schema.StringAttribute{
+							Computed: true,
+						},
+						"command": schema.StringAttribute{
+							Computed: true,
+						},
+						"args": schema.ListAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						"env": schema.MapAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+					},
+					Computed:  true,
+					Sensitive: true,
+				},
+			},
+			Computed: true,
+		},
+		"kubeconfig_user_exec": schema.SingleNestedAttribute{
+			MarkdownDescription: "Render `kubeconfig_user` with this exec plugin instead of the one returned by meltcloud.",
+			Attributes: map[string]schema.Attribute{
+				"command": schema.StringAttribute{
+					MarkdownDescription: "Command to run. Defaults to `kubectl`, which runs `kubectl oidc-login`. Any other command is expected to be a standalone " +
+						"[kubelogin](https://github.com/int128/kubelogin) compatible binary, unless `args` are set.",
+					Optional: true,
+				},
+				"args": schema.ListAttribute{
+					MarkdownDescription: "Arguments of the command, replacing the generated `get-token --oidc-issuer-url=... --oidc-client-id=...` arguments",
+					ElementType:         types.StringType,
+					Optional:            true,
+				},
+				"env": schema.MapAttribute{
+					MarkdownDescription: "Environment variables for the command",
+					ElementType:         types.StringType,
+					Optional:            true,
+				},
+			},
+			Optional: true,
+		},
+		"store_kubeconfig": schema.BoolAttribute{
+			MarkdownDescription: "Whether to store `kubeconfig`, `kubeconfig_raw`, `kubeconfig_user_raw` and `kubeconfig_user` in the Terraform state. " +
+				"Set to `false` and use the `meltcloud_cluster_credentials` ephemeral resource to keep the cluster credentials out of the state. Defaults to `true`.",
+			Optional: true,
+			Computed: true,
+			Default:  booldefault.StaticBool(true),
+		},
+		"client_certificate_expires_at": schema.StringAttribute{
+			MarkdownDescription: "Expiry of the client certificate in the admin kubeconfig",
+			CustomType:          timetypes.RFC3339Type{},
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"cluster_ca_certificate_sha256": schema.StringAttribute{
+			MarkdownDescription: "SHA-256 fingerprint of the cluster CA certificate, hex encoded",
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"cluster_ca_certificate_expires_at": schema.StringAttribute{
+			MarkdownDescription: "Expiry of the cluster CA certificate",
+			CustomType:          timetypes.RFC3339Type{},
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"renew_before": schema.StringAttribute{
+			MarkdownDescription: "Rotate the admin credentials when the client certificate expires within this duration (e.g. `720h`). " +
+				"The rotation is planned as an in-place update of the cluster. If not set, credentials are never rotated by Terraform.",
+			Optional: true,
+			Validators: []validator.String{
+				stringvalidator.RegexMatches(durationPattern, "must be a duration like 720h"),
+			},
 		},
 	}
 }
 
+func clusterAddonResourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"name": schema.StringAttribute{
+			MarkdownDescription: "Name of the addon, e.g. `cilium` or `metrics-server`. See the `meltcloud_addons` data source for the available addons. " +
+				"kube-proxy and CoreDNS are managed with `addon_kube_proxy` and `addon_core_dns`.",
+			Required: true,
+			Validators: []validator.String{
+				stringvalidator.NoneOfCaseInsensitive(clusterBuiltinAddons...),
+			},
+		},
+		"version": schema.StringAttribute{
+			MarkdownDescription: "Version of the addon. If not specified, the default version for the Kubernetes version of the cluster is installed " +
+				"and the version is not tracked, see the `addons` of the `meltcloud_cluster` data source for the installed version.",
+			Optional: true,
+		},
+		"values": schema.StringAttribute{
+			MarkdownDescription: "YAML or JSON document configuring the addon, e.g. `yamlencode({ ... })`.",
+			Optional:            true,
+		},
+	}
+}
+
+// clusterBuiltinAddons are managed with dedicated attributes and cannot be used in addon blocks.
+var clusterBuiltinAddons = []string{"kube-proxy", "coredns"}
+
 func (r *ClusterResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: clusterDesc,
 
 		Attributes: clusterResourceAttributes(),
+
+		Blocks: map[string]schema.Block{
+			"addon": schema.ListNestedBlock{
+				MarkdownDescription: "Addons to install on the cluster, e.g. a CNI, metrics-server or a CSI driver. " +
+					"Addons are installed, updated and removed in place, addons installed outside of Terraform are removed. " +
+					"If no addon block is specified, the addons are not managed by Terraform, removing the last block leaves its addon installed.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: clusterAddonResourceAttributes(),
+				},
+			},
+			"maintenance_window": maintenanceWindowBlock("control plane"),
+			"auto_upgrade":       autoUpgradeBlock("control plane"),
+			"oidc":               oidcBlock(),
+		},
+	}
+}
+
+func (r *ClusterResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"cluster_id": identityschema.Int64Attribute{
+				Description:       "Internal ID of the Cluster in meltcloud",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+// ValidateConfig checks the network settings against each other and that addons are unique, the attribute validators
+// check the syntax of the values.
+func (r *ClusterResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ClusterResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateAddonNames(ctx, data.Addons)...)
+
+	// invalid values are reported by the attribute validators
+	podCIDR, podCIDRErr := parseConfiguredCIDR(data.PodCIDR)
+	serviceCIDR, serviceCIDRErr := parseConfiguredCIDR(data.ServiceCIDR)
+
+	if podCIDRErr == nil && serviceCIDRErr == nil && podCIDR.Overlaps(serviceCIDR) {
+		resp.Diagnostics.AddAttributeError(path.Root("service_cidr"), "Overlapping CIDRs",
+			fmt.Sprintf("service_cidr %s overlaps pod_cidr %s, Pods and Services must use separate ranges.", serviceCIDR, podCIDR))
+	}
+
+	if serviceCIDRErr != nil || data.DNSServiceIP.IsNull() || data.DNSServiceIP.IsUnknown() {
+		return
+	}
+
+	dnsServiceIP, err := netip.ParseAddr(data.DNSServiceIP.ValueString())
+	if err != nil {
+		return
+	}
+
+	if !serviceCIDR.Contains(dnsServiceIP) {
+		resp.Diagnostics.AddAttributeError(path.Root("dns_service_ip"), "Invalid DNS Service IP",
+			fmt.Sprintf("dns_service_ip %s is not within service_cidr %s.", dnsServiceIP, serviceCIDR))
+		return
+	}
+
+	if dnsServiceIP == serviceCIDR.Addr() {
+		resp.Diagnostics.AddAttributeError(path.Root("dns_service_ip"), "Invalid DNS Service IP",
+			fmt.Sprintf("dns_service_ip %s is the network address of service_cidr %s and cannot be assigned to a Service.", dnsServiceIP, serviceCIDR))
 	}
 }
 
+// validateAddonNames returns an error for each addon configured more than once.
+func validateAddonNames(ctx context.Context, value types.List) diag.Diagnostics {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+
+	var addons []ClusterAddonResourceModel
+	diags := value.ElementsAs(ctx, &addons, false)
+	if diags.HasError() {
+		return diags
+	}
+
+	seen := map[string]bool{}
+	for i, addon := range addons {
+		if addon.Name.IsNull() || addon.Name.IsUnknown() {
+			continue
+		}
+
+		name := addon.Name.ValueString()
+		if seen[name] {
+			diags.AddAttributeError(path.Root("addon").AtListIndex(i).AtName("name"), "Duplicate Addon",
+				fmt.Sprintf("The %s addon is configured more than once.", name))
+		}
+		seen[name] = true
+	}
+
+	return diags
+}
+
+// parseConfiguredCIDR returns the CIDR of a configured attribute, an error if it is not set, unknown or invalid.
+func parseConfiguredCIDR(value types.String) (netip.Prefix, error) {
+	if value.IsNull() || value.IsUnknown() {
+		return netip.Prefix{}, fmt.Errorf("CIDR is not known")
+	}
+
+	return kubernetes.ParseCIDR(value.ValueString())
+}
+
 func (r *ClusterResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -211,6 +623,12 @@ func (r *ClusterResource) Create(ctx context.Context, req resource.CreateRequest
 	if !data.AddonCoreDNS.IsNull() && !data.AddonCoreDNS.IsUnknown() {
 		addonCoreDNS = data.AddonCoreDNS.ValueBoolPointer()
 	}
+	addons, diags := r.addonInput(ctx, data.Addons)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var podCIDR *string
 	if !data.PodCIDR.IsNull() && !data.PodCIDR.IsUnknown() {
 		podCIDR = data.PodCIDR.ValueStringPointer()
@@ -226,6 +644,18 @@ func (r *ClusterResource) Create(ctx context.Context, req resource.CreateRequest
 		dnsServiceIP = data.DNSServiceIP.ValueStringPointer()
 	}
 
+	allowedCIDRs, certSANs, diags := r.apiServerInput(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	oidc, diags := oidcInput(ctx, data.OIDC)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	clusterCreateInput := &client.ClusterCreateInput{
 		Name:           data.Name.ValueString(),
 		UserVersion:    data.Version.ValueString(),
@@ -234,6 +664,20 @@ func (r *ClusterResource) Create(ctx context.Context, req resource.CreateRequest
 		DNSServiceIP:   dnsServiceIP,
 		AddonKubeProxy: addonKubeProxy,
 		AddonCoreDNS:   addonCoreDNS,
+		Addons:         addonList(addons),
+
+		MaintenanceWindow: maintenanceWindowInput(data.MaintenanceWindow),
+		AutoUpgrade:       autoUpgradeInput(data.AutoUpgrade),
+
+		DeletionProtection: data.DeletionProtection.ValueBoolPointer(),
+
+		APIServerAllowedCIDRs:    allowedCIDRs,
+		APIServerPrivateEndpoint: data.APIServerPrivateEndpoint.ValueBool(),
+		APIServerCertSANs:        certSANs,
+		APIServerDNSName:         data.APIServerDNSName.ValueString(),
+
+		OIDC:                 oidc,
+		ServiceAccountIssuer: data.ServiceAccountIssuer.ValueString(),
 	}
 
 	clusterCreateResult, err := r.client.Cluster().Create(ctx, clusterCreateInput)
@@ -262,17 +706,31 @@ func (r *ClusterResource) Create(ctx context.Context, req resource.CreateRequest
 	data.Version = types.StringValue(clusterGetResult.Cluster.UserVersion)
 	data.PatchVersion = types.StringValue(clusterGetResult.Cluster.PatchVersion)
 	r.setValues(clusterGetResult.Cluster, &data)
+	resp.Diagnostics.Append(setCertificateValues(&data, clusterGetResult.Cluster.KubeConfig)...)
+	resp.Diagnostics.Append(r.setAddons(ctx, clusterGetResult.Cluster, &data)...)
+	resp.Diagnostics.Append(r.setAPIServer(ctx, clusterGetResult.Cluster, &data)...)
+	resp.Diagnostics.Append(r.setOIDC(ctx, clusterGetResult.Cluster, &data)...)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, ClusterResourceIdentityModel{ClusterID: data.ID})...)
 
-	kubeConfigResourceModel, kErr := r.getKubeConfigResourceModel(clusterGetResult.Cluster.KubeConfig)
+	kubeConfigResourceModel, kErr := r.kubeConfigState(&data, clusterGetResult.Cluster.KubeConfig)
 	if kErr != nil {
 		resp.Diagnostics.AddError("Client Error", kErr.Error())
 		return
 	}
 
-	diags := resp.State.SetAttribute(ctx, path.Root("kubeconfig"), kubeConfigResourceModel)
+	diags = resp.State.SetAttribute(ctx, path.Root("kubeconfig"), kubeConfigResourceModel)
 	resp.Diagnostics.Append(diags...)
+
+	kubeConfigUserModel, kubeConfigUserRaw, uDiags := r.kubeConfigUserState(ctx, &data, clusterGetResult.Cluster.KubeConfigUser)
+	resp.Diagnostics.Append(uDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("kubeconfig_user"), kubeConfigUserModel)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("kubeconfig_user_raw"), kubeConfigUserRaw)...)
 }
 
 func (r *ClusterResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -295,10 +753,19 @@ func (r *ClusterResource) Read(ctx context.Context, req resource.ReadRequest, re
 	}
 	data.Version = types.StringValue(result.Cluster.UserVersion)
 	data.PatchVersion = types.StringValue(result.Cluster.PatchVersion)
+	if data.StoreKubeConfig.IsNull() {
+		// not set after import
+		data.StoreKubeConfig = types.BoolValue(true)
+	}
 	r.setValues(result.Cluster, &data)
+	resp.Diagnostics.Append(setCertificateValues(&data, result.Cluster.KubeConfig)...)
+	resp.Diagnostics.Append(r.setAddons(ctx, result.Cluster, &data)...)
+	resp.Diagnostics.Append(r.setAPIServer(ctx, result.Cluster, &data)...)
+	resp.Diagnostics.Append(r.setOIDC(ctx, result.Cluster, &data)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, ClusterResourceIdentityModel{ClusterID: data.ID})...)
 
-	kubeConfigResourceModel, kErr := r.getKubeConfigResourceModel(result.Cluster.KubeConfig)
+	kubeConfigResourceModel, kErr := r.kubeConfigState(&data, result.Cluster.KubeConfig)
 	if kErr != nil {
 		resp.Diagnostics.AddError("Client Error", kErr.Error())
 		return
@@ -306,6 +773,15 @@ func (r *ClusterResource) Read(ctx context.Context, req resource.ReadRequest, re
 
 	diags := resp.State.SetAttribute(ctx, path.Root("kubeconfig"), kubeConfigResourceModel)
 	resp.Diagnostics.Append(diags...)
+
+	kubeConfigUserModel, kubeConfigUserRaw, uDiags := r.kubeConfigUserState(ctx, &data, result.Cluster.KubeConfigUser)
+	resp.Diagnostics.Append(uDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("kubeconfig_user"), kubeConfigUserModel)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("kubeconfig_user_raw"), kubeConfigUserRaw)...)
 }
 
 func (r *ClusterResource) setValues(result *client.Cluster, data *ClusterResourceModel) {
@@ -315,38 +791,462 @@ func (r *ClusterResource) setValues(result *client.Cluster, data *ClusterResourc
 	data.DNSServiceIP = types.StringValue(result.DNSServiceIP)
 	data.AddonKubeProxy = types.BoolValue(result.AddonKubeProxy)
 	data.AddonCoreDNS = types.BoolValue(result.AddonCoreDNS)
-	data.KubeConfigRaw = types.StringValue(result.KubeConfig)
-	data.KubeConfigUserRaw = types.StringValue(result.KubeConfigUser)
+	data.MaintenanceWindow = maintenanceWindowModel(result.MaintenanceWindow)
+	data.AutoUpgrade = autoUpgradeModel(result.AutoUpgrade)
+	data.NextMaintenanceAt = nextMaintenanceAtValue(result.NextMaintenanceAt)
+	data.DeletionProtection = types.BoolValue(result.DeletionProtection)
+
+	if data.StoreKubeConfig.ValueBool() {
+		data.KubeConfigRaw = types.StringValue(result.KubeConfig)
+		data.KubeConfigUserRaw = types.StringValue(result.KubeConfigUser)
+	} else {
+		data.KubeConfigRaw = types.StringNull()
+		data.KubeConfigUserRaw = types.StringNull()
+	}
+}
+
+// addonInput returns the addons to send to the API, nil to leave them unchanged if no addon block is configured.
+func (r *ClusterResource) addonInput(ctx context.Context, value types.List) (*[]client.ClusterAddon, diag.Diagnostics) {
+	var addons []ClusterAddonResourceModel
+	diags := value.ElementsAs(ctx, &addons, false)
+	if diags.HasError() || len(addons) == 0 {
+		return nil, diags
+	}
+
+	addonInput := make([]client.ClusterAddon, 0, len(addons))
+	for _, addon := range addons {
+		addonInput = append(addonInput, client.ClusterAddon{
+			Name:    addon.Name.ValueString(),
+			Version: addon.Version.ValueString(),
+			Values:  addon.Values.ValueString(),
+		})
+	}
+
+	return &addonInput, diags
 }
 
-func (r *ClusterResource) getKubeConfigResourceModel(kubeconfig string) (*KubeConfigResourceModel, error) {
+// addonList dereferences the result of addonInput, nil if the addons are left unchanged.
+func addonList(addons *[]client.ClusterAddon) []client.ClusterAddon {
+	if addons == nil {
+		return nil
+	}
+
+	return *addons
+}
+
+// setAddons sets the addon blocks from the cluster, in the order of the blocks already in data so that the API order
+// does not cause a diff. Addons not in data are appended, unless data has no addon blocks and the addons are not managed.
+func (r *ClusterResource) setAddons(ctx context.Context, result *client.Cluster, data *ClusterResourceModel) diag.Diagnostics {
+	var prior []ClusterAddonResourceModel
+	if !data.Addons.IsNull() && !data.Addons.IsUnknown() {
+		diags := data.Addons.ElementsAs(ctx, &prior, false)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	if len(prior) == 0 {
+		data.Addons = types.ListValueMust(types.ObjectType{AttrTypes: clusterAddonAttributeTypes}, []attr.Value{})
+		return nil
+	}
+
+	position := map[string]int{}
+	untrackedVersion := map[string]bool{}
+	priorValues := map[string]types.String{}
+	for i, addon := range prior {
+		position[addon.Name.ValueString()] = i
+		untrackedVersion[addon.Name.ValueString()] = addon.Version.IsNull()
+		priorValues[addon.Name.ValueString()] = addon.Values
+	}
+
+	installed := make([]client.ClusterAddon, len(result.Addons))
+	copy(installed, result.Addons)
+	sort.SliceStable(installed, func(i, j int) bool {
+		pi, iok := position[installed[i].Name]
+		pj, jok := position[installed[j].Name]
+		if iok && jok {
+			return pi < pj
+		}
+		return iok && !jok
+	})
+
+	addons := make([]ClusterAddonResourceModel, 0, len(installed))
+	for _, addon := range installed {
+		values := types.StringNull()
+		if addon.Values != "" {
+			values = types.StringValue(addon.Values)
+		}
+
+		// the API may return the document reformatted, e.g. as YAML with other key order
+		if prior, ok := priorValues[addon.Name]; ok && !prior.IsNull() && !prior.IsUnknown() && sameDocument(prior.ValueString(), addon.Values) {
+			values = prior
+		}
+
+		version := types.StringValue(addon.Version)
+		if untrackedVersion[addon.Name] {
+			version = types.StringNull()
+		}
+
+		addons = append(addons, ClusterAddonResourceModel{
+			Name:    types.StringValue(addon.Name),
+			Version: version,
+			Values:  values,
+		})
+	}
+
+	var diags diag.Diagnostics
+	data.Addons, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: clusterAddonAttributeTypes}, addons)
+
+	return diags
+}
+
+// sameDocument returns whether two YAML or JSON documents have the same content.
+func sameDocument(a string, b string) bool {
+	if a == b {
+		return true
+	}
+
+	var aValue, bValue interface{}
+	if err := yaml.Unmarshal([]byte(a), &aValue); err != nil {
+		return false
+	}
+	if err := yaml.Unmarshal([]byte(b), &bValue); err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(aValue, bValue)
+}
+
+// apiServerInput returns the allowed CIDRs and the certificate SANs to send to the API, empty lists if none are configured.
+func (r *ClusterResource) apiServerInput(ctx context.Context, data *ClusterResourceModel) ([]string, []string, diag.Diagnostics) {
+	allowedCIDRs := []string{}
+	certSANs := []string{}
+
+	diags := data.APIServerAllowedCIDRs.ElementsAs(ctx, &allowedCIDRs, false)
+	diags.Append(data.APIServerCertSANs.ElementsAs(ctx, &certSANs, false)...)
+
+	return allowedCIDRs, certSANs, diags
+}
+
+func (r *ClusterResource) setAPIServer(ctx context.Context, result *client.Cluster, data *ClusterResourceModel) diag.Diagnostics {
+	var diags, d diag.Diagnostics
+
+	data.APIServerAllowedCIDRs, d = types.ListValueFrom(ctx, types.StringType, append([]string{}, result.APIServerAllowedCIDRs...))
+	diags.Append(d...)
+	data.APIServerPrivateEndpoint = types.BoolValue(result.APIServerPrivateEndpoint)
+	data.APIServerCertSANs, d = types.ListValueFrom(ctx, types.StringType, append([]string{}, result.APIServerCertSANs...))
+	diags.Append(d...)
+	data.APIServerDNSName = optionalStringValue(result.APIServerDNSName)
+
+	return diags
+}
+
+func (r *ClusterResource) setOIDC(ctx context.Context, result *client.Cluster, data *ClusterResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	data.OIDC, diags = oidcModel(ctx, result.OIDC)
+	data.ServiceAccountIssuer = optionalStringValue(result.ServiceAccountIssuer)
+
+	return diags
+}
+
+// kubeConfigState returns the admin kubeconfig values to store in the state, nil if store_kubeconfig is disabled.
+func (r *ClusterResource) kubeConfigState(data *ClusterResourceModel, kubeconfig string) (*KubeConfigResourceModel, error) {
+	if !data.StoreKubeConfig.ValueBool() {
+		return nil, nil
+	}
+
+	return getKubeConfigResourceModel(kubeconfig)
+}
+
+// kubeConfigUserState returns the user kubeconfig values and the rendered user kubeconfig to store in the state, null if
+// store_kubeconfig is disabled.
+func (r *ClusterResource) kubeConfigUserState(ctx context.Context, data *ClusterResourceModel, kubeconfig string) (*KubeConfigUserModel, types.String, diag.Diagnostics) {
+	if !data.StoreKubeConfig.ValueBool() {
+		return nil, types.StringNull(), nil
+	}
+
+	return getKubeConfigUserModel(ctx, kubeconfig, data.KubeConfigUserExec)
+}
+
+func getKubeConfigResourceModel(kubeconfig string) (*KubeConfigResourceModel, error) {
 	kubeConfig, err := kubernetes.ParseKubeConfig(kubeconfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse kubeconfig error %+v", err)
 	}
 
+	resolved, err := kubeConfig.Resolve("")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse kubeconfig: %s", err)
+	}
+
 	return &KubeConfigResourceModel{
-		Host:                 types.StringValue(kubeConfig.Clusters[0].Cluster.Server),
-		Username:             types.StringValue(kubeConfig.Users[0].Name),
-		Password:             types.StringValue(""),
-		ClientCertificate:    types.StringValue(kubeConfig.Users[0].User.ClientCertificateData),
-		ClientKey:            types.StringValue(kubeConfig.Users[0].User.ClientKeyData),
-		ClusterCACertificate: types.StringValue(kubeConfig.Clusters[0].Cluster.ClusterAuthorityData),
+		Host:                 types.StringValue(resolved.Cluster.Server),
+		Username:             types.StringValue(resolved.UserName),
+		Password:             types.StringValue(resolved.User.Password),
+		ClientCertificate:    types.StringValue(resolved.User.ClientCertificateData),
+		ClientKey:            types.StringValue(resolved.User.ClientKeyData),
+		ClusterCACertificate: types.StringValue(resolved.Cluster.CertificateAuthorityData),
+		Token:                optionalStringValue(resolved.User.BearerToken()),
+		ProxyURL:             optionalStringValue(resolved.Cluster.ProxyURL),
+		TLSServerName:        optionalStringValue(resolved.Cluster.TLSServerName),
+		Exec:                 getExecModel(resolved.User.Exec),
 	}, nil
 }
 
+// getKubeConfigUserModel breaks out the OIDC settings of the user kubeconfig and returns it along with the kubeconfig.
+// If execOptions is set, the kubeconfig is rendered again with that exec plugin.
+func getKubeConfigUserModel(ctx context.Context, kubeconfig string, execOptions *KubeConfigUserExecModel) (*KubeConfigUserModel, types.String, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if kubeconfig == "" {
+		return nil, types.StringValue(kubeconfig), diags
+	}
+
+	kubeConfig, err := kubernetes.ParseKubeConfig(kubeconfig)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("failed to parse user kubeconfig error %+v", err))
+		return nil, types.StringNull(), diags
+	}
+
+	resolved, err := kubeConfig.Resolve("")
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("failed to parse user kubeconfig: %s", err))
+		return nil, types.StringNull(), diags
+	}
+
+	data := &KubeConfigUserModel{
+		Host:                 types.StringValue(resolved.Cluster.Server),
+		ClusterCACertificate: types.StringValue(resolved.Cluster.CertificateAuthorityData),
+		IssuerURL:            types.StringNull(),
+		ClientID:             types.StringNull(),
+		Scopes:               types.ListNull(types.StringType),
+		Exec:                 getExecModel(resolved.User.Exec),
+	}
+
+	oidc := resolved.User.OIDC()
+	if oidc != nil {
+		scopes := make([]attr.Value, 0, len(oidc.ExtraScopes))
+		for _, scope := range oidc.ExtraScopes {
+			scopes = append(scopes, types.StringValue(scope))
+		}
+
+		data.IssuerURL = types.StringValue(oidc.IssuerURL)
+		data.ClientID = types.StringValue(oidc.ClientID)
+		data.Scopes = types.ListValueMust(types.StringType, scopes)
+	}
+
+	if execOptions == nil {
+		return data, types.StringValue(kubeconfig), diags
+	}
+
+	if oidc == nil {
+		diags.AddAttributeError(path.Root("kubeconfig_user_exec"), "Invalid Attribute Configuration", "The kubeconfig for regular users of this cluster does not use OIDC, it cannot be rendered with a different exec plugin.")
+		return nil, types.StringNull(), diags
+	}
+
+	var args []string
+	var env map[string]string
+	diags.Append(execOptions.Args.ElementsAs(ctx, &args, false)...)
+	diags.Append(execOptions.Env.ElementsAs(ctx, &env, false)...)
+	if diags.HasError() {
+		return nil, types.StringNull(), diags
+	}
+
+	exec := oidc.OIDCLoginExec(execOptions.Command.ValueString(), args, env)
+	if err := kubeConfig.SetExec(resolved.UserName, exec); err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("failed to render user kubeconfig: %s", err))
+		return nil, types.StringNull(), diags
+	}
+
+	rendered, err := kubeConfig.Marshal()
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("failed to render user kubeconfig: %s", err))
+		return nil, types.StringNull(), diags
+	}
+
+	data.Exec = getExecModel(exec)
+
+	return data, types.StringValue(rendered), diags
+}
+
+func getExecModel(exec *kubernetes.ExecConfig) *ExecModel {
+	if exec == nil {
+		return nil
+	}
+
+	args := make([]attr.Value, 0, len(exec.Args))
+	for _, arg := range exec.Args {
+		args = append(args, types.StringValue(arg))
+	}
+
+	env := make(map[string]attr.Value, len(exec.Env))
+	for _, e := range exec.Env {
+		env[e.Name] = types.StringValue(e.Value)
+	}
+
+	return &ExecModel{
+		APIVersion: types.StringValue(exec.APIVersion),
+		Command:    types.StringValue(exec.Command),
+		Args:       types.ListValueMust(types.StringType, args),
+		Env:        types.MapValueMust(types.StringType, env),
+	}
+}
+
+// clusterCertificates are the expiry details of the certificates in the admin kubeconfig.
+type clusterCertificates struct {
+	ClientCertificateExpiresAt    timetypes.RFC3339
+	ClusterCACertificateSHA256    types.String
+	ClusterCACertificateExpiresAt timetypes.RFC3339
+}
+
+func setCertificateValues(data *ClusterResourceModel, kubeconfig string) diag.Diagnostics {
+	certificates, diags := getClusterCertificates(kubeconfig)
+
+	data.ClientCertificateExpiresAt = certificates.ClientCertificateExpiresAt
+	data.ClusterCACertificateSHA256 = certificates.ClusterCACertificateSHA256
+	data.ClusterCACertificateExpiresAt = certificates.ClusterCACertificateExpiresAt
+
+	return diags
+}
+
+// getClusterCertificates decodes the client and CA certificate of the admin kubeconfig to track their expiry.
+// Undecodable certificates only warn, the cluster itself is still usable.
+func getClusterCertificates(kubeconfig string) (*clusterCertificates, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	data := &clusterCertificates{
+		ClientCertificateExpiresAt:    timetypes.NewRFC3339Null(),
+		ClusterCACertificateSHA256:    types.StringNull(),
+		ClusterCACertificateExpiresAt: timetypes.NewRFC3339Null(),
+	}
+
+	if kubeconfig == "" {
+		return data, diags
+	}
+
+	kubeConfig, err := kubernetes.ParseKubeConfig(kubeconfig)
+	if err != nil {
+		diags.AddWarning("Unable to read cluster certificates", fmt.Sprintf("failed to parse kubeconfig error %+v", err))
+		return data, diags
+	}
+
+	resolved, err := kubeConfig.Resolve("")
+	if err != nil {
+		diags.AddWarning("Unable to read cluster certificates", fmt.Sprintf("failed to parse kubeconfig: %s", err))
+		return data, diags
+	}
+
+	if resolved.User.ClientCertificateData != "" {
+		certificate, err := kubernetes.DecodeCertificate(resolved.User.ClientCertificateData)
+		if err != nil {
+			diags.AddWarning("Unable to read cluster certificates", fmt.Sprintf("client certificate: %s", err))
+		} else {
+			data.ClientCertificateExpiresAt = timetypes.NewRFC3339TimeValue(certificate.NotAfter)
+		}
+	}
+
+	if resolved.Cluster.CertificateAuthorityData != "" {
+		certificate, err := kubernetes.DecodeCertificate(resolved.Cluster.CertificateAuthorityData)
+		if err != nil {
+			diags.AddWarning("Unable to read cluster certificates", fmt.Sprintf("cluster CA certificate: %s", err))
+		} else {
+			data.ClusterCACertificateSHA256 = types.StringValue(certificate.SHA256Fingerprint)
+			data.ClusterCACertificateExpiresAt = timetypes.NewRFC3339TimeValue(certificate.NotAfter)
+		}
+	}
+
+	return data, diags
+}
+
+// credentialsDueForRenewal returns whether the client certificate expires within renewBefore.
+func credentialsDueForRenewal(renewBefore types.String, expiresAt timetypes.RFC3339) bool {
+	if renewBefore.IsNull() || renewBefore.IsUnknown() || expiresAt.IsNull() || expiresAt.IsUnknown() {
+		return false
+	}
+
+	window, err := time.ParseDuration(renewBefore.ValueString())
+	if err != nil {
+		return false
+	}
+
+	expiry, diags := expiresAt.ValueRFC3339Time()
+	if diags.HasError() {
+		return false
+	}
+
+	return time.Until(expiry) < window
+}
+
+func optionalStringValue(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(value)
+}
+
 func (r *ClusterResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data ClusterResourceModel
+	var data, state ClusterResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// null removes the custom DNS name and resets the service account issuer
+	apiServerDNSName := data.APIServerDNSName.ValueString()
+	serviceAccountIssuer := data.ServiceAccountIssuer.ValueString()
+
 	clusterUpdateInput := &client.ClusterUpdateInput{
-		UserVersion: data.Version.ValueString(),
+		UserVersion:       data.Version.ValueString(),
+		MaintenanceWindow: maintenanceWindowUpdateInput(data.MaintenanceWindow),
+		AutoUpgrade:       autoUpgradeUpdateInput(data.AutoUpgrade),
+
+		DeletionProtection: data.DeletionProtection.ValueBoolPointer(),
+
+		APIServerPrivateEndpoint: data.APIServerPrivateEndpoint.ValueBoolPointer(),
+		APIServerDNSName:         &apiServerDNSName,
+
+		ServiceAccountIssuer: &serviceAccountIssuer,
 	}
 
+	allowedCIDRs, certSANs, diags := r.apiServerInput(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterUpdateInput.APIServerAllowedCIDRs = &allowedCIDRs
+	clusterUpdateInput.APIServerCertSANs = &certSANs
+
+	// an empty value switches back to the meltcloud identity provider
+	clusterUpdateInput.OIDC = &client.ClusterOIDC{}
+	if data.OIDC != nil {
+		clusterUpdateInput.OIDC, diags = oidcInput(ctx, data.OIDC)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if !data.AddonKubeProxy.IsUnknown() {
+		clusterUpdateInput.AddonKubeProxy = data.AddonKubeProxy.ValueBoolPointer()
+	}
+
+	if !data.AddonCoreDNS.IsUnknown() {
+		clusterUpdateInput.AddonCoreDNS = data.AddonCoreDNS.ValueBoolPointer()
+	}
+
+	addons, diags := r.addonInput(ctx, data.Addons)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterUpdateInput.Addons = addons
+
 	result, err := r.client.Cluster().Update(ctx, data.ID.ValueInt64(), clusterUpdateInput)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update cluster, got error: %s", err))
@@ -360,30 +1260,248 @@ func (r *ClusterResource) Update(ctx context.Context, req resource.UpdateRequest
 			return
 		}
 
-		_, err := r.client.Cluster().Get(ctx, data.ID.ValueInt64())
+		result, err = r.client.Cluster().Get(ctx, data.ID.ValueInt64())
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read cluster, got error: %s", err))
 			return
 		}
 	}
+
+	resp.Diagnostics.Append(verifyAddons(&data, addons, result.Cluster)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// rotate only if planned by planCredentialRotation, the certificate may have entered renew_before since the plan
+	// was made, and the kubeconfig was then planned to stay unchanged
+	if data.ClientCertificateExpiresAt.IsUnknown() {
+		rotateResult, err := r.client.Cluster().RotateCredentials(ctx, data.ID.ValueInt64())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to rotate cluster credentials, got error: %s", err))
+			return
+		}
+
+		if rotateResult.Operation != nil {
+			_, err = r.client.Operation().PollUntilDone(ctx, rotateResult.Operation.ID)
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to rotate cluster credentials, got error: %s", err))
+				return
+			}
+		}
+
+		result, err = r.client.Cluster().Get(ctx, data.ID.ValueInt64())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read cluster, got error: %s", err))
+			return
+		}
+	}
+
 	r.setValues(result.Cluster, &data)
+	resp.Diagnostics.Append(setCertificateValues(&data, result.Cluster.KubeConfig)...)
+	resp.Diagnostics.Append(r.setAddons(ctx, result.Cluster, &data)...)
+	resp.Diagnostics.Append(r.setAPIServer(ctx, result.Cluster, &data)...)
+	resp.Diagnostics.Append(r.setOIDC(ctx, result.Cluster, &data)...)
 	data.PatchVersion = types.StringValue(result.Cluster.PatchVersion)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, ClusterResourceIdentityModel{ClusterID: data.ID})...)
 
-	kubeConfigResourceModel, kErr := r.getKubeConfigResourceModel(result.Cluster.KubeConfig)
+	kubeConfigResourceModel, kErr := r.kubeConfigState(&data, result.Cluster.KubeConfig)
 	if kErr != nil {
 		resp.Diagnostics.AddError("Client Error", kErr.Error())
 		return
 	}
 
-	diags := resp.State.SetAttribute(ctx, path.Root("kubeconfig"), kubeConfigResourceModel)
+	diags = resp.State.SetAttribute(ctx, path.Root("kubeconfig"), kubeConfigResourceModel)
 	resp.Diagnostics.Append(diags...)
 
+	kubeConfigUserModel, kubeConfigUserRaw, uDiags := r.kubeConfigUserState(ctx, &data, result.Cluster.KubeConfigUser)
+	resp.Diagnostics.Append(uDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("kubeconfig_user"), kubeConfigUserModel)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("kubeconfig_user_raw"), kubeConfigUserRaw)...)
+
+}
+
+// ModifyPlan validates the planned cluster and marks the values that change with it as unknown.
+func (r *ClusterResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		resp.Diagnostics.Append(checkDeletionProtection(ctx, req.State, "cluster", "the plan destroys it")...)
+		return
+	}
+
+	var plan, state ClusterResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.validateClusterVersion(ctx, &plan, &state)...)
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateAddonTransitions(&plan, &state)...)
+	resp.Diagnostics.Append(r.warnClusterReplacement(ctx, &plan, &state)...)
+	planKubeConfigs(ctx, req, resp, &plan, &state)
+	planNextMaintenanceAt(ctx, req, resp)
+	planCredentialRotation(ctx, resp, &plan, &state)
+}
+
+// validateClusterVersion checks the planned version against the supported versions and, unless skip_upgrade_checks is
+// set, the change from the prior version.
+func (r *ClusterResource) validateClusterVersion(ctx context.Context, plan *ClusterResourceModel, state *ClusterResourceModel) diag.Diagnostics {
+	diags := validateKubernetesVersion(ctx, r.client, path.Root("version"), plan.Version, state.Version)
+
+	if !plan.SkipUpgradeChecks.ValueBool() {
+		diags.Append(validateVersionChange(path.Root("version"), plan.Version, state.Version)...)
+	}
+
+	return diags
+}
+
+// warnClusterReplacement warns about the pools rebuilt if a change replaces the cluster.
+func (r *ClusterResource) warnClusterReplacement(ctx context.Context, plan *ClusterResourceModel, state *ClusterResourceModel) diag.Diagnostics {
+	replacing := replacingAttributes(
+		attributeChange{"name", plan.Name, state.Name},
+		attributeChange{"pod_cidr", plan.PodCIDR, state.PodCIDR},
+		attributeChange{"service_cidr", plan.ServiceCIDR, state.ServiceCIDR},
+		attributeChange{"dns_service_ip", plan.DNSServiceIP, state.DNSServiceIP},
+	)
+	if len(replacing) == 0 {
+		return nil
+	}
+
+	return clusterReplacementWarning(ctx, r.client, state, replacing)
+}
+
+// planKubeConfigs marks the kubeconfigs as unknown if the API server endpoint or the identity provider changes.
+func planKubeConfigs(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, plan *ClusterResourceModel, state *ClusterResourceModel) {
+	var plannedOIDC, priorOIDC types.Object
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("oidc"), &plannedOIDC)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("oidc"), &priorOIDC)...)
+
+	endpointChanged := !plan.APIServerPrivateEndpoint.Equal(state.APIServerPrivateEndpoint) || !plan.APIServerDNSName.Equal(state.APIServerDNSName)
+
+	if endpointChanged {
+		// the kubeconfigs point to the new API server endpoint
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("kubeconfig"), types.ObjectUnknown(plan.KubeConfig.AttributeTypes(ctx)))...)
+		if plan.StoreKubeConfig.ValueBool() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("kubeconfig_raw"), types.StringUnknown())...)
+		}
+	}
+
+	if endpointChanged || !plannedOIDC.Equal(priorOIDC) {
+		// the user kubeconfig also logs in with the configured identity provider
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("kubeconfig_user"), types.ObjectUnknown(plan.KubeConfigUser.AttributeTypes(ctx)))...)
+		if plan.StoreKubeConfig.ValueBool() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("kubeconfig_user_raw"), types.StringUnknown())...)
+		}
+	}
+}
+
+// planCredentialRotation warns about and marks the admin kubeconfig as unknown if the client certificate expires within
+// renew_before, Update then rotates the credentials.
+func planCredentialRotation(ctx context.Context, resp *resource.ModifyPlanResponse, plan *ClusterResourceModel, state *ClusterResourceModel) {
+	if !credentialsDueForRenewal(plan.RenewBefore, state.ClientCertificateExpiresAt) {
+		return
+	}
+
+	resp.Diagnostics.AddWarning("Cluster credentials will be rotated",
+		fmt.Sprintf("The admin client certificate of cluster %s expires at %s, which is within renew_before (%s). "+
+			"A new certificate will be issued on apply.", state.Name.ValueString(), state.ClientCertificateExpiresAt.ValueString(), plan.RenewBefore.ValueString()))
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("client_certificate_expires_at"), timetypes.NewRFC3339Unknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("kubeconfig"), types.ObjectUnknown(plan.KubeConfig.AttributeTypes(ctx)))...)
+	if plan.StoreKubeConfig.ValueBool() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("kubeconfig_raw"), types.StringUnknown())...)
+	}
+}
+
+// validateAddonTransitions rejects addon changes meltcloud cannot apply to a running cluster: kube-proxy cannot be
+// disabled, the iptables/IPVS rules it programmed would remain on the nodes and break Service routing.
+func validateAddonTransitions(plan *ClusterResourceModel, state *ClusterResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !plan.AddonKubeProxy.IsUnknown() && !plan.AddonKubeProxy.IsNull() && state.AddonKubeProxy.ValueBool() && !plan.AddonKubeProxy.ValueBool() {
+		diags.AddAttributeError(path.Root("addon_kube_proxy"), "Unsupported Addon Change",
+			fmt.Sprintf("The kube-proxy addon cannot be disabled on the running cluster %s. Recreate the cluster to run it without kube-proxy, "+
+				"e.g. with a CNI that replaces kube-proxy.", state.Name.ValueString()))
+	}
+
+	return diags
+}
+
+// verifyAddons returns an error if the cluster does not run the planned addons after an update. The addons are only
+// verified if they were sent, nil addons were left unchanged.
+func verifyAddons(plan *ClusterResourceModel, addons *[]client.ClusterAddon, cluster *client.Cluster) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	installed := map[string]client.ClusterAddon{}
+	if addons != nil {
+		for _, addon := range cluster.Addons {
+			installed[addon.Name] = addon
+		}
+	}
+
+	for _, addon := range addonList(addons) {
+		actual, ok := installed[addon.Name]
+		if !ok {
+			diags.AddAttributeError(path.Root("addon"), "Addon Not Applied",
+				fmt.Sprintf("The %s addon should be installed on cluster %s after the update, but is not. "+
+					"Check the operations of the cluster in meltcloud.", addon.Name, cluster.Name))
+			continue
+		}
+
+		if addon.Version != "" && addon.Version != actual.Version {
+			diags.AddAttributeError(path.Root("addon"), "Addon Not Applied",
+				fmt.Sprintf("The %s addon of cluster %s should be at version %s after the update, but is at %s. "+
+					"Check the operations of the cluster in meltcloud.", addon.Name, cluster.Name, addon.Version, actual.Version))
+		}
+
+		delete(installed, addon.Name)
+	}
+
+	for name := range installed {
+		diags.AddAttributeError(path.Root("addon"), "Addon Not Applied",
+			fmt.Sprintf("The %s addon should be removed from cluster %s after the update, but is still installed. "+
+				"Check the operations of the cluster in meltcloud.", name, cluster.Name))
+	}
+
+	builtinAddons := []struct {
+		attribute string
+		name      string
+		planned   types.Bool
+		actual    bool
+	}{
+		{"addon_kube_proxy", "kube-proxy", plan.AddonKubeProxy, cluster.AddonKubeProxy},
+		{"addon_core_dns", "CoreDNS", plan.AddonCoreDNS, cluster.AddonCoreDNS},
+	}
+
+	for _, addon := range builtinAddons {
+		if addon.planned.IsUnknown() || addon.planned.IsNull() || addon.planned.ValueBool() == addon.actual {
+			continue
+		}
+
+		diags.AddAttributeError(path.Root(addon.attribute), "Addon Not Applied",
+			fmt.Sprintf("The %s addon of cluster %s should be enabled=%t after the update, but is enabled=%t. "+
+				"Check the operations of the cluster in meltcloud.", addon.name, cluster.Name, addon.planned.ValueBool(), addon.actual))
+	}
+
+	return diags
 }
 
 func (r *ClusterResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var data ClusterResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	resp.Diagnostics.Append(checkDeletionProtection(ctx, req.State, "cluster", "it is being deleted")...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -403,22 +1521,26 @@ func (r *ClusterResource) Delete(ctx context.Context, req resource.DeleteRequest
 	}
 }
 
-var clusterImportIDPattern = regexp.MustCompile(`clusters/(\d+)`)
-
 func (r *ClusterResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	match := clusterImportIDPattern.FindStringSubmatch(req.ID)
-	if len(match) != 2 {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("ID does not follow format: %s", clusterImportIDPattern.String()))
+	if req.ID == "" {
+		var identity ClusterResourceIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), identity.ClusterID)...)
 		return
 	}
 
-	id, err := strconv.ParseInt(match[1], 10, 64)
+	id, err := clusterImportID(ctx, r.client, req.ID)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Invalid ID: %s", err))
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import: %s", err))
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, ClusterResourceIdentityModel{ClusterID: types.Int64Value(id)})...)
 
 	if resp.Diagnostics.HasError() {
 		return
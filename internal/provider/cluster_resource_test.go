@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+	"terraform-provider-meltcloud/internal/client"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestSameDocument(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want bool
+	}{
+		{name: "identical", a: "replicas: 2\n", b: "replicas: 2\n", want: true},
+		{name: "key order", a: "a: 1\nb: 2\n", b: "b: 2\na: 1\n", want: true},
+		{name: "JSON and YAML", a: `{"controller":{"replicas":2,"tags":["a","b"]}}`, b: "controller:\n  replicas: 2\n  tags:\n    - a\n    - b\n", want: true},
+		{name: "JSON whitespace", a: `{"replicas": 2}`, b: `{"replicas":2}`, want: true},
+		{name: "different value", a: "replicas: 2\n", b: "replicas: 3\n"},
+		{name: "number and string", a: "replicas: 2\n", b: "replicas: \"2\"\n"},
+		{name: "list order", a: "tags: [a, b]\n", b: "tags: [b, a]\n"},
+		{name: "invalid document", a: "replicas: [", b: "replicas: [\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sameDocument(tt.a, tt.b); got != tt.want {
+				t.Errorf("sameDocument(%q, %q) = %t, want %t", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClusterResourceStoreKubeConfig(t *testing.T) {
+	cluster := &client.Cluster{ID: 1, Name: "prod", KubeConfig: testKubeConfig, KubeConfigUser: testKubeConfig}
+
+	tests := []struct {
+		name  string
+		store bool
+	}{
+		{name: "stored", store: true},
+		{name: "not stored", store: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &ClusterResource{}
+			data := &ClusterResourceModel{StoreKubeConfig: types.BoolValue(tt.store)}
+
+			r.setValues(cluster, data)
+
+			kubeConfig, err := r.kubeConfigState(data, cluster.KubeConfig)
+			if err != nil {
+				t.Fatalf("kubeConfigState() error = %v", err)
+			}
+
+			kubeConfigUser, kubeConfigUserRaw, diags := r.kubeConfigUserState(context.Background(), data, cluster.KubeConfigUser)
+			if diags.HasError() {
+				t.Fatalf("kubeConfigUserState() diagnostics = %v", diags)
+			}
+
+			if tt.store {
+				if data.KubeConfigRaw.ValueString() != testKubeConfig || data.KubeConfigUserRaw.ValueString() != testKubeConfig {
+					t.Errorf("setValues() kubeconfig_raw = %s, kubeconfig_user_raw = %s, want the kubeconfigs", data.KubeConfigRaw, data.KubeConfigUserRaw)
+				}
+				if kubeConfig == nil || kubeConfig.Host.ValueString() != "https://test.k8s.meltcloud.io" {
+					t.Errorf("kubeConfigState() = %+v, want the admin kubeconfig values", kubeConfig)
+				}
+				if kubeConfigUser == nil || kubeConfigUserRaw.IsNull() {
+					t.Errorf("kubeConfigUserState() = %+v, %s, want the user kubeconfig values", kubeConfigUser, kubeConfigUserRaw)
+				}
+				return
+			}
+
+			if !data.KubeConfigRaw.IsNull() || !data.KubeConfigUserRaw.IsNull() {
+				t.Errorf("setValues() kubeconfig_raw = %s, kubeconfig_user_raw = %s, want null", data.KubeConfigRaw, data.KubeConfigUserRaw)
+			}
+			if kubeConfig != nil {
+				t.Errorf("kubeConfigState() = %+v, want nil", kubeConfig)
+			}
+			if kubeConfigUser != nil || !kubeConfigUserRaw.IsNull() {
+				t.Errorf("kubeConfigUserState() = %+v, %s, want nil and null", kubeConfigUser, kubeConfigUserRaw)
+			}
+		})
+	}
+}
+
+func TestClusterResourceIdentity(t *testing.T) {
+	api := newTestAPI(t)
+
+	config := testProviderConfig(api) + `
+resource "meltcloud_cluster" "test" {
+  name    = "melt01"
+  version = "1.31"
+}
+`
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity("meltcloud_cluster.test", map[string]knownvalue.Check{
+						"cluster_id": knownvalue.Int64Exact(1),
+					}),
+					statecheck.ExpectIdentityValueMatchesStateAtPath("meltcloud_cluster.test", tfjsonpath.New("cluster_id"), tfjsonpath.New("id")),
+				},
+			},
+			{
+				Config:          config,
+				ResourceName:    "meltcloud_cluster.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+			{
+				Config:          config,
+				ResourceName:    "meltcloud_cluster.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+				ImportStateId:   "clusters/1",
+			},
+		},
+	})
+}
@@ -0,0 +1,682 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"terraform-provider-meltcloud/internal/client"
+	"terraform-provider-meltcloud/internal/kubernetes"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ClusterUpgradeResource{}
+var _ resource.ResourceWithModifyPlan = &ClusterUpgradeResource{}
+
+const (
+	clusterUpgradePoolMachinePool     = "machine_pool"
+	clusterUpgradePoolElasticNodePool = "elastic_node_pool"
+)
+
+const (
+	clusterUpgradePoolStatusPending  = "pending"
+	clusterUpgradePoolStatusUpToDate = "up_to_date"
+	clusterUpgradePoolStatusUpgraded = "upgraded"
+	clusterUpgradePoolStatusFailed   = "failed"
+)
+
+func NewClusterUpgradeResource() resource.Resource {
+	return &ClusterUpgradeResource{}
+}
+
+// ClusterUpgradeResource defines the resource implementation.
+type ClusterUpgradeResource struct {
+	client *client.Client
+}
+
+// ClusterUpgradeResourceModel describes the resource data model.
+type ClusterUpgradeResourceModel struct {
+	ID                  types.String `tfsdk:"id"`
+	ClusterID           types.Int64  `tfsdk:"cluster_id"`
+	Version             types.String `tfsdk:"version"`
+	MachinePoolIDs      types.List   `tfsdk:"machine_pool_ids"`
+	ElasticNodePoolIDs  types.List   `tfsdk:"elastic_node_pool_ids"`
+	BatchSize           types.Int64  `tfsdk:"batch_size"`
+	SkipUpgradeChecks   types.Bool   `tfsdk:"skip_upgrade_checks"`
+	ControlPlaneVersion types.String `tfsdk:"control_plane_version"`
+	Pools               types.List   `tfsdk:"pools"`
+}
+
+type ClusterUpgradePoolModel struct {
+	Type         types.String `tfsdk:"type"`
+	ID           types.Int64  `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	Status       types.String `tfsdk:"status"`
+	PatchVersion types.String `tfsdk:"patch_version"`
+}
+
+var clusterUpgradePoolAttributeTypes = map[string]attr.Type{
+	"type":          types.StringType,
+	"id":            types.Int64Type,
+	"name":          types.StringType,
+	"status":        types.StringType,
+	"patch_version": types.StringType,
+}
+
+// clusterUpgradePool is a pool to upgrade, with its progress.
+type clusterUpgradePool struct {
+	Type         string
+	ID           int64
+	Name         string
+	Status       string
+	PatchVersion string
+}
+
+func (r *ClusterUpgradeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_upgrade"
+}
+
+func (r *ClusterUpgradeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Upgrades a cluster to a Kubernetes version in a single apply: first the control plane, then the machine pools and " +
+			"elastic node pools in the configured order and batch size. The upgrade stops at the first failed pool, `pools` shows the progress.\n\n" +
+			"Changing `version` starts a new upgrade. Destroying the resource does not change the cluster.\n\n" +
+			"~> Add `lifecycle { ignore_changes = [version] }` to the `meltcloud_cluster`, `meltcloud_machine_pool` and `meltcloud_elastic_node_pool` " +
+			"resources of the cluster, otherwise they will plan to change the version back.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the upgrade in the form `<cluster_id>#<version>`",
+				Computed:            true,
+			},
+			"cluster_id": schema.Int64Attribute{
+				MarkdownDescription: "ID of the cluster to upgrade",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "Kubernetes minor version to upgrade the cluster and its pools to, at most one minor version newer than the control plane",
+				Required:            true,
+			},
+			"machine_pool_ids": schema.ListAttribute{
+				MarkdownDescription: "IDs of the machine pools to upgrade, in this order. If not specified, all machine pools of the cluster are upgraded in the order they were created.",
+				ElementType:         types.Int64Type,
+				Optional:            true,
+			},
+			"elastic_node_pool_ids": schema.ListAttribute{
+				MarkdownDescription: "IDs of the elastic node pools to upgrade after the machine pools, in this order. " +
+					"If not specified, all elastic node pools of the cluster are upgraded in the order they were created.",
+				ElementType: types.Int64Type,
+				Optional:    true,
+			},
+			"batch_size": schema.Int64Attribute{
+				MarkdownDescription: "Number of pools upgraded at the same time",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(1),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"skip_upgrade_checks": skipUpgradeChecksAttribute(),
+			"control_plane_version": schema.StringAttribute{
+				MarkdownDescription: "Kubernetes patch version of the control plane after the upgrade",
+				Computed:            true,
+			},
+			"pools": schema.ListNestedAttribute{
+				MarkdownDescription: "Progress of the upgrade per pool, in upgrade order",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							MarkdownDescription: "`machine_pool` or `elastic_node_pool`",
+							Computed:            true,
+						},
+						"id": schema.Int64Attribute{
+							MarkdownDescription: "ID of the pool",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of the pool",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							MarkdownDescription: "`up_to_date` if the pool already ran the version, `upgraded`, `failed`, or `pending` if the upgrade stopped before the pool",
+							Computed:            true,
+						},
+						"patch_version": schema.StringAttribute{
+							MarkdownDescription: "Kubernetes patch version of the pool after the upgrade",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *ClusterUpgradeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ClusterUpgradeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ClusterUpgradeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// if the upgrade fails, the stored state is tainted by Terraform and the upgrade is repeated with the next apply
+	resp.Diagnostics.Append(r.upgrade(ctx, &data)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ClusterUpgradeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ClusterUpgradeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterID := data.ClusterID.ValueInt64()
+	result, err := r.client.Cluster().Get(ctx, clusterID)
+	if err != nil {
+		if err.HTTPStatusCode == 404 {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read cluster, got error: %s", err))
+		return
+	}
+
+	if !data.ControlPlaneVersion.IsNull() {
+		data.ControlPlaneVersion = types.StringValue(result.Cluster.PatchVersion)
+	}
+
+	// the upgrade is not repeated on drift, only the progress of the pools is refreshed
+	var poolModels []ClusterUpgradePoolModel
+	resp.Diagnostics.Append(data.Pools.ElementsAs(ctx, &poolModels, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	version := data.Version.ValueString()
+	pools := make([]clusterUpgradePool, 0, len(poolModels))
+	for _, model := range poolModels {
+		pool := clusterUpgradePool{
+			Type:         model.Type.ValueString(),
+			ID:           model.ID.ValueInt64(),
+			Name:         model.Name.ValueString(),
+			Status:       model.Status.ValueString(),
+			PatchVersion: model.PatchVersion.ValueString(),
+		}
+
+		poolVersion, patchVersion, err := r.poolVersion(ctx, clusterID, pool)
+		if err != nil {
+			if err.HTTPStatusCode == 404 {
+				continue
+			}
+
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read %s %s, got error: %s", pool.Type, pool.Name, err))
+			return
+		}
+
+		pool.PatchVersion = patchVersion
+		pool.Status = refreshedClusterUpgradePoolStatus(pool.Status, poolVersion, version)
+
+		pools = append(pools, pool)
+	}
+
+	if !data.Pools.IsNull() {
+		resp.Diagnostics.Append(setClusterUpgradePools(ctx, &data, pools)...)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ClusterUpgradeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state ClusterUpgradeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.upgrade(ctx, &data)...)
+
+	// store the prior version if the upgrade failed, so that the next plan shows the change again and the upgrade
+	// resumes with the pools that are not upgraded yet
+	if resp.Diagnostics.HasError() {
+		data.ID = state.ID
+		data.Version = state.Version
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ClusterUpgradeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// an upgrade cannot be undone, the resource is only removed from the state
+}
+
+// ModifyPlan validates the target version and rejects downgrades and upgrades skipping a minor version.
+func (r *ClusterUpgradeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state ClusterUpgradeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateKubernetesVersion(ctx, r.client, path.Root("version"), plan.Version, state.Version)...)
+
+	if !plan.SkipUpgradeChecks.ValueBool() {
+		resp.Diagnostics.Append(validateVersionChange(path.Root("version"), plan.Version, state.Version)...)
+	}
+}
+
+// upgrade upgrades the control plane and then the pools of the cluster to the version in data. The progress is set in
+// data also if the upgrade fails, so that it can be stored in the state.
+func (r *ClusterUpgradeResource) upgrade(ctx context.Context, data *ClusterUpgradeResourceModel) (diags diag.Diagnostics) {
+	clusterID := data.ClusterID.ValueInt64()
+	version := data.Version.ValueString()
+
+	data.ID = types.StringValue(fmt.Sprintf("%d#%s", clusterID, version))
+	data.ControlPlaneVersion = types.StringNull()
+	data.Pools = types.ListNull(types.ObjectType{AttrTypes: clusterUpgradePoolAttributeTypes})
+
+	pools, poolDiags := r.pools(ctx, data)
+	diags.Append(poolDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	// diags is a named result, so that the diagnostics of the deferred call are returned
+	defer func() {
+		diags.Append(setClusterUpgradePools(ctx, data, pools)...)
+	}()
+
+	controlPlaneVersion, err := r.upgradeControlPlane(ctx, clusterID, version, data.SkipUpgradeChecks.ValueBool())
+	if err != nil {
+		diags.AddError("Cluster Upgrade Failed", fmt.Sprintf("Unable to upgrade the control plane of cluster %d to %s: %s", clusterID, version, err))
+		return diags
+	}
+	data.ControlPlaneVersion = types.StringValue(controlPlaneVersion)
+
+	start := func(pool clusterUpgradePool) (int64, error) {
+		return r.startPoolUpgrade(ctx, clusterID, pool, version)
+	}
+	finish := func(pool clusterUpgradePool, operationID int64) (string, error) {
+		return r.finishPoolUpgrade(ctx, clusterID, pool, operationID)
+	}
+
+	diags.Append(upgradeClusterPools(pools, int(data.BatchSize.ValueInt64()), version, start, finish)...)
+
+	return diags
+}
+
+// upgradeClusterPools upgrades the pools in batches of batchSize, skipping the pools that are up to date. All pools of
+// a batch are started before waiting for them. The upgrade stops after the first batch with a failed pool, the pools of
+// the following batches stay pending. The status and patch version of the pools are updated in place.
+func upgradeClusterPools(
+	pools []clusterUpgradePool,
+	batchSize int,
+	version string,
+	start func(pool clusterUpgradePool) (int64, error),
+	finish func(pool clusterUpgradePool, operationID int64) (string, error),
+) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for first := 0; first < len(pools); first += batchSize {
+		batch := pools[first:min(first+batchSize, len(pools))]
+
+		operations := map[int]int64{}
+		for i, pool := range batch {
+			if pool.Status == clusterUpgradePoolStatusUpToDate {
+				continue
+			}
+
+			operationID, err := start(pool)
+			if err != nil {
+				batch[i].Status = clusterUpgradePoolStatusFailed
+				diags.AddError("Cluster Upgrade Failed", fmt.Sprintf("Unable to upgrade %s %s to %s, got error: %s", pool.Type, pool.Name, version, err))
+				continue
+			}
+			operations[i] = operationID
+		}
+
+		// wait for all pools of the batch, also if one of them failed
+		for i, pool := range batch {
+			operationID, ok := operations[i]
+			if !ok {
+				continue
+			}
+
+			patchVersion, err := finish(pool, operationID)
+			if err != nil {
+				batch[i].Status = clusterUpgradePoolStatusFailed
+				diags.AddError("Cluster Upgrade Failed", fmt.Sprintf("Unable to upgrade %s %s to %s, got error: %s", pool.Type, pool.Name, version, err))
+				continue
+			}
+
+			batch[i].Status = clusterUpgradePoolStatusUpgraded
+			batch[i].PatchVersion = patchVersion
+		}
+
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// refreshedClusterUpgradePoolStatus returns the status of a pool that now runs poolVersion: pools changed outside of the
+// upgrade become up to date or pending again, failed and pending pools that run the version are up to date.
+func refreshedClusterUpgradePoolStatus(status string, poolVersion string, version string) string {
+	switch {
+	case poolVersion == version && status != clusterUpgradePoolStatusUpgraded:
+		return clusterUpgradePoolStatusUpToDate
+	case poolVersion != version && (status == clusterUpgradePoolStatusUpgraded || status == clusterUpgradePoolStatusUpToDate):
+		return clusterUpgradePoolStatusPending
+	}
+
+	return status
+}
+
+// upgradeControlPlane upgrades the control plane to version if it does not run it yet and returns its patch version.
+func (r *ClusterUpgradeResource) upgradeControlPlane(ctx context.Context, clusterID int64, version string, skipUpgradeChecks bool) (string, error) {
+	result, err := r.client.Cluster().Get(ctx, clusterID)
+	if err != nil {
+		return "", err
+	}
+
+	if result.Cluster.UserVersion == version {
+		return result.Cluster.PatchVersion, nil
+	}
+
+	if !skipUpgradeChecks {
+		from, parseErr := kubernetes.ParseVersion(result.Cluster.UserVersion)
+		if parseErr != nil {
+			return "", parseErr
+		}
+
+		to, parseErr := kubernetes.ParseVersion(version)
+		if parseErr != nil {
+			return "", parseErr
+		}
+
+		if upgradeErr := kubernetes.CheckUpgrade(from, to); upgradeErr != nil {
+			return "", fmt.Errorf("%s. %s", upgradeErr, skipUpgradeChecksHint)
+		}
+	}
+
+	updateResult, err := r.client.Cluster().Update(ctx, clusterID, &client.ClusterUpdateInput{
+		UserVersion: version,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if updateResult.Operation != nil {
+		_, err = r.client.Operation().PollUntilDone(ctx, updateResult.Operation.ID)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	result, err = r.client.Cluster().Get(ctx, clusterID)
+	if err != nil {
+		return "", err
+	}
+
+	return result.Cluster.PatchVersion, nil
+}
+
+// pools returns the pools to upgrade in upgrade order: the machine pools, then the elastic node pools.
+func (r *ClusterUpgradeResource) pools(ctx context.Context, data *ClusterUpgradeResourceModel) ([]clusterUpgradePool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	clusterID := data.ClusterID.ValueInt64()
+	version := data.Version.ValueString()
+
+	var machinePoolIDs, elasticNodePoolIDs []int64
+	diags.Append(data.MachinePoolIDs.ElementsAs(ctx, &machinePoolIDs, false)...)
+	diags.Append(data.ElasticNodePoolIDs.ElementsAs(ctx, &elasticNodePoolIDs, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	machinePools, err := r.client.MachinePool().List(ctx, clusterID)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read machine pools of cluster %d, got error: %s", clusterID, err))
+		return nil, diags
+	}
+
+	elasticNodePools, err := r.client.ElasticNodePool().List(ctx, clusterID)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read elastic node pools of cluster %d, got error: %s", clusterID, err))
+		return nil, diags
+	}
+
+	var candidates []clusterUpgradePool
+	for _, pool := range machinePools.MachinePools {
+		candidates = append(candidates, newClusterUpgradePool(clusterUpgradePoolMachinePool, pool.ID, pool.Name, pool.UserVersion, pool.PatchVersion, version))
+	}
+	for _, pool := range elasticNodePools.ElasticNodePools {
+		candidates = append(candidates, newClusterUpgradePool(clusterUpgradePoolElasticNodePool, pool.ID, pool.Name, pool.Version, pool.PatchVersion, version))
+	}
+
+	pools, selectDiags := selectClusterUpgradePools(clusterID, candidates, machinePoolIDs, data.MachinePoolIDs.IsNull(), elasticNodePoolIDs, data.ElasticNodePoolIDs.IsNull())
+	diags.Append(selectDiags...)
+
+	return pools, diags
+}
+
+// selectClusterUpgradePools returns the candidates to upgrade in upgrade order: the machine pools, then the elastic node
+// pools, each in the order of machinePoolIDs and elasticNodePoolIDs, or all pools of the type ordered by ID if
+// allMachinePools or allElasticNodePools is set.
+func selectClusterUpgradePools(clusterID int64, candidates []clusterUpgradePool, machinePoolIDs []int64, allMachinePools bool, elasticNodePoolIDs []int64, allElasticNodePools bool) ([]clusterUpgradePool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var pools []clusterUpgradePool
+	for _, selection := range []struct {
+		poolType  string
+		attribute string
+		ids       []int64
+		all       bool
+	}{
+		{clusterUpgradePoolMachinePool, "machine_pool_ids", machinePoolIDs, allMachinePools},
+		{clusterUpgradePoolElasticNodePool, "elastic_node_pool_ids", elasticNodePoolIDs, allElasticNodePools},
+	} {
+		byID := map[int64]clusterUpgradePool{}
+		var ofType []clusterUpgradePool
+		for _, pool := range candidates {
+			if pool.Type == selection.poolType {
+				byID[pool.ID] = pool
+				ofType = append(ofType, pool)
+			}
+		}
+
+		if selection.all {
+			sort.Slice(ofType, func(i, j int) bool { return ofType[i].ID < ofType[j].ID })
+			pools = append(pools, ofType...)
+			continue
+		}
+
+		for _, id := range selection.ids {
+			pool, ok := byID[id]
+			if !ok {
+				diags.AddAttributeError(path.Root(selection.attribute), "Pool Not Found",
+					fmt.Sprintf("Cluster %d has no %s with ID %d.", clusterID, selection.poolType, id))
+				continue
+			}
+			pools = append(pools, pool)
+		}
+	}
+
+	return pools, diags
+}
+
+func newClusterUpgradePool(poolType string, id int64, name string, version string, patchVersion string, targetVersion string) clusterUpgradePool {
+	status := clusterUpgradePoolStatusPending
+	if version == targetVersion {
+		status = clusterUpgradePoolStatusUpToDate
+	}
+
+	return clusterUpgradePool{
+		Type:         poolType,
+		ID:           id,
+		Name:         name,
+		Status:       status,
+		PatchVersion: patchVersion,
+	}
+}
+
+// startPoolUpgrade updates the version of the pool and returns the ID of the resulting operation, 0 if there is none.
+func (r *ClusterUpgradeResource) startPoolUpgrade(ctx context.Context, clusterID int64, pool clusterUpgradePool, version string) (int64, error) {
+	var operation *client.Operation
+
+	switch pool.Type {
+	case clusterUpgradePoolMachinePool:
+		current, err := r.client.MachinePool().Get(ctx, clusterID, pool.ID)
+		if err != nil {
+			return 0, err
+		}
+
+		result, err := r.client.MachinePool().Update(ctx, clusterID, pool.ID, &client.MachinePoolUpdateInput{
+			Name:             current.MachinePool.Name,
+			UserVersion:      version,
+			NetworkProfileID: current.MachinePool.NetworkProfileID,
+
+			MaintenanceWindow: current.MachinePool.MaintenanceWindow,
+			AutoUpgrade:       current.MachinePool.AutoUpgrade,
+
+			DeletionProtection: &current.MachinePool.DeletionProtection,
+		})
+		if err != nil {
+			return 0, err
+		}
+		operation = result.Operation
+	case clusterUpgradePoolElasticNodePool:
+		current, err := r.client.ElasticNodePool().Get(ctx, clusterID, pool.ID)
+		if err != nil {
+			return 0, err
+		}
+
+		result, err := r.client.ElasticNodePool().Update(ctx, clusterID, pool.ID, &client.ElasticNodePoolUpdateInput{
+			NodeCount:     current.ElasticNodePool.NodeCount,
+			NodeVCPUs:     current.ElasticNodePool.NodeVCPUs,
+			NodeMemoryMiB: current.ElasticNodePool.NodeMemoryMiB,
+			NodeDiskGiB:   current.ElasticNodePool.NodeDiskGiB,
+			Version:       version,
+
+			DeletionProtection: &current.ElasticNodePool.DeletionProtection,
+		})
+		if err != nil {
+			return 0, err
+		}
+		operation = result.Operation
+	}
+
+	if operation == nil {
+		return 0, nil
+	}
+
+	return operation.ID, nil
+}
+
+// finishPoolUpgrade waits for the operation of startPoolUpgrade, if any, and returns the new patch version of the pool.
+func (r *ClusterUpgradeResource) finishPoolUpgrade(ctx context.Context, clusterID int64, pool clusterUpgradePool, operationID int64) (string, error) {
+	if operationID != 0 {
+		if _, err := r.client.Operation().PollUntilDone(ctx, operationID); err != nil {
+			return "", err
+		}
+	}
+
+	_, patchVersion, err := r.poolVersion(ctx, clusterID, pool)
+	if err != nil {
+		return "", fmt.Errorf("unable to read the %s: %s", pool.Type, err)
+	}
+
+	return patchVersion, nil
+}
+
+// poolVersion returns the Kubernetes minor and patch version of the pool.
+func (r *ClusterUpgradeResource) poolVersion(ctx context.Context, clusterID int64, pool clusterUpgradePool) (string, string, *client.Error) {
+	if pool.Type == clusterUpgradePoolElasticNodePool {
+		result, err := r.client.ElasticNodePool().Get(ctx, clusterID, pool.ID)
+		if err != nil {
+			return "", "", err
+		}
+		return result.ElasticNodePool.Version, result.ElasticNodePool.PatchVersion, nil
+	}
+
+	result, err := r.client.MachinePool().Get(ctx, clusterID, pool.ID)
+	if err != nil {
+		return "", "", err
+	}
+	return result.MachinePool.UserVersion, result.MachinePool.PatchVersion, nil
+}
+
+func setClusterUpgradePools(ctx context.Context, data *ClusterUpgradeResourceModel, pools []clusterUpgradePool) diag.Diagnostics {
+	models := make([]ClusterUpgradePoolModel, 0, len(pools))
+	for _, pool := range pools {
+		patchVersion := types.StringNull()
+		if pool.PatchVersion != "" {
+			patchVersion = types.StringValue(pool.PatchVersion)
+		}
+
+		models = append(models, ClusterUpgradePoolModel{
+			Type:         types.StringValue(pool.Type),
+			ID:           types.Int64Value(pool.ID),
+			Name:         types.StringValue(pool.Name),
+			Status:       types.StringValue(pool.Status),
+			PatchVersion: patchVersion,
+		})
+	}
+
+	var diags diag.Diagnostics
+	data.Pools, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: clusterUpgradePoolAttributeTypes}, models)
+
+	return diags
+}
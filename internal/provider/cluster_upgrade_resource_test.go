@@ -0,0 +1,206 @@
+package provider
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestUpgradeClusterPools(t *testing.T) {
+	pool := func(id int64, status string) clusterUpgradePool {
+		return clusterUpgradePool{Type: clusterUpgradePoolMachinePool, ID: id, Name: "pool", Status: status}
+	}
+
+	tests := []struct {
+		name       string
+		pools      []clusterUpgradePool
+		batchSize  int
+		failStart  int64
+		failFinish int64
+
+		wantStarted  []int64
+		wantStatuses []string
+		wantErrors   int
+	}{
+		{
+			name:         "all pools",
+			pools:        []clusterUpgradePool{pool(1, "pending"), pool(2, "pending"), pool(3, "pending")},
+			batchSize:    2,
+			wantStarted:  []int64{1, 2, 3},
+			wantStatuses: []string{"upgraded", "upgraded", "upgraded"},
+		},
+		{
+			name:         "up to date pools are skipped",
+			pools:        []clusterUpgradePool{pool(1, "up_to_date"), pool(2, "pending"), pool(3, "up_to_date")},
+			batchSize:    1,
+			wantStarted:  []int64{2},
+			wantStatuses: []string{"up_to_date", "upgraded", "up_to_date"},
+		},
+		{
+			name:         "failed start in batch 1 leaves batch 2 pending",
+			pools:        []clusterUpgradePool{pool(1, "pending"), pool(2, "pending"), pool(3, "pending")},
+			batchSize:    2,
+			failStart:    1,
+			wantStarted:  []int64{1, 2},
+			wantStatuses: []string{"failed", "upgraded", "pending"},
+			wantErrors:   1,
+		},
+		{
+			name:         "failed operation in batch 1 leaves batch 2 pending",
+			pools:        []clusterUpgradePool{pool(1, "pending"), pool(2, "pending"), pool(3, "pending")},
+			batchSize:    1,
+			failFinish:   1,
+			wantStarted:  []int64{1},
+			wantStatuses: []string{"failed", "pending", "pending"},
+			wantErrors:   1,
+		},
+		{
+			name:         "failure in the last batch",
+			pools:        []clusterUpgradePool{pool(1, "pending"), pool(2, "pending")},
+			batchSize:    1,
+			failFinish:   2,
+			wantStarted:  []int64{1, 2},
+			wantStatuses: []string{"upgraded", "failed"},
+			wantErrors:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var started []int64
+			start := func(pool clusterUpgradePool) (int64, error) {
+				started = append(started, pool.ID)
+				if pool.ID == tt.failStart {
+					return 0, errors.New("start failed")
+				}
+				return pool.ID * 10, nil
+			}
+			finish := func(pool clusterUpgradePool, operationID int64) (string, error) {
+				if operationID != pool.ID*10 {
+					t.Errorf("finish(%d) operationID = %d, want %d", pool.ID, operationID, pool.ID*10)
+				}
+				if pool.ID == tt.failFinish {
+					return "", errors.New("operation failed")
+				}
+				return "1.31.4", nil
+			}
+
+			diags := upgradeClusterPools(tt.pools, tt.batchSize, "1.31", start, finish)
+
+			if diags.ErrorsCount() != tt.wantErrors {
+				t.Errorf("upgradeClusterPools() diagnostics = %v, want %d errors", diags, tt.wantErrors)
+			}
+			if !reflect.DeepEqual(started, tt.wantStarted) {
+				t.Errorf("upgradeClusterPools() started %v, want %v", started, tt.wantStarted)
+			}
+
+			var statuses []string
+			for _, pool := range tt.pools {
+				statuses = append(statuses, pool.Status)
+				if pool.Status == clusterUpgradePoolStatusUpgraded && pool.PatchVersion != "1.31.4" {
+					t.Errorf("upgradeClusterPools() pool %d patch version = %q, want %q", pool.ID, pool.PatchVersion, "1.31.4")
+				}
+			}
+			if !reflect.DeepEqual(statuses, tt.wantStatuses) {
+				t.Errorf("upgradeClusterPools() statuses = %v, want %v", statuses, tt.wantStatuses)
+			}
+		})
+	}
+}
+
+func TestSelectClusterUpgradePools(t *testing.T) {
+	candidates := []clusterUpgradePool{
+		newClusterUpgradePool(clusterUpgradePoolElasticNodePool, 8, "elastic-b", "1.30", "1.30.2", "1.31"),
+		newClusterUpgradePool(clusterUpgradePoolMachinePool, 3, "machine-b", "1.31", "1.31.4", "1.31"),
+		newClusterUpgradePool(clusterUpgradePoolElasticNodePool, 7, "elastic-a", "1.30", "1.30.2", "1.31"),
+		newClusterUpgradePool(clusterUpgradePoolMachinePool, 2, "machine-a", "1.30", "1.30.2", "1.31"),
+	}
+
+	tests := []struct {
+		name                string
+		machinePoolIDs      []int64
+		allMachinePools     bool
+		elasticNodePoolIDs  []int64
+		allElasticNodePools bool
+
+		wantIDs    []int64
+		wantErrors int
+	}{
+		{
+			name:                "all pools by ID, machine pools first",
+			allMachinePools:     true,
+			allElasticNodePools: true,
+			wantIDs:             []int64{2, 3, 7, 8},
+		},
+		{
+			name:               "explicit order",
+			machinePoolIDs:     []int64{3, 2},
+			elasticNodePoolIDs: []int64{8, 7},
+			wantIDs:            []int64{3, 2, 8, 7},
+		},
+		{
+			name:                "explicit machine pools and all elastic node pools",
+			machinePoolIDs:      []int64{3},
+			allElasticNodePools: true,
+			wantIDs:             []int64{3, 7, 8},
+		},
+		{
+			name:           "no pools of a type",
+			machinePoolIDs: []int64{2},
+			wantIDs:        []int64{2},
+		},
+		{
+			name:               "elastic node pool ID as machine pool ID",
+			machinePoolIDs:     []int64{7, 2},
+			elasticNodePoolIDs: []int64{},
+			wantIDs:            []int64{2},
+			wantErrors:         1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pools, diags := selectClusterUpgradePools(1, candidates, tt.machinePoolIDs, tt.allMachinePools, tt.elasticNodePoolIDs, tt.allElasticNodePools)
+
+			if diags.ErrorsCount() != tt.wantErrors {
+				t.Errorf("selectClusterUpgradePools() diagnostics = %v, want %d errors", diags, tt.wantErrors)
+			}
+
+			var ids []int64
+			for _, pool := range pools {
+				ids = append(ids, pool.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("selectClusterUpgradePools() IDs = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+
+	if candidates[1].Status != clusterUpgradePoolStatusUpToDate || candidates[3].Status != clusterUpgradePoolStatusPending {
+		t.Errorf("newClusterUpgradePool() statuses = %q, %q, want %q, %q",
+			candidates[1].Status, candidates[3].Status, clusterUpgradePoolStatusUpToDate, clusterUpgradePoolStatusPending)
+	}
+}
+
+func TestRefreshedClusterUpgradePoolStatus(t *testing.T) {
+	tests := []struct {
+		status      string
+		poolVersion string
+		want        string
+	}{
+		{status: "upgraded", poolVersion: "1.31", want: "upgraded"},
+		{status: "up_to_date", poolVersion: "1.31", want: "up_to_date"},
+		{status: "pending", poolVersion: "1.31", want: "up_to_date"},
+		{status: "failed", poolVersion: "1.31", want: "up_to_date"},
+		{status: "upgraded", poolVersion: "1.30", want: "pending"},
+		{status: "up_to_date", poolVersion: "1.30", want: "pending"},
+		{status: "pending", poolVersion: "1.30", want: "pending"},
+		{status: "failed", poolVersion: "1.30", want: "failed"},
+	}
+
+	for _, tt := range tests {
+		if got := refreshedClusterUpgradePoolStatus(tt.status, tt.poolVersion, "1.31"); got != tt.want {
+			t.Errorf("refreshedClusterUpgradePoolStatus(%q, %q) = %q, want %q", tt.status, tt.poolVersion, got, tt.want)
+		}
+	}
+}
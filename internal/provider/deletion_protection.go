@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const deletionProtectionHint = "Set deletion_protection = false and apply first to allow it."
+
+func deletionProtectionAttribute(subject string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		MarkdownDescription: fmt.Sprintf("Prevents the %s from being deleted, both by meltcloud and by Terraform: plans that destroy or replace it fail. "+
+			"Must be set to `false` and applied before the %s can be deleted. Defaults to `false`.", subject, subject),
+		Optional: true,
+		Computed: true,
+		Default:  booldefault.StaticBool(false),
+	}
+}
+
+// requiresReplaceUnlessProtectedString is stringplanmodifier.RequiresReplace, but fails the plan instead if
+// deletion_protection is enabled.
+func requiresReplaceUnlessProtectedString(subject string) planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			resp.Diagnostics.Append(checkDeletionProtection(ctx, req.State, subject, fmt.Sprintf("changing %s replaces it", req.Path))...)
+			resp.RequiresReplace = true
+		},
+		"If the value of this attribute changes, Terraform will destroy and recreate the resource, unless deletion_protection is enabled.",
+		"If the value of this attribute changes, Terraform will destroy and recreate the resource, unless `deletion_protection` is enabled.",
+	)
+}
+
+// requiresReplaceUnlessProtectedInt64 is int64planmodifier.RequiresReplace, but fails the plan instead if
+// deletion_protection is enabled.
+func requiresReplaceUnlessProtectedInt64(subject string) planmodifier.Int64 {
+	return int64planmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.Int64Request, resp *int64planmodifier.RequiresReplaceIfFuncResponse) {
+			resp.Diagnostics.Append(checkDeletionProtection(ctx, req.State, subject, fmt.Sprintf("changing %s replaces it", req.Path))...)
+			resp.RequiresReplace = true
+		},
+		"If the value of this attribute changes, Terraform will destroy and recreate the resource, unless deletion_protection is enabled.",
+		"If the value of this attribute changes, Terraform will destroy and recreate the resource, unless `deletion_protection` is enabled.",
+	)
+}
+
+// checkDeletionProtection returns an error if deletion_protection is enabled in the state. reason describes why the
+// resource would be deleted, e.g. "it is destroyed".
+func checkDeletionProtection(ctx context.Context, state tfsdk.State, subject string, reason string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if state.Raw.IsNull() {
+		return diags
+	}
+
+	var deletionProtection types.Bool
+	var name types.String
+	diags.Append(state.GetAttribute(ctx, path.Root("deletion_protection"), &deletionProtection)...)
+	diags.Append(state.GetAttribute(ctx, path.Root("name"), &name)...)
+
+	if diags.HasError() || !deletionProtection.ValueBool() {
+		return diags
+	}
+
+	diags.AddError("Deletion Protection Enabled",
+		fmt.Sprintf("The %s %s has deletion_protection enabled, but %s. %s", subject, name.ValueString(), reason, deletionProtectionHint))
+
+	return diags
+}
@@ -0,0 +1,186 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// elasticFleetTestState returns the state of an elastic fleet, a null state if data is nil.
+func elasticFleetTestState(t *testing.T, data *ElasticFleetResourceModel) tfsdk.State {
+	t.Helper()
+
+	ctx := context.Background()
+
+	schemaResp := &resource.SchemaResponse{}
+	(&ElasticFleetResource{}).Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	if data != nil {
+		if diags := state.Set(ctx, data); diags.HasError() {
+			t.Fatalf("Set() diagnostics = %v", diags)
+		}
+	}
+
+	return state
+}
+
+func newElasticFleetTestModel(deletionProtection bool) *ElasticFleetResourceModel {
+	return &ElasticFleetResourceModel{
+		ID:                 types.Int64Value(3),
+		Name:               types.StringValue("gpu"),
+		ClusterID:          types.Int64Value(1),
+		Status:             types.StringValue("Ready"),
+		DeletionProtection: types.BoolValue(deletionProtection),
+	}
+}
+
+func TestCheckDeletionProtection(t *testing.T) {
+	tests := []struct {
+		name      string
+		state     *ElasticFleetResourceModel
+		wantError bool
+	}{
+		{name: "no state", state: nil},
+		{name: "unprotected", state: newElasticFleetTestModel(false)},
+		{name: "protected", state: newElasticFleetTestModel(true), wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := checkDeletionProtection(context.Background(), elasticFleetTestState(t, tt.state), "elastic fleet", "the plan destroys it")
+
+			if !tt.wantError {
+				if diags.HasError() {
+					t.Errorf("checkDeletionProtection() diagnostics = %v, want none", diags)
+				}
+				return
+			}
+
+			want := "The elastic fleet gpu has deletion_protection enabled, but the plan destroys it. " + deletionProtectionHint
+			if diags.ErrorsCount() != 1 || diags.Errors()[0].Detail() != want {
+				t.Errorf("checkDeletionProtection() diagnostics = %v, want error %q", diags, want)
+			}
+		})
+	}
+}
+
+func TestDeletionProtectionDestroyPlan(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		state     *ElasticFleetResourceModel
+		wantError bool
+	}{
+		{name: "unprotected", state: newElasticFleetTestModel(false)},
+		{name: "protected", state: newElasticFleetTestModel(true), wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := elasticFleetTestState(t, tt.state)
+			req := resource.ModifyPlanRequest{
+				State: state,
+				Plan:  tfsdk.Plan{Schema: state.Schema, Raw: tftypes.NewValue(state.Raw.Type(), nil)},
+			}
+			resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+
+			(&ElasticFleetResource{}).ModifyPlan(ctx, req, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantError {
+				t.Errorf("ModifyPlan() diagnostics = %v, want error %t", resp.Diagnostics, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestRequiresReplaceUnlessProtected(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name            string
+		state           *ElasticFleetResourceModel
+		planName        string
+		planClusterID   int64
+		wantReplace     bool
+		wantErrorSuffix string
+	}{
+		{name: "unchanged", state: newElasticFleetTestModel(true), planName: "gpu", planClusterID: 1},
+		{name: "create", planName: "gpu", planClusterID: 1},
+		{name: "unprotected replace", state: newElasticFleetTestModel(false), planName: "cpu", planClusterID: 2, wantReplace: true},
+		{name: "protected replace", state: newElasticFleetTestModel(true), planName: "cpu", planClusterID: 2, wantReplace: true, wantErrorSuffix: "replaces it. " + deletionProtectionHint},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := elasticFleetTestState(t, tt.state)
+
+			planModel := newElasticFleetTestModel(false)
+			planModel.Name = types.StringValue(tt.planName)
+			planModel.ClusterID = types.Int64Value(tt.planClusterID)
+			plan := elasticFleetTestState(t, planModel)
+
+			var stateName types.String
+			var stateClusterID types.Int64
+			if tt.state != nil {
+				stateName, stateClusterID = tt.state.Name, tt.state.ClusterID
+			} else {
+				stateName, stateClusterID = types.StringNull(), types.Int64Null()
+			}
+
+			stringResp := &planmodifier.StringResponse{PlanValue: planModel.Name}
+			requiresReplaceUnlessProtectedString("elastic fleet").PlanModifyString(ctx, planmodifier.StringRequest{
+				Path:        path.Root("name"),
+				State:       state,
+				Plan:        tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw},
+				StateValue:  stateName,
+				PlanValue:   planModel.Name,
+				ConfigValue: planModel.Name,
+			}, stringResp)
+
+			int64Resp := &planmodifier.Int64Response{PlanValue: planModel.ClusterID}
+			requiresReplaceUnlessProtectedInt64("elastic fleet").PlanModifyInt64(ctx, planmodifier.Int64Request{
+				Path:        path.Root("cluster_id"),
+				State:       state,
+				Plan:        tfsdk.Plan{Schema: plan.Schema, Raw: plan.Raw},
+				StateValue:  stateClusterID,
+				PlanValue:   planModel.ClusterID,
+				ConfigValue: planModel.ClusterID,
+			}, int64Resp)
+
+			for _, got := range []struct {
+				attribute       string
+				requiresReplace bool
+				diags           diag.Diagnostics
+			}{
+				{attribute: "name", requiresReplace: stringResp.RequiresReplace, diags: stringResp.Diagnostics},
+				{attribute: "cluster_id", requiresReplace: int64Resp.RequiresReplace, diags: int64Resp.Diagnostics},
+			} {
+				if got.requiresReplace != tt.wantReplace {
+					t.Errorf("%s RequiresReplace = %t, want %t", got.attribute, got.requiresReplace, tt.wantReplace)
+				}
+
+				if tt.wantErrorSuffix == "" {
+					if got.diags.HasError() {
+						t.Errorf("%s diagnostics = %v, want none", got.attribute, got.diags)
+					}
+					continue
+				}
+
+				want := "changing " + got.attribute + " " + tt.wantErrorSuffix
+				if got.diags.ErrorsCount() != 1 || !strings.HasSuffix(got.diags.Errors()[0].Detail(), want) {
+					t.Errorf("%s diagnostics = %v, want an error ending in %q", got.attribute, got.diags, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-meltcloud/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ list.ListResource = &ElasticFleetListResource{}
+var _ list.ListResourceWithConfigure = &ElasticFleetListResource{}
+
+func NewElasticFleetListResource() list.ListResource {
+	return &ElasticFleetListResource{}
+}
+
+// ElasticFleetListResource defines the list resource implementation.
+type ElasticFleetListResource struct {
+	client *client.Client
+}
+
+// ElasticFleetListResourceModel describes the list resource filter model.
+type ElasticFleetListResourceModel struct {
+	ClusterID types.Int64 `tfsdk:"cluster_id"`
+}
+
+func (r *ElasticFleetListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_elastic_fleet"
+}
+
+func (r *ElasticFleetListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: elasticFleetDesc,
+
+		Attributes: map[string]schema.Attribute{
+			"cluster_id": schema.Int64Attribute{
+				MarkdownDescription: "Only list elastic fleets of this Cluster",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *ElasticFleetListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ElasticFleetListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var data ElasticFleetListResourceModel
+	diags := req.Config.Get(ctx, &data)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	result, err := r.client.ElasticFleet().List(ctx)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to list elastic fleets, got error: %s", err))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for _, elasticFleet := range result.ElasticFleets {
+			if !data.ClusterID.IsNull() && data.ClusterID.ValueInt64() != elasticFleet.ClusterID {
+				continue
+			}
+
+			if !push(r.listResult(ctx, req, elasticFleet)) {
+				return
+			}
+		}
+	}
+}
+
+func (r *ElasticFleetListResource) listResult(ctx context.Context, req list.ListRequest, elasticFleet *client.ElasticFleet) list.ListResult {
+	result := req.NewListResult(ctx)
+	result.DisplayName = elasticFleet.Name
+
+	result.Diagnostics.Append(result.Identity.Set(ctx, ElasticFleetResourceIdentityModel{ElasticFleetID: types.Int64Value(elasticFleet.ID)})...)
+
+	if req.IncludeResource {
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("id"), elasticFleet.ID)...)
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("name"), elasticFleet.Name)...)
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("cluster_id"), elasticFleet.ClusterID)...)
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("status"), elasticFleet.Status)...)
+	}
+
+	return result
+}
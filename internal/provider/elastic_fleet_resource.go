@@ -3,12 +3,11 @@ package provider
 import (
 	"context"
 	"fmt"
-	"regexp"
-	"strconv"
 	"terraform-provider-meltcloud/internal/client"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -18,6 +17,8 @@ import (
 
 var _ resource.Resource = &ElasticFleetResource{}
 var _ resource.ResourceWithImportState = &ElasticFleetResource{}
+var _ resource.ResourceWithIdentity = &ElasticFleetResource{}
+var _ resource.ResourceWithModifyPlan = &ElasticFleetResource{}
 
 func NewElasticFleetResource() resource.Resource {
 	return &ElasticFleetResource{}
@@ -32,6 +33,13 @@ type ElasticFleetResourceModel struct {
 	Name      types.String `tfsdk:"name"`
 	ClusterID types.Int64  `tfsdk:"cluster_id"`
 	Status    types.String `tfsdk:"status"`
+
+	DeletionProtection types.Bool `tfsdk:"deletion_protection"`
+}
+
+// ElasticFleetResourceIdentityModel describes the resource identity data model.
+type ElasticFleetResourceIdentityModel struct {
+	ElasticFleetID types.Int64 `tfsdk:"elastic_fleet_id"`
 }
 
 func (r *ElasticFleetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -53,16 +61,17 @@ func elasticFleetResourceAttributes() map[string]schema.Attribute {
 			MarkdownDescription: "Name of the Elastic Fleet",
 			Required:            true,
 			PlanModifiers: []planmodifier.String{
-				stringplanmodifier.RequiresReplace(),
+				requiresReplaceUnlessProtectedString("elastic fleet"),
 			},
 		},
 		"cluster_id": schema.Int64Attribute{
 			MarkdownDescription: "ID of the associated cluster",
 			Required:            true,
 			PlanModifiers: []planmodifier.Int64{
-				int64planmodifier.RequiresReplace(),
+				requiresReplaceUnlessProtectedInt64("elastic fleet"),
 			},
 		},
+		"deletion_protection": deletionProtectionAttribute("elastic fleet"),
 		"status": schema.StringAttribute{
 			MarkdownDescription: "Status of the Elastic Fleet",
 			Computed:            true,
@@ -80,6 +89,17 @@ func (r *ElasticFleetResource) Schema(ctx context.Context, req resource.SchemaRe
 	}
 }
 
+func (r *ElasticFleetResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"elastic_fleet_id": identityschema.Int64Attribute{
+				Description:       "Internal ID of the Elastic Fleet in meltcloud",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
 func (r *ElasticFleetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -107,6 +127,8 @@ func (r *ElasticFleetResource) Create(ctx context.Context, req resource.CreateRe
 	input := &client.ElasticFleetCreateInput{
 		Name:      data.Name.ValueString(),
 		ClusterID: data.ClusterID.ValueInt64(),
+
+		DeletionProtection: data.DeletionProtection.ValueBoolPointer(),
 	}
 
 	result, err := r.client.ElasticFleet().Create(ctx, input)
@@ -135,6 +157,7 @@ func (r *ElasticFleetResource) Create(ctx context.Context, req resource.CreateRe
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, ElasticFleetResourceIdentityModel{ElasticFleetID: data.ID})...)
 }
 
 func (r *ElasticFleetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -157,17 +180,48 @@ func (r *ElasticFleetResource) Read(ctx context.Context, req resource.ReadReques
 	data.Name = types.StringValue(result.ElasticFleet.Name)
 	data.ClusterID = types.Int64Value(result.ElasticFleet.ClusterID)
 	data.Status = types.StringValue(result.ElasticFleet.Status)
+	data.DeletionProtection = types.BoolValue(result.ElasticFleet.DeletionProtection)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, ElasticFleetResourceIdentityModel{ElasticFleetID: data.ID})...)
 }
 
 func (r *ElasticFleetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// All attributes are RequiresReplace; no in-place update.
+	// All attributes except deletion_protection are RequiresReplace.
+	var data ElasticFleetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input := &client.ElasticFleetUpdateInput{
+		DeletionProtection: data.DeletionProtection.ValueBoolPointer(),
+	}
+
+	result, err := r.client.ElasticFleet().Update(ctx, data.ID.ValueInt64(), input)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update elastic fleet, got error: %s", err))
+		return
+	}
+
+	data.Status = types.StringValue(result.ElasticFleet.Status)
+	data.DeletionProtection = types.BoolValue(result.ElasticFleet.DeletionProtection)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, ElasticFleetResourceIdentityModel{ElasticFleetID: data.ID})...)
+}
+
+// ModifyPlan refuses to destroy the elastic fleet if deletion_protection is enabled.
+func (r *ElasticFleetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		resp.Diagnostics.Append(checkDeletionProtection(ctx, req.State, "elastic fleet", "the plan destroys it")...)
+	}
 }
 
 func (r *ElasticFleetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var data ElasticFleetResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	resp.Diagnostics.Append(checkDeletionProtection(ctx, req.State, "elastic fleet", "it is being deleted")...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -187,20 +241,24 @@ func (r *ElasticFleetResource) Delete(ctx context.Context, req resource.DeleteRe
 	}
 }
 
-var elasticFleetImportIDPattern = regexp.MustCompile(`elastic_fleets/(\d+)`)
-
 func (r *ElasticFleetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	match := elasticFleetImportIDPattern.FindStringSubmatch(req.ID)
-	if len(match) != 2 {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("ID does not follow format: %s", elasticFleetImportIDPattern.String()))
+	if req.ID == "" {
+		var identity ElasticFleetResourceIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), identity.ElasticFleetID)...)
 		return
 	}
 
-	id, err := strconv.ParseInt(match[1], 10, 64)
+	id, err := elasticFleetImportID(ctx, r.client, req.ID)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Invalid ID: %s", err))
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import: %s", err))
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, ElasticFleetResourceIdentityModel{ElasticFleetID: types.Int64Value(id)})...)
 }
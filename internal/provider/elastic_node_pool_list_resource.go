@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-meltcloud/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ list.ListResource = &ElasticNodePoolListResource{}
+var _ list.ListResourceWithConfigure = &ElasticNodePoolListResource{}
+
+func NewElasticNodePoolListResource() list.ListResource {
+	return &ElasticNodePoolListResource{}
+}
+
+// ElasticNodePoolListResource defines the list resource implementation.
+type ElasticNodePoolListResource struct {
+	client *client.Client
+}
+
+// ElasticNodePoolListResourceModel describes the list resource filter model.
+type ElasticNodePoolListResourceModel struct {
+	ClusterID      types.Int64 `tfsdk:"cluster_id"`
+	ElasticQuotaID types.Int64 `tfsdk:"elastic_quota_id"`
+}
+
+func (r *ElasticNodePoolListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_elastic_node_pool"
+}
+
+func (r *ElasticNodePoolListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: elasticNodePoolDesc,
+
+		Attributes: map[string]schema.Attribute{
+			"cluster_id": schema.Int64Attribute{
+				MarkdownDescription: "Only list elastic node pools of this Cluster, lists the elastic node pools of all clusters if not set",
+				Optional:            true,
+			},
+			"elastic_quota_id": schema.Int64Attribute{
+				MarkdownDescription: "Only list elastic node pools provisioned from this Elastic Quota",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *ElasticNodePoolListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ElasticNodePoolListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var data ElasticNodePoolListResourceModel
+	diags := req.Config.Get(ctx, &data)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var clusterIDs []int64
+	if !data.ClusterID.IsNull() {
+		clusterIDs = append(clusterIDs, data.ClusterID.ValueInt64())
+	} else {
+		result, err := r.client.Cluster().List(ctx)
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to list clusters, got error: %s", err))
+			stream.Results = list.ListResultsStreamDiagnostics(diags)
+			return
+		}
+
+		for _, cluster := range result.Clusters {
+			clusterIDs = append(clusterIDs, cluster.ID)
+		}
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for _, clusterID := range clusterIDs {
+			result, err := r.client.ElasticNodePool().List(ctx, clusterID)
+			if err != nil {
+				errResult := list.ListResult{}
+				errResult.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list elastic node pools of cluster %d, got error: %s", clusterID, err))
+				push(errResult)
+				return
+			}
+
+			for _, elasticNodePool := range result.ElasticNodePools {
+				if !data.ElasticQuotaID.IsNull() && data.ElasticQuotaID.ValueInt64() != elasticNodePool.ElasticQuotaID {
+					continue
+				}
+
+				if !push(r.listResult(ctx, req, clusterID, elasticNodePool)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (r *ElasticNodePoolListResource) listResult(ctx context.Context, req list.ListRequest, clusterID int64, elasticNodePool *client.ElasticNodePool) list.ListResult {
+	result := req.NewListResult(ctx)
+	result.DisplayName = elasticNodePool.Name
+
+	result.Diagnostics.Append(result.Identity.Set(ctx, ElasticNodePoolResourceIdentityModel{ClusterID: types.Int64Value(clusterID), ElasticNodePoolID: types.Int64Value(elasticNodePool.ID)})...)
+
+	if req.IncludeResource {
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("id"), elasticNodePool.ID)...)
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("cluster_id"), clusterID)...)
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("name"), elasticNodePool.Name)...)
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("elastic_quota_id"), elasticNodePool.ElasticQuotaID)...)
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("version"), elasticNodePool.Version)...)
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("patch_version"), elasticNodePool.PatchVersion)...)
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("node_count"), elasticNodePool.NodeCount)...)
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("status"), elasticNodePool.Status)...)
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("node_config"), &NodeConfigModel{
+			VCPUs:     types.Int64Value(elasticNodePool.NodeVCPUs),
+			MemoryMiB: types.Int64Value(elasticNodePool.NodeMemoryMiB),
+			DiskGiB:   types.Int64Value(elasticNodePool.NodeDiskGiB),
+		})...)
+	}
+
+	return result
+}
@@ -3,12 +3,11 @@ package provider
 import (
 	"context"
 	"fmt"
-	"regexp"
-	"strconv"
 	"terraform-provider-meltcloud/internal/client"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -18,6 +17,8 @@ import (
 
 var _ resource.Resource = &ElasticNodePoolResource{}
 var _ resource.ResourceWithImportState = &ElasticNodePoolResource{}
+var _ resource.ResourceWithIdentity = &ElasticNodePoolResource{}
+var _ resource.ResourceWithModifyPlan = &ElasticNodePoolResource{}
 
 func NewElasticNodePoolResource() resource.Resource {
 	return &ElasticNodePoolResource{}
@@ -37,6 +38,16 @@ type ElasticNodePoolResourceModel struct {
 	NodeCount      types.Int64      `tfsdk:"node_count"`
 	Status         types.String     `tfsdk:"status"`
 	NodeConfig     *NodeConfigModel `tfsdk:"node_config"`
+
+	SkipUpgradeChecks types.Bool `tfsdk:"skip_upgrade_checks"`
+
+	DeletionProtection types.Bool `tfsdk:"deletion_protection"`
+}
+
+// ElasticNodePoolResourceIdentityModel describes the resource identity data model.
+type ElasticNodePoolResourceIdentityModel struct {
+	ClusterID         types.Int64 `tfsdk:"cluster_id"`
+	ElasticNodePoolID types.Int64 `tfsdk:"elastic_node_pool_id"`
 }
 
 type NodeConfigModel struct {
@@ -64,27 +75,29 @@ func elasticNodePoolResourceAttributes() map[string]schema.Attribute {
 			MarkdownDescription: "ID of the cluster the node pool runs on",
 			Required:            true,
 			PlanModifiers: []planmodifier.Int64{
-				int64planmodifier.RequiresReplace(),
+				requiresReplaceUnlessProtectedInt64("elastic node pool"),
 			},
 		},
 		"name": schema.StringAttribute{
 			MarkdownDescription: "Name of the Elastic Node Pool",
 			Required:            true,
 			PlanModifiers: []planmodifier.String{
-				stringplanmodifier.RequiresReplace(),
+				requiresReplaceUnlessProtectedString("elastic node pool"),
 			},
 		},
 		"elastic_quota_id": schema.Int64Attribute{
 			MarkdownDescription: "ID of the Elastic Quota backing the node pool",
 			Required:            true,
 			PlanModifiers: []planmodifier.Int64{
-				int64planmodifier.RequiresReplace(),
+				requiresReplaceUnlessProtectedInt64("elastic node pool"),
 			},
 		},
 		"version": schema.StringAttribute{
-			MarkdownDescription: "Kubernetes minor version of the Elastic Node Pool nodes (Kubelet)",
+			MarkdownDescription: "Kubernetes minor version of the Elastic Node Pool nodes (Kubelet). Must be one of the versions of the `meltcloud_kubernetes_versions` data source, plans warn when it nears its end of support.",
 			Required:            true,
 		},
+		"skip_upgrade_checks": skipUpgradeChecksAttribute(),
+		"deletion_protection": deletionProtectionAttribute("elastic node pool"),
 		"patch_version": schema.StringAttribute{
 			MarkdownDescription: "Kubernetes patch version of the Elastic Node Pool nodes (Kubelet)",
 			Computed:            true,
@@ -133,6 +146,21 @@ func (r *ElasticNodePoolResource) Schema(ctx context.Context, req resource.Schem
 	}
 }
 
+func (r *ElasticNodePoolResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"cluster_id": identityschema.Int64Attribute{
+				Description:       "Internal ID of the Cluster in meltcloud",
+				RequiredForImport: true,
+			},
+			"elastic_node_pool_id": identityschema.Int64Attribute{
+				Description:       "Internal ID of the Elastic Node Pool in meltcloud",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
 func (r *ElasticNodePoolResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -170,6 +198,8 @@ func (r *ElasticNodePoolResource) Create(ctx context.Context, req resource.Creat
 		NodeMemoryMiB:  data.NodeConfig.MemoryMiB.ValueInt64(),
 		NodeDiskGiB:    data.NodeConfig.DiskGiB.ValueInt64(),
 		Version:        data.Version.ValueString(),
+
+		DeletionProtection: data.DeletionProtection.ValueBoolPointer(),
 	}
 
 	result, err := r.client.ElasticNodePool().Create(ctx, data.ClusterID.ValueInt64(), input)
@@ -200,6 +230,7 @@ func (r *ElasticNodePoolResource) Create(ctx context.Context, req resource.Creat
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, ElasticNodePoolResourceIdentityModel{ClusterID: data.ClusterID, ElasticNodePoolID: data.ID})...)
 }
 
 func (r *ElasticNodePoolResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -225,6 +256,7 @@ func (r *ElasticNodePoolResource) Read(ctx context.Context, req resource.ReadReq
 	data.Version = types.StringValue(result.ElasticNodePool.Version)
 	data.PatchVersion = types.StringValue(result.ElasticNodePool.PatchVersion)
 	data.Status = types.StringValue(result.ElasticNodePool.Status)
+	data.DeletionProtection = types.BoolValue(result.ElasticNodePool.DeletionProtection)
 	data.NodeConfig = &NodeConfigModel{
 		VCPUs:     types.Int64Value(result.ElasticNodePool.NodeVCPUs),
 		MemoryMiB: types.Int64Value(result.ElasticNodePool.NodeMemoryMiB),
@@ -232,6 +264,7 @@ func (r *ElasticNodePoolResource) Read(ctx context.Context, req resource.ReadReq
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, ElasticNodePoolResourceIdentityModel{ClusterID: data.ClusterID, ElasticNodePoolID: data.ID})...)
 }
 
 func (r *ElasticNodePoolResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -252,6 +285,8 @@ func (r *ElasticNodePoolResource) Update(ctx context.Context, req resource.Updat
 		NodeMemoryMiB: data.NodeConfig.MemoryMiB.ValueInt64(),
 		NodeDiskGiB:   data.NodeConfig.DiskGiB.ValueInt64(),
 		Version:       data.Version.ValueString(),
+
+		DeletionProtection: data.DeletionProtection.ValueBoolPointer(),
 	}
 
 	result, err := r.client.ElasticNodePool().Update(ctx, data.ClusterID.ValueInt64(), data.ID.ValueInt64(), input)
@@ -280,11 +315,53 @@ func (r *ElasticNodePoolResource) Update(ctx context.Context, req resource.Updat
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, ElasticNodePoolResourceIdentityModel{ClusterID: data.ClusterID, ElasticNodePoolID: data.ID})...)
+}
+
+// ModifyPlan refuses to destroy the node pool if deletion_protection is enabled, warns about the nodes deleted by a
+// replacement, validates the Kubernetes version against the versions supported by meltcloud and rejects version
+// changes not supported by the control plane of the cluster, unless skip_upgrade_checks is set.
+func (r *ElasticNodePoolResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		resp.Diagnostics.Append(checkDeletionProtection(ctx, req.State, "elastic node pool", "the plan destroys it")...)
+		return
+	}
+
+	var plan, state ElasticNodePoolResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		replacing := replacingAttributes(
+			attributeChange{"cluster_id", plan.ClusterID, state.ClusterID},
+			attributeChange{"name", plan.Name, state.Name},
+			attributeChange{"elastic_quota_id", plan.ElasticQuotaID, state.ElasticQuotaID},
+		)
+		if len(replacing) > 0 {
+			resp.Diagnostics.Append(elasticNodePoolReplacementWarning(&state, replacing)...)
+		}
+	}
+
+	resp.Diagnostics.Append(validateKubernetesVersion(ctx, r.client, path.Root("version"), plan.Version, state.Version)...)
+
+	if plan.SkipUpgradeChecks.ValueBool() || plan.Version.Equal(state.Version) {
+		return
+	}
+
+	resp.Diagnostics.Append(validateVersionChange(path.Root("version"), plan.Version, state.Version)...)
+	resp.Diagnostics.Append(validateKubeletVersion(ctx, r.client, path.Root("version"), plan.ClusterID, plan.Version)...)
 }
 
 func (r *ElasticNodePoolResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var data ElasticNodePoolResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	resp.Diagnostics.Append(checkDeletionProtection(ctx, req.State, "elastic node pool", "it is being deleted")...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -304,27 +381,26 @@ func (r *ElasticNodePoolResource) Delete(ctx context.Context, req resource.Delet
 	}
 }
 
-var elasticNodePoolImportIDPattern = regexp.MustCompile(`clusters/(\d+)/elastic_node_pools/(\d+)`)
-
 func (r *ElasticNodePoolResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	match := elasticNodePoolImportIDPattern.FindStringSubmatch(req.ID)
-	if len(match) != 3 {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("ID does not follow format: %s", elasticNodePoolImportIDPattern.String()))
-		return
-	}
+	if req.ID == "" {
+		var identity ElasticNodePoolResourceIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 
-	clusterID, err := strconv.ParseInt(match[1], 10, 64)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Invalid cluster ID: %s", err))
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster_id"), identity.ClusterID)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), identity.ElasticNodePoolID)...)
 		return
 	}
 
-	id, err := strconv.ParseInt(match[2], 10, 64)
+	clusterID, id, err := elasticNodePoolImportID(ctx, r.client, req.ID)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Invalid ID: %s", err))
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import: %s", err))
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster_id"), clusterID)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, ElasticNodePoolResourceIdentityModel{ClusterID: types.Int64Value(clusterID), ElasticNodePoolID: types.Int64Value(id)})...)
 }
@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-meltcloud/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ list.ListResource = &ElasticQuotaListResource{}
+var _ list.ListResourceWithConfigure = &ElasticQuotaListResource{}
+
+func NewElasticQuotaListResource() list.ListResource {
+	return &ElasticQuotaListResource{}
+}
+
+// ElasticQuotaListResource defines the list resource implementation.
+type ElasticQuotaListResource struct {
+	client *client.Client
+}
+
+// ElasticQuotaListResourceModel describes the list resource filter model.
+type ElasticQuotaListResourceModel struct {
+	ElasticFleetID types.Int64 `tfsdk:"elastic_fleet_id"`
+}
+
+func (r *ElasticQuotaListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_elastic_quota"
+}
+
+func (r *ElasticQuotaListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: elasticQuotaDesc,
+
+		Attributes: map[string]schema.Attribute{
+			"elastic_fleet_id": schema.Int64Attribute{
+				MarkdownDescription: "Only list elastic quotas of this Elastic Fleet",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *ElasticQuotaListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ElasticQuotaListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var data ElasticQuotaListResourceModel
+	diags := req.Config.Get(ctx, &data)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	result, err := r.client.ElasticQuota().List(ctx)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to list elastic quotas, got error: %s", err))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for _, elasticQuota := range result.ElasticQuotas {
+			if !data.ElasticFleetID.IsNull() && data.ElasticFleetID.ValueInt64() != elasticQuota.ElasticFleetID {
+				continue
+			}
+
+			if !push(r.listResult(ctx, req, elasticQuota)) {
+				return
+			}
+		}
+	}
+}
+
+func (r *ElasticQuotaListResource) listResult(ctx context.Context, req list.ListRequest, elasticQuota *client.ElasticQuota) list.ListResult {
+	result := req.NewListResult(ctx)
+	result.DisplayName = elasticQuota.Name
+
+	result.Diagnostics.Append(result.Identity.Set(ctx, ElasticQuotaResourceIdentityModel{ElasticQuotaID: types.Int64Value(elasticQuota.ID)})...)
+
+	if req.IncludeResource {
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("id"), elasticQuota.ID)...)
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("name"), elasticQuota.Name)...)
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("vcpus"), elasticQuota.VCPUs)...)
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("disk_gib"), elasticQuota.DiskGiB)...)
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("memory_mib"), elasticQuota.MemoryMiB)...)
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("elastic_fleet_id"), elasticQuota.ElasticFleetID)...)
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("consuming_organization_uuid"), elasticQuota.ConsumingOrganizationUUID)...)
+	}
+
+	return result
+}
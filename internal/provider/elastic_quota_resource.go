@@ -3,12 +3,11 @@ package provider
 import (
 	"context"
 	"fmt"
-	"regexp"
-	"strconv"
 	"terraform-provider-meltcloud/internal/client"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -18,6 +17,7 @@ import (
 
 var _ resource.Resource = &ElasticQuotaResource{}
 var _ resource.ResourceWithImportState = &ElasticQuotaResource{}
+var _ resource.ResourceWithIdentity = &ElasticQuotaResource{}
 
 func NewElasticQuotaResource() resource.Resource {
 	return &ElasticQuotaResource{}
@@ -37,6 +37,11 @@ type ElasticQuotaResourceModel struct {
 	ConsumingOrganizationUUID types.String `tfsdk:"consuming_organization_uuid"`
 }
 
+// ElasticQuotaResourceIdentityModel describes the resource identity data model.
+type ElasticQuotaResourceIdentityModel struct {
+	ElasticQuotaID types.Int64 `tfsdk:"elastic_quota_id"`
+}
+
 func (r *ElasticQuotaResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_elastic_quota"
 }
@@ -92,6 +97,17 @@ func (r *ElasticQuotaResource) Schema(ctx context.Context, req resource.SchemaRe
 	}
 }
 
+func (r *ElasticQuotaResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"elastic_quota_id": identityschema.Int64Attribute{
+				Description:       "Internal ID of the Elastic Quota in meltcloud",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
 func (r *ElasticQuotaResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -133,6 +149,7 @@ func (r *ElasticQuotaResource) Create(ctx context.Context, req resource.CreateRe
 
 	data.ID = types.Int64Value(result.ElasticQuota.ID)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, ElasticQuotaResourceIdentityModel{ElasticQuotaID: data.ID})...)
 }
 
 func (r *ElasticQuotaResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -160,6 +177,7 @@ func (r *ElasticQuotaResource) Read(ctx context.Context, req resource.ReadReques
 	data.ConsumingOrganizationUUID = types.StringValue(result.ElasticQuota.ConsumingOrganizationUUID)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, ElasticQuotaResourceIdentityModel{ElasticQuotaID: data.ID})...)
 }
 
 func (r *ElasticQuotaResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -183,6 +201,7 @@ func (r *ElasticQuotaResource) Update(ctx context.Context, req resource.UpdateRe
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, ElasticQuotaResourceIdentityModel{ElasticQuotaID: data.ID})...)
 }
 
 func (r *ElasticQuotaResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -199,20 +218,24 @@ func (r *ElasticQuotaResource) Delete(ctx context.Context, req resource.DeleteRe
 	}
 }
 
-var elasticQuotaImportIDPattern = regexp.MustCompile(`elastic_quotas/(\d+)`)
-
 func (r *ElasticQuotaResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	match := elasticQuotaImportIDPattern.FindStringSubmatch(req.ID)
-	if len(match) != 2 {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("ID does not follow format: %s", elasticQuotaImportIDPattern.String()))
+	if req.ID == "" {
+		var identity ElasticQuotaResourceIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), identity.ElasticQuotaID)...)
 		return
 	}
 
-	id, err := strconv.ParseInt(match[1], 10, 64)
+	id, err := elasticQuotaImportID(ctx, r.client, req.ID)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Invalid ID: %s", err))
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import: %s", err))
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, ElasticQuotaResourceIdentityModel{ElasticQuotaID: types.Int64Value(id)})...)
 }
@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"terraform-provider-meltcloud/internal/client"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ list.ListResource = &EnrollmentImageListResource{}
+var _ list.ListResourceWithConfigure = &EnrollmentImageListResource{}
+
+func NewEnrollmentImageListResource() list.ListResource {
+	return &EnrollmentImageListResource{}
+}
+
+// EnrollmentImageListResource defines the list resource implementation.
+type EnrollmentImageListResource struct {
+	client *client.Client
+}
+
+// EnrollmentImageListResourceModel describes the list resource filter model.
+type EnrollmentImageListResourceModel struct {
+	ExpiresBefore timetypes.RFC3339 `tfsdk:"expires_before"`
+	Status        types.String      `tfsdk:"status"`
+}
+
+func (r *EnrollmentImageListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_enrollment_image"
+}
+
+func (r *EnrollmentImageListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: enrollmentImageDesc,
+
+		Attributes: map[string]schema.Attribute{
+			"expires_before": schema.StringAttribute{
+				MarkdownDescription: "Only list enrollment images expiring before this timestamp (RFC3339), e.g. `2025-07-01T00:00:00Z`",
+				CustomType:          timetypes.RFC3339Type{},
+				Optional:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Only list enrollment images with this status",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *EnrollmentImageListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *EnrollmentImageListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var data EnrollmentImageListResourceModel
+	diags := req.Config.Get(ctx, &data)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var expiresBefore time.Time
+	if !data.ExpiresBefore.IsNull() {
+		var tDiags diag.Diagnostics
+		expiresBefore, tDiags = data.ExpiresBefore.ValueRFC3339Time()
+		diags.Append(tDiags...)
+		if diags.HasError() {
+			stream.Results = list.ListResultsStreamDiagnostics(diags)
+			return
+		}
+	}
+
+	result, err := r.client.EnrollmentImage().List(ctx)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to list enrollment images, got error: %s", err))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for _, image := range result.EnrollmentImages {
+			if !r.matches(&data, expiresBefore, image) {
+				continue
+			}
+
+			if !push(r.listResult(ctx, req, image)) {
+				return
+			}
+		}
+	}
+}
+
+// matches returns whether the image passes all filters of data, expiresBefore is the parsed expires_before filter.
+func (r *EnrollmentImageListResource) matches(data *EnrollmentImageListResourceModel, expiresBefore time.Time, image *client.EnrollmentImage) bool {
+	if !data.ExpiresBefore.IsNull() && !image.ExpiresAt.Before(expiresBefore) {
+		return false
+	}
+	if !data.Status.IsNull() && !strings.EqualFold(data.Status.ValueString(), image.Status) {
+		return false
+	}
+
+	return true
+}
+
+func (r *EnrollmentImageListResource) listResult(ctx context.Context, req list.ListRequest, image *client.EnrollmentImage) list.ListResult {
+	result := req.NewListResult(ctx)
+	result.DisplayName = image.Name
+
+	result.Diagnostics.Append(result.Identity.Set(ctx, EnrollmentImageResourceIdentityModel{EnrollmentImageID: types.Int64Value(image.ID)})...)
+
+	if req.IncludeResource {
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("id"), image.ID)...)
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("name"), image.Name)...)
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("expires_at"), timetypes.NewRFC3339TimeValue(image.ExpiresAt.UTC()))...)
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("install_disk_device"), image.InstallDiskDevice)...)
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("install_disk_force_overwrite"), image.InstallDiskForceOverwrite)...)
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("vlan"), image.VLAN)...)
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("enable_http"), image.EnableHTTP)...)
+	}
+
+	return result
+}
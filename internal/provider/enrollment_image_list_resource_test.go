@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"terraform-provider-meltcloud/internal/client"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestEnrollmentImageListResourceMatches(t *testing.T) {
+	expiresAt := time.Date(2026, 11, 1, 12, 0, 0, 0, time.UTC)
+	image := &client.EnrollmentImage{ID: 1, Status: "Ready", ExpiresAt: expiresAt}
+
+	tests := []struct {
+		name          string
+		expiresBefore time.Time
+		status        types.String
+		want          bool
+	}{
+		{name: "no filter", status: types.StringNull(), want: true},
+		{name: "expires before", expiresBefore: expiresAt.Add(time.Second), status: types.StringNull(), want: true},
+		{name: "expires at the same time", expiresBefore: expiresAt, status: types.StringNull()},
+		{name: "expires after", expiresBefore: expiresAt.Add(-time.Hour), status: types.StringNull()},
+		{name: "status", status: types.StringValue("Ready"), want: true},
+		{name: "status in other case", status: types.StringValue("ready"), want: true},
+		{name: "other status", status: types.StringValue("Building")},
+		{name: "expires before and other status", expiresBefore: expiresAt.Add(time.Hour), status: types.StringValue("Building")},
+	}
+
+	r := &EnrollmentImageListResource{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := &EnrollmentImageListResourceModel{ExpiresBefore: timetypes.NewRFC3339Null(), Status: tt.status}
+			if !tt.expiresBefore.IsZero() {
+				data.ExpiresBefore = timetypes.NewRFC3339TimeValue(tt.expiresBefore)
+			}
+
+			if got := r.matches(data, tt.expiresBefore, image); got != tt.want {
+				t.Errorf("matches() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
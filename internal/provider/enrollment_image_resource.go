@@ -6,13 +6,12 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
-	"regexp"
-	"strconv"
 	"terraform-provider-meltcloud/internal/client"
 
 	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -21,6 +20,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &EnrollmentImageResource{}
 var _ resource.ResourceWithImportState = &EnrollmentImageResource{}
+var _ resource.ResourceWithIdentity = &EnrollmentImageResource{}
 
 func NewEnrollmentImageResource() resource.Resource {
 	return &EnrollmentImageResource{}
@@ -42,11 +42,16 @@ type EnrollmentImageResourceModel struct {
 	EnableHTTP                types.Bool        `tfsdk:"enable_http"`
 	HTTPURLISOAMD64           types.String      `tfsdk:"http_url_iso_amd64"`
 	HTTPURLISOARM64           types.String      `tfsdk:"http_url_iso_arm64"`
-	HTTPSURLISOAMD64          types.String      `tfsdk:"https_url_iso_arm64"`
-	HTTPSURLISOARM64          types.String      `tfsdk:"https_url_iso_amd64"`
+	HTTPSURLISOAMD64          types.String      `tfsdk:"https_url_iso_amd64"`
+	HTTPSURLISOARM64          types.String      `tfsdk:"https_url_iso_arm64"`
 	LastUsedAt                timetypes.RFC3339 `tfsdk:"last_used_at"`
 }
 
+// EnrollmentImageResourceIdentityModel describes the resource identity data model.
+type EnrollmentImageResourceIdentityModel struct {
+	EnrollmentImageID types.Int64 `tfsdk:"enrollment_image_id"`
+}
+
 func (r *EnrollmentImageResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_enrollment_image"
 }
@@ -144,6 +149,17 @@ func (r *EnrollmentImageResource) Schema(ctx context.Context, req resource.Schem
 	}
 }
 
+func (r *EnrollmentImageResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"enrollment_image_id": identityschema.Int64Attribute{
+				Description:       "Internal ID of the Enrollment Image in meltcloud",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
 func (r *EnrollmentImageResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -227,6 +243,7 @@ func (r *EnrollmentImageResource) Create(ctx context.Context, req resource.Creat
 	data.ID = types.Int64Value(result.EnrollmentImage.ID)
 	r.setValues(result.EnrollmentImage, &data)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, EnrollmentImageResourceIdentityModel{EnrollmentImageID: data.ID})...)
 }
 
 func (r *EnrollmentImageResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -254,6 +271,7 @@ func (r *EnrollmentImageResource) Read(ctx context.Context, req resource.ReadReq
 	r.setValues(result.EnrollmentImage, &data)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, EnrollmentImageResourceIdentityModel{EnrollmentImageID: data.ID})...)
 }
 
 func (r *EnrollmentImageResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -286,22 +304,26 @@ func (r *EnrollmentImageResource) Delete(ctx context.Context, req resource.Delet
 	}
 }
 
-var enrollmentImageImportIDPattern = regexp.MustCompile(`enrollment_images/(\d+)`)
-
 func (r *EnrollmentImageResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	match := enrollmentImageImportIDPattern.FindStringSubmatch(req.ID)
-	if len(match) != 2 {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("ID does not follow format: %s", enrollmentImageImportIDPattern.String()))
+	if req.ID == "" {
+		var identity EnrollmentImageResourceIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), identity.EnrollmentImageID)...)
 		return
 	}
 
-	id, err := strconv.ParseInt(match[1], 10, 64)
+	id, err := enrollmentImageImportID(ctx, r.client, req.ID)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Invalid ID: %s", err))
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import: %s", err))
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, EnrollmentImageResourceIdentityModel{EnrollmentImageID: types.Int64Value(id)})...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -0,0 +1,393 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"terraform-provider-meltcloud/internal/client"
+
+	"github.com/google/uuid"
+)
+
+// Import IDs can be given as
+//   - the resource path as displayed in the console URL, e.g. clusters/1/machine_pools/2
+//   - the full console URL, e.g. https://app.meltcloud.io/ui/orgs/<org>/clusters/1/machine_pools/2
+//   - by name: name=<name> for top-level objects, <cluster-name>/<name> for objects within a cluster
+//   - the UUID for machines
+//
+// Paths may have the prefix of the API path, e.g. orgs/<org>/clusters/1 or api/v1/orgs/<org>/clusters/1, like before
+// names and console URLs were supported. The organization of such a prefix must match the provider. Paths with other
+// prefixes or extra segments are rejected.
+
+var consoleURLPathPattern = regexp.MustCompile(`^/ui/orgs/([^/]+)/(.+)$`)
+
+var importOrganizationPattern = regexp.MustCompile(`(?:^|/)orgs/([^/]+)/`)
+
+// importPath strips the console URL prefix from an import ID and validates that the URL or the prefix of a path
+// belongs to the organization the provider is configured for. URLs other than console URLs are rejected.
+func importPath(c *client.Client, importID string) (string, error) {
+	importID = strings.TrimSpace(importID)
+
+	p := strings.Trim(importID, "/")
+	if strings.HasPrefix(importID, "http://") || strings.HasPrefix(importID, "https://") {
+		u, err := url.Parse(importID)
+		if err != nil {
+			return "", fmt.Errorf("invalid console URL: %s", err)
+		}
+
+		match := consoleURLPathPattern.FindStringSubmatch(strings.TrimSuffix(u.Path, "/"))
+		if len(match) != 3 {
+			return "", fmt.Errorf("%s is not a console URL, expected a URL of the form https://<host>/ui/orgs/<organization>/<path>", importID)
+		}
+
+		if !strings.EqualFold(match[1], c.Organization) {
+			return "", fmt.Errorf("console URL belongs to organization %s, but the provider is configured for organization %s", match[1], c.Organization)
+		}
+
+		p = match[2]
+	}
+
+	for _, match := range importOrganizationPattern.FindAllStringSubmatch(p, -1) {
+		if !strings.EqualFold(match[1], c.Organization) {
+			return "", fmt.Errorf("ID belongs to organization %s, but the provider is configured for organization %s", match[1], c.Organization)
+		}
+	}
+
+	return p, nil
+}
+
+// importName returns the name of a name=<name> import ID.
+func importName(importPath string) (string, bool) {
+	name, found := strings.CutPrefix(importPath, "name=")
+	if !found || name == "" {
+		return "", false
+	}
+	return name, true
+}
+
+// importNames returns the names of a <cluster-name>/<name> import ID, with an optional name= prefix.
+func importNames(importPath string) (string, string, bool) {
+	importPath = strings.TrimPrefix(importPath, "name=")
+
+	clusterName, name, found := strings.Cut(importPath, "/")
+	if !found || clusterName == "" || name == "" || strings.Contains(name, "/") {
+		return "", "", false
+	}
+	return clusterName, name, true
+}
+
+// importIDPattern returns the anchored pattern of an import path, allowing the optional prefix of the API path.
+func importIDPattern(path string) *regexp.Regexp {
+	return regexp.MustCompile(`^(?:(?:api/v1/)?orgs/[^/]+/)?` + path + `$`)
+}
+
+func importIDFormatError(formats ...string) error {
+	return fmt.Errorf("ID does not follow format: %s or a console URL", strings.Join(formats, ", "))
+}
+
+func parseImportInt64(match string, what string) (int64, error) {
+	id, err := strconv.ParseInt(match, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %s", what, err)
+	}
+	return id, nil
+}
+
+var clusterImportIDPattern = importIDPattern(`clusters/(\d+)`)
+
+func clusterImportID(ctx context.Context, c *client.Client, importID string) (int64, error) {
+	p, err := importPath(c, importID)
+	if err != nil {
+		return 0, err
+	}
+
+	if match := clusterImportIDPattern.FindStringSubmatch(p); len(match) == 2 {
+		return parseImportInt64(match[1], "ID")
+	}
+
+	if name, ok := importName(p); ok {
+		cluster, err := clusterByName(ctx, c, name)
+		if err != nil {
+			return 0, err
+		}
+		return cluster.ID, nil
+	}
+
+	return 0, importIDFormatError("clusters/<id>", "name=<name>")
+}
+
+var machinePoolImportIDPattern = importIDPattern(`clusters/(\d+)/machine_pools/(\d+)`)
+
+func machinePoolImportID(ctx context.Context, c *client.Client, importID string) (int64, int64, error) {
+	p, err := importPath(c, importID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if match := machinePoolImportIDPattern.FindStringSubmatch(p); len(match) == 3 {
+		return parseImportInt64Pair(match[1], match[2])
+	}
+
+	if clusterName, name, ok := importNames(p); ok {
+		cluster, err := clusterByName(ctx, c, clusterName)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		result, cErr := c.MachinePool().List(ctx, cluster.ID)
+		if cErr != nil {
+			return 0, 0, fmt.Errorf("unable to read machine pools of cluster %s, got error: %s", cluster.Name, cErr)
+		}
+
+		names := map[int64]string{}
+		for _, pool := range result.MachinePools {
+			names[pool.ID] = pool.Name
+		}
+
+		id, err := idByName(names, name, fmt.Sprintf("machine pool by name %s in cluster %s", name, cluster.Name))
+		if err != nil {
+			return 0, 0, err
+		}
+
+		return cluster.ID, id, nil
+	}
+
+	return 0, 0, importIDFormatError("clusters/<cluster id>/machine_pools/<id>", "<cluster name>/<name>")
+}
+
+var elasticNodePoolImportIDPattern = importIDPattern(`clusters/(\d+)/elastic_node_pools/(\d+)`)
+
+func elasticNodePoolImportID(ctx context.Context, c *client.Client, importID string) (int64, int64, error) {
+	p, err := importPath(c, importID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if match := elasticNodePoolImportIDPattern.FindStringSubmatch(p); len(match) == 3 {
+		return parseImportInt64Pair(match[1], match[2])
+	}
+
+	if clusterName, name, ok := importNames(p); ok {
+		cluster, err := clusterByName(ctx, c, clusterName)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		result, cErr := c.ElasticNodePool().List(ctx, cluster.ID)
+		if cErr != nil {
+			return 0, 0, fmt.Errorf("unable to read elastic node pools of cluster %s, got error: %s", cluster.Name, cErr)
+		}
+
+		names := map[int64]string{}
+		for _, pool := range result.ElasticNodePools {
+			names[pool.ID] = pool.Name
+		}
+
+		id, err := idByName(names, name, fmt.Sprintf("elastic node pool by name %s in cluster %s", name, cluster.Name))
+		if err != nil {
+			return 0, 0, err
+		}
+
+		return cluster.ID, id, nil
+	}
+
+	return 0, 0, importIDFormatError("clusters/<cluster id>/elastic_node_pools/<id>", "<cluster name>/<name>")
+}
+
+var machineImportIDPattern = importIDPattern(`machines/(\d+)`)
+
+func machineImportID(ctx context.Context, c *client.Client, importID string) (int64, error) {
+	p, err := importPath(c, importID)
+	if err != nil {
+		return 0, err
+	}
+
+	if match := machineImportIDPattern.FindStringSubmatch(p); len(match) == 2 {
+		return parseImportInt64(match[1], "ID")
+	}
+
+	machineUUID, uuidErr := uuid.Parse(p)
+	if uuidErr == nil {
+		return machineIDByUUID(ctx, c, machineUUID)
+	}
+
+	name, ok := importName(p)
+	if !ok {
+		return 0, importIDFormatError("machines/<id>", "<uuid>", "name=<name>")
+	}
+
+	result, cErr := c.Machine().List(ctx)
+	if cErr != nil {
+		return 0, fmt.Errorf("unable to read machines, got error: %s", cErr)
+	}
+
+	names := map[int64]string{}
+	for _, m := range result.Machines {
+		names[m.ID] = m.Name
+	}
+
+	return idByName(names, name, fmt.Sprintf("machine by name %s", name))
+}
+
+// machineIDByUUID returns the ID of the machine with the UUID.
+func machineIDByUUID(ctx context.Context, c *client.Client, machineUUID uuid.UUID) (int64, error) {
+	result, cErr := c.Machine().List(ctx)
+	if cErr != nil {
+		return 0, fmt.Errorf("unable to read machines, got error: %s", cErr)
+	}
+
+	for _, m := range result.Machines {
+		if m.UUID == machineUUID {
+			return m.ID, nil
+		}
+	}
+
+	return 0, fmt.Errorf("could not find machine %s", machineUUID)
+}
+
+var enrollmentImageImportIDPattern = importIDPattern(`enrollment_images/(\d+)`)
+
+func enrollmentImageImportID(ctx context.Context, c *client.Client, importID string) (int64, error) {
+	return namedImportID(ctx, c, importID, enrollmentImageImportIDPattern, "enrollment_images/<id>", func(ctx context.Context) (map[int64]string, *client.Error) {
+		result, err := c.EnrollmentImage().List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		names := map[int64]string{}
+		for _, i := range result.EnrollmentImages {
+			names[i.ID] = i.Name
+		}
+		return names, nil
+	})
+}
+
+var networkProfileImportIDPattern = importIDPattern(`network_profiles/(\d+)`)
+
+func networkProfileImportID(ctx context.Context, c *client.Client, importID string) (int64, error) {
+	return namedImportID(ctx, c, importID, networkProfileImportIDPattern, "network_profiles/<id>", func(ctx context.Context) (map[int64]string, *client.Error) {
+		result, err := c.NetworkProfile().List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		names := map[int64]string{}
+		for _, p := range result.NetworkProfiles {
+			names[p.ID] = p.Name
+		}
+		return names, nil
+	})
+}
+
+var elasticFleetImportIDPattern = importIDPattern(`elastic_fleets/(\d+)`)
+
+func elasticFleetImportID(ctx context.Context, c *client.Client, importID string) (int64, error) {
+	return namedImportID(ctx, c, importID, elasticFleetImportIDPattern, "elastic_fleets/<id>", func(ctx context.Context) (map[int64]string, *client.Error) {
+		result, err := c.ElasticFleet().List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		names := map[int64]string{}
+		for _, f := range result.ElasticFleets {
+			names[f.ID] = f.Name
+		}
+		return names, nil
+	})
+}
+
+var elasticQuotaImportIDPattern = importIDPattern(`elastic_quotas/(\d+)`)
+
+func elasticQuotaImportID(ctx context.Context, c *client.Client, importID string) (int64, error) {
+	return namedImportID(ctx, c, importID, elasticQuotaImportIDPattern, "elastic_quotas/<id>", func(ctx context.Context) (map[int64]string, *client.Error) {
+		result, err := c.ElasticQuota().List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		names := map[int64]string{}
+		for _, q := range result.ElasticQuotas {
+			names[q.ID] = q.Name
+		}
+		return names, nil
+	})
+}
+
+// namedImportID resolves the import ID of a top-level object that can be imported by path or by name=<name>.
+// list returns the names of all objects by ID.
+func namedImportID(ctx context.Context, c *client.Client, importID string, pattern *regexp.Regexp, format string, list func(ctx context.Context) (map[int64]string, *client.Error)) (int64, error) {
+	p, err := importPath(c, importID)
+	if err != nil {
+		return 0, err
+	}
+
+	if match := pattern.FindStringSubmatch(p); len(match) == 2 {
+		return parseImportInt64(match[1], "ID")
+	}
+
+	name, ok := importName(p)
+	if !ok {
+		return 0, importIDFormatError(format, "name=<name>")
+	}
+
+	names, cErr := list(ctx)
+	if cErr != nil {
+		return 0, fmt.Errorf("unable to look up %s, got error: %s", name, cErr)
+	}
+
+	return idByName(names, name, fmt.Sprintf("object by name %s", name))
+}
+
+// idByName returns the ID of the only object with the name, compared case-insensitively. notFound describes the
+// object in the error if there is no such object, e.g. "cluster by name melt01".
+func idByName(names map[int64]string, name string, notFound string) (int64, error) {
+	var matches []int64
+	for id, n := range names {
+		if strings.EqualFold(name, n) {
+			matches = append(matches, id)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return 0, fmt.Errorf("could not find %s", notFound)
+	case 1:
+		return matches[0], nil
+	default:
+		return 0, fmt.Errorf("name %s is ambiguous, use the ID instead", name)
+	}
+}
+
+func clusterByName(ctx context.Context, c *client.Client, name string) (*client.Cluster, error) {
+	result, err := c.Cluster().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read clusters, got error: %s", err)
+	}
+
+	clusters := map[int64]*client.Cluster{}
+	names := map[int64]string{}
+	for _, cluster := range result.Clusters {
+		clusters[cluster.ID] = cluster
+		names[cluster.ID] = cluster.Name
+	}
+
+	id, nameErr := idByName(names, name, fmt.Sprintf("cluster by name %s", name))
+	if nameErr != nil {
+		return nil, nameErr
+	}
+
+	return clusters[id], nil
+}
+
+func parseImportInt64Pair(clusterMatch string, idMatch string) (int64, int64, error) {
+	clusterID, err := parseImportInt64(clusterMatch, "cluster ID")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	id, err := parseImportInt64(idMatch, "ID")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return clusterID, id, nil
+}
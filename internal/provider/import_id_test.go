@@ -0,0 +1,272 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"terraform-provider-meltcloud/internal/client"
+	"testing"
+)
+
+const testImportOrganization = "deadbeef-0000-0000-0000-000000000000"
+
+func TestImportPath(t *testing.T) {
+	c := &client.Client{Organization: testImportOrganization}
+
+	tests := []struct {
+		name      string
+		importID  string
+		want      string
+		wantError string
+	}{
+		{name: "path", importID: "clusters/1/machine_pools/2", want: "clusters/1/machine_pools/2"},
+		{name: "path with slashes and spaces", importID: " /clusters/1/ ", want: "clusters/1"},
+		{name: "name", importID: "name=melt01", want: "name=melt01"},
+		{name: "console URL", importID: "https://app.meltcloud.io/ui/orgs/" + testImportOrganization + "/clusters/1/machine_pools/2", want: "clusters/1/machine_pools/2"},
+		{name: "console URL with trailing slash", importID: "https://app.meltcloud.io/ui/orgs/" + testImportOrganization + "/clusters/1/", want: "clusters/1"},
+		{name: "console URL organization in upper case", importID: "https://app.meltcloud.io/ui/orgs/" + strings.ToUpper(testImportOrganization) + "/clusters/1", want: "clusters/1"},
+		{name: "console URL of another organization", importID: "https://app.meltcloud.io/ui/orgs/00000000-0000-0000-0000-000000000001/clusters/1", wantError: "belongs to organization"},
+		{name: "API URL", importID: "https://app.meltcloud.io/api/v1/orgs/" + testImportOrganization + "/clusters/1", wantError: "is not a console URL"},
+		{name: "API URL of another organization", importID: "https://app.meltcloud.io/api/v1/orgs/00000000-0000-0000-0000-000000000001/clusters/1", wantError: "is not a console URL"},
+		{name: "URL without path", importID: "https://app.meltcloud.io", wantError: "is not a console URL"},
+		{name: "prefixed path", importID: "orgs/" + testImportOrganization + "/clusters/1", want: "orgs/" + testImportOrganization + "/clusters/1"},
+		{name: "prefixed path of another organization", importID: "orgs/00000000-0000-0000-0000-000000000001/clusters/1", wantError: "belongs to organization"},
+		{name: "API path of another organization", importID: "/api/v1/orgs/00000000-0000-0000-0000-000000000001/clusters/1", wantError: "belongs to organization"},
+		{name: "console URL with a prefix of another organization", importID: "https://app.meltcloud.io/ui/orgs/" + testImportOrganization + "/orgs/00000000-0000-0000-0000-000000000001/clusters/1", wantError: "belongs to organization"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := importPath(c, tt.importID)
+			if tt.wantError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+					t.Fatalf("importPath(%q) = %q, %v, want error %q", tt.importID, got, err, tt.wantError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("importPath(%q) unexpected error: %s", tt.importID, err)
+			}
+			if got != tt.want {
+				t.Errorf("importPath(%q) = %q, want %q", tt.importID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImportName(t *testing.T) {
+	tests := []struct {
+		importPath string
+		want       string
+		wantOK     bool
+	}{
+		{importPath: "name=melt01", want: "melt01", wantOK: true},
+		{importPath: "name=melt 01", want: "melt 01", wantOK: true},
+		{importPath: "name=", wantOK: false},
+		{importPath: "melt01", wantOK: false},
+		{importPath: "clusters/1", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.importPath, func(t *testing.T) {
+			got, ok := importName(tt.importPath)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("importName(%q) = %q, %t, want %q, %t", tt.importPath, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestImportNames(t *testing.T) {
+	tests := []struct {
+		importPath      string
+		wantClusterName string
+		wantName        string
+		wantOK          bool
+	}{
+		{importPath: "melt01/pool01", wantClusterName: "melt01", wantName: "pool01", wantOK: true},
+		{importPath: "name=melt01/pool01", wantClusterName: "melt01", wantName: "pool01", wantOK: true},
+		{importPath: "melt01", wantOK: false},
+		{importPath: "melt01/", wantOK: false},
+		{importPath: "/pool01", wantOK: false},
+		{importPath: "melt01/pool01/extra", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.importPath, func(t *testing.T) {
+			clusterName, name, ok := importNames(tt.importPath)
+			if ok != tt.wantOK || clusterName != tt.wantClusterName || name != tt.wantName {
+				t.Errorf("importNames(%q) = %q, %q, %t, want %q, %q, %t", tt.importPath, clusterName, name, ok, tt.wantClusterName, tt.wantName, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestIDByName(t *testing.T) {
+	names := map[int64]string{
+		1: "melt01",
+		2: "Melt02",
+		3: "melt03",
+		4: "MELT03",
+	}
+
+	tests := []struct {
+		name      string
+		want      int64
+		wantError string
+	}{
+		{name: "melt01", want: 1},
+		{name: "MELT01", want: 1},
+		{name: "melt02", want: 2},
+		{name: "melt03", wantError: "is ambiguous"},
+		{name: "melt04", wantError: "could not find"},
+		{name: "melt0", wantError: "could not find"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := idByName(names, tt.name, "cluster by name "+tt.name)
+			if tt.wantError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+					t.Fatalf("idByName(%q) = %d, %v, want error %q", tt.name, got, err, tt.wantError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("idByName(%q) unexpected error: %s", tt.name, err)
+			}
+			if got != tt.want {
+				t.Errorf("idByName(%q) = %d, want %d", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMachinePoolImportID(t *testing.T) {
+	c := &client.Client{Organization: testImportOrganization}
+
+	tests := []struct {
+		name          string
+		importID      string
+		wantClusterID int64
+		wantID        int64
+		wantError     string
+	}{
+		{name: "path", importID: "clusters/1/machine_pools/2", wantClusterID: 1, wantID: 2},
+		{name: "console URL", importID: "https://app.meltcloud.io/ui/orgs/" + testImportOrganization + "/clusters/1/machine_pools/2", wantClusterID: 1, wantID: 2},
+		{name: "prefixed path", importID: "orgs/" + testImportOrganization + "/clusters/1/machine_pools/2", wantClusterID: 1, wantID: 2},
+		{name: "API path", importID: "/api/v1/orgs/" + testImportOrganization + "/clusters/1/machine_pools/2", wantClusterID: 1, wantID: 2},
+		{name: "prefixed path of another organization", importID: "orgs/00000000-0000-0000-0000-000000000001/clusters/1/machine_pools/2", wantError: "belongs to organization"},
+		{name: "API URL", importID: "https://app.meltcloud.io/api/v1/orgs/" + testImportOrganization + "/clusters/1/machine_pools/2", wantError: "is not a console URL"},
+		{name: "pool ID missing", importID: "clusters/1/machine_pools", wantError: "ID does not follow format"},
+		{name: "extra trailing segments", importID: "clusters/1/machine_pools/2/machines/3", wantError: "ID does not follow format"},
+		{name: "other prefix", importID: "foo/clusters/1/machine_pools/2", wantError: "ID does not follow format"},
+		{name: "elastic node pool path", importID: "clusters/1/elastic_node_pools/2", wantError: "ID does not follow format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clusterID, id, err := machinePoolImportID(context.Background(), c, tt.importID)
+			if tt.wantError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+					t.Fatalf("machinePoolImportID(%q) = %d, %d, %v, want error %q", tt.importID, clusterID, id, err, tt.wantError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("machinePoolImportID(%q) unexpected error: %s", tt.importID, err)
+			}
+			if clusterID != tt.wantClusterID || id != tt.wantID {
+				t.Errorf("machinePoolImportID(%q) = %d, %d, want %d, %d", tt.importID, clusterID, id, tt.wantClusterID, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestClusterImportID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/orgs/"+testImportOrganization+"/clusters") {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(client.ClustersResult{Clusters: []*client.Cluster{
+			{ID: 7, Name: "clusters/5"},
+		}})
+	}))
+	defer server.Close()
+
+	c := client.New(server.URL, testImportOrganization, "dummy", nil)
+
+	tests := []struct {
+		name      string
+		importID  string
+		want      int64
+		wantError string
+	}{
+		{name: "path", importID: "clusters/5", want: 5},
+		{name: "prefixed path", importID: "orgs/" + testImportOrganization + "/clusters/5", want: 5},
+		{name: "console URL", importID: "https://app.meltcloud.io/ui/orgs/" + testImportOrganization + "/clusters/5", want: 5},
+		{name: "name that looks like a path", importID: "name=clusters/5", want: 7},
+		{name: "machine pool path", importID: "clusters/12/machine_pools/3", wantError: "ID does not follow format"},
+		{name: "console URL of a machine pool", importID: "https://app.meltcloud.io/ui/orgs/" + testImportOrganization + "/clusters/12/machine_pools/3", wantError: "ID does not follow format"},
+		{name: "other prefix", importID: "foo/clusters/5", wantError: "ID does not follow format"},
+		{name: "extra trailing segment", importID: "clusters/5/bar", wantError: "ID does not follow format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := clusterImportID(context.Background(), c, tt.importID)
+			if tt.wantError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+					t.Fatalf("clusterImportID(%q) = %d, %v, want error %q", tt.importID, got, err, tt.wantError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("clusterImportID(%q) unexpected error: %s", tt.importID, err)
+			}
+			if got != tt.want {
+				t.Errorf("clusterImportID(%q) = %d, want %d", tt.importID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMachineImportID(t *testing.T) {
+	c := &client.Client{Organization: testImportOrganization}
+
+	tests := []struct {
+		name      string
+		importID  string
+		want      int64
+		wantError string
+	}{
+		{name: "path", importID: "machines/5", want: 5},
+		{name: "console URL", importID: "https://app.meltcloud.io/ui/orgs/" + testImportOrganization + "/machines/5", want: 5},
+		{name: "path of another kind", importID: "elastic_machines/5", wantError: "ID does not follow format"},
+		{name: "extra trailing segment", importID: "machines/5/labels", wantError: "ID does not follow format"},
+		{name: "console URL of another kind", importID: "https://app.meltcloud.io/ui/orgs/" + testImportOrganization + "/clusters/1", wantError: "ID does not follow format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := machineImportID(context.Background(), c, tt.importID)
+			if tt.wantError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+					t.Fatalf("machineImportID(%q) = %d, %v, want error %q", tt.importID, got, err, tt.wantError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("machineImportID(%q) unexpected error: %s", tt.importID, err)
+			}
+			if got != tt.want {
+				t.Errorf("machineImportID(%q) = %d, want %d", tt.importID, got, tt.want)
+			}
+		})
+	}
+}
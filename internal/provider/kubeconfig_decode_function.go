@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-meltcloud/internal/kubernetes"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &KubeConfigDecodeFunction{}
+
+// kubeConfigContextAttributeTypes are the attributes of an entry of the contexts list returned by kubeconfig_decode.
+var kubeConfigContextAttributeTypes = map[string]attr.Type{
+	"name":      types.StringType,
+	"cluster":   types.StringType,
+	"user":      types.StringType,
+	"namespace": types.StringType,
+}
+
+// kubeConfigObjectAttributeTypes are the attributes returned by kubeconfig_decode and accepted by kubeconfig_encode.
+var kubeConfigObjectAttributeTypes = map[string]attr.Type{
+	"current_context":        types.StringType,
+	"context_name":           types.StringType,
+	"cluster_name":           types.StringType,
+	"user_name":              types.StringType,
+	"namespace":              types.StringType,
+	"host":                   types.StringType,
+	"cluster_ca_certificate": types.StringType,
+	"tls_server_name":        types.StringType,
+	"proxy_url":              types.StringType,
+	"client_certificate":     types.StringType,
+	"client_key":             types.StringType,
+	"token":                  types.StringType,
+	"contexts":               types.ListType{ElemType: types.ObjectType{AttrTypes: kubeConfigContextAttributeTypes}},
+}
+
+func NewKubeConfigDecodeFunction() function.Function {
+	return &KubeConfigDecodeFunction{}
+}
+
+// KubeConfigDecodeFunction defines the function implementation.
+type KubeConfigDecodeFunction struct{}
+
+func (f *KubeConfigDecodeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "kubeconfig_decode"
+}
+
+func (f *KubeConfigDecodeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Decode a kubeconfig",
+		MarkdownDescription: "Decodes a kubeconfig, e.g. `kubeconfig_raw` of a `meltcloud_cluster` or a kubeconfig from a remote state output. " +
+			"The connection details (`host`, `cluster_ca_certificate`, `client_certificate`, `client_key`, `token`, ...) are those of the `current-context`, " +
+			"or of the only context if none is set. `contexts` lists all contexts of the kubeconfig. Certificates and keys stay base64 encoded, attributes not " +
+			"set in the kubeconfig are null.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "kubeconfig",
+				Description: "Kubeconfig in YAML format",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: kubeConfigObjectAttributeTypes,
+		},
+	}
+}
+
+func (f *KubeConfigDecodeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var kubeconfig string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &kubeconfig))
+	if resp.Error != nil {
+		return
+	}
+
+	kubeConfig, err := kubernetes.ParseKubeConfig(kubeconfig)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("failed to parse kubeconfig: %s", err))
+		return
+	}
+
+	resolved, err := kubeConfig.Resolve("")
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("failed to parse kubeconfig: %s", err))
+		return
+	}
+
+	contexts := make([]attr.Value, 0, len(kubeConfig.Contexts))
+	for _, c := range kubeConfig.Contexts {
+		contexts = append(contexts, types.ObjectValueMust(kubeConfigContextAttributeTypes, map[string]attr.Value{
+			"name":      types.StringValue(c.Name),
+			"cluster":   types.StringValue(c.Context.Cluster),
+			"user":      types.StringValue(c.Context.User),
+			"namespace": optionalStringValue(c.Context.Namespace),
+		}))
+	}
+
+	result, diags := types.ObjectValue(kubeConfigObjectAttributeTypes, map[string]attr.Value{
+		"current_context":        optionalStringValue(kubeConfig.CurrentContext),
+		"context_name":           optionalStringValue(resolved.Name),
+		"cluster_name":           types.StringValue(resolved.ClusterName),
+		"user_name":              types.StringValue(resolved.UserName),
+		"namespace":              optionalStringValue(resolved.Namespace),
+		"host":                   types.StringValue(resolved.Cluster.Server),
+		"cluster_ca_certificate": optionalStringValue(resolved.Cluster.CertificateAuthorityData),
+		"tls_server_name":        optionalStringValue(resolved.Cluster.TLSServerName),
+		"proxy_url":              optionalStringValue(resolved.Cluster.ProxyURL),
+		"client_certificate":     optionalStringValue(resolved.User.ClientCertificateData),
+		"client_key":             optionalStringValue(resolved.User.ClientKeyData),
+		"token":                  optionalStringValue(resolved.User.BearerToken()),
+		"contexts":               types.ListValueMust(types.ObjectType{AttrTypes: kubeConfigContextAttributeTypes}, contexts),
+	})
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, diags))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, result))
+}
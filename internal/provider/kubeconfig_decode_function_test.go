@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// kubeConfigWithCertificates has base64 encoded certificates, a namespace and a current-context.
+const kubeConfigWithCertificates = `apiVersion: v1
+kind: Config
+clusters:
+- name: prod
+  cluster:
+    server: https://prod.k8s.meltcloud.io
+    certificate-authority-data: Q0E=
+    tls-server-name: api.prod
+users:
+- name: prod-admin
+  user:
+    client-certificate-data: Q0VSVA==
+    client-key-data: S0VZ
+contexts:
+- name: prod-admin@prod
+  context:
+    cluster: prod
+    user: prod-admin
+    namespace: kube-system
+current-context: prod-admin@prod
+`
+
+func runKubeConfigDecode(t *testing.T, kubeconfig string) (types.Object, *function.FuncError) {
+	t.Helper()
+
+	resp := &function.RunResponse{Result: function.NewResultData(types.ObjectUnknown(kubeConfigObjectAttributeTypes))}
+	(&KubeConfigDecodeFunction{}).Run(context.Background(), function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(kubeconfig)}),
+	}, resp)
+
+	result, _ := resp.Result.Value().(types.Object)
+	return result, resp.Error
+}
+
+func TestKubeConfigDecodeFunction(t *testing.T) {
+	tests := []struct {
+		name       string
+		kubeconfig string
+		want       map[string]attr.Value
+		wantError  string
+	}{
+		{
+			name:       "current context",
+			kubeconfig: kubeConfigWithCertificates,
+			want: map[string]attr.Value{
+				"current_context":        types.StringValue("prod-admin@prod"),
+				"context_name":           types.StringValue("prod-admin@prod"),
+				"cluster_name":           types.StringValue("prod"),
+				"user_name":              types.StringValue("prod-admin"),
+				"namespace":              types.StringValue("kube-system"),
+				"host":                   types.StringValue("https://prod.k8s.meltcloud.io"),
+				"cluster_ca_certificate": types.StringValue("Q0E="),
+				"tls_server_name":        types.StringValue("api.prod"),
+				"proxy_url":              types.StringNull(),
+				"client_certificate":     types.StringValue("Q0VSVA=="),
+				"client_key":             types.StringValue("S0VZ"),
+				"token":                  types.StringNull(),
+			},
+		},
+		{
+			name:       "no current context",
+			kubeconfig: strings.Replace(testKubeConfig, "current-context: test\n", "", 1),
+			want: map[string]attr.Value{
+				"current_context": types.StringNull(),
+				"context_name":    types.StringValue("test"),
+				"host":            types.StringValue("https://test.k8s.meltcloud.io"),
+				"token":           types.StringValue("dummy"),
+			},
+		},
+		{
+			name: "no current context and multiple contexts",
+			kubeconfig: strings.Replace(testKubeConfig, "current-context: test\n", "", 1) +
+				"- name: other\n  context:\n    cluster: test\n    user: test-admin\n",
+			wantError: "no current-context and multiple contexts",
+		},
+		{
+			name:       "invalid YAML",
+			kubeconfig: "clusters: [",
+			wantError:  "failed to parse kubeconfig",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := runKubeConfigDecode(t, tt.kubeconfig)
+
+			if tt.wantError != "" {
+				if err == nil || !strings.Contains(err.Text, tt.wantError) {
+					t.Errorf("kubeconfig_decode() error = %v, want %q", err, tt.wantError)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("kubeconfig_decode() error = %v", err)
+			}
+
+			attributes := result.Attributes()
+			for name, want := range tt.want {
+				if !attributes[name].Equal(want) {
+					t.Errorf("kubeconfig_decode() %s = %s, want %s", name, attributes[name], want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"terraform-provider-meltcloud/internal/kubernetes"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &KubeConfigEncodeFunction{}
+
+// kubeConfigEncodeIgnoredAttributes are returned by kubeconfig_decode, but not used for encoding.
+var kubeConfigEncodeIgnoredAttributes = map[string]bool{
+	"current_context": true,
+	"contexts":        true,
+}
+
+const kubeConfigEncodeDefaultName = "default"
+
+func NewKubeConfigEncodeFunction() function.Function {
+	return &KubeConfigEncodeFunction{}
+}
+
+// KubeConfigEncodeFunction defines the function implementation.
+type KubeConfigEncodeFunction struct{}
+
+func (f *KubeConfigEncodeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "kubeconfig_encode"
+}
+
+func (f *KubeConfigEncodeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Encode a kubeconfig",
+		MarkdownDescription: "Renders a kubeconfig with a single context from its parts. The object accepts the attributes returned by `kubeconfig_decode`, " +
+			"all of them optional except `host`: `context_name`, `cluster_name` and `user_name` default to `default`, certificates and keys must be base64 encoded. " +
+			"`current_context` and `contexts` are ignored, so that a decoded kubeconfig can be changed with `merge()` and encoded again.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:        "kubeconfig",
+				Description: "Object with the parts of the kubeconfig, e.g. { host = \"https://...\", token = \"...\" }",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *KubeConfigEncodeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var input types.Dynamic
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &input))
+	if resp.Error != nil {
+		return
+	}
+
+	var attributes map[string]attr.Value
+	switch value := input.UnderlyingValue().(type) {
+	case basetypes.ObjectValue:
+		attributes = value.Attributes()
+	case basetypes.MapValue:
+		attributes = value.Elements()
+	default:
+		resp.Error = function.NewArgumentFuncError(0, "kubeconfig must be an object")
+		return
+	}
+
+	parts := map[string]string{}
+	for _, name := range sortedKeys(attributes) {
+		if kubeConfigEncodeIgnoredAttributes[name] {
+			continue
+		}
+
+		if _, ok := kubeConfigObjectAttributeTypes[name]; !ok {
+			resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("unsupported attribute %q", name))
+			return
+		}
+
+		if attributes[name].IsNull() {
+			continue
+		}
+
+		value, ok := attributes[name].(basetypes.StringValue)
+		if !ok {
+			resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("attribute %q must be a string", name))
+			return
+		}
+
+		if value.IsUnknown() {
+			resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("attribute %q is unknown", name))
+			return
+		}
+
+		parts[name] = value.ValueString()
+	}
+
+	if parts["host"] == "" {
+		resp.Error = function.NewArgumentFuncError(0, "attribute \"host\" is required")
+		return
+	}
+
+	for _, name := range []string{"cluster_ca_certificate", "client_certificate", "client_key"} {
+		if _, err := base64.StdEncoding.DecodeString(parts[name]); err != nil {
+			resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("attribute %q must be base64 encoded: %s", name, err))
+			return
+		}
+	}
+
+	entries := kubernetes.Entries{
+		ContextName: withDefault(parts["context_name"], kubeConfigEncodeDefaultName),
+		ClusterName: withDefault(parts["cluster_name"], kubeConfigEncodeDefaultName),
+		UserName:    withDefault(parts["user_name"], kubeConfigEncodeDefaultName),
+		Namespace:   parts["namespace"],
+		Cluster: kubernetes.Cluster{
+			Server:                   parts["host"],
+			CertificateAuthorityData: parts["cluster_ca_certificate"],
+			TLSServerName:            parts["tls_server_name"],
+			ProxyURL:                 parts["proxy_url"],
+		},
+		User: kubernetes.AuthInfo{
+			ClientCertificateData: parts["client_certificate"],
+			ClientKeyData:         parts["client_key"],
+			Token:                 parts["token"],
+		},
+	}
+
+	kubeConfig := &kubernetes.KubeConfig{CurrentContext: entries.ContextName}
+	kubeConfig.Upsert(entries)
+
+	kubeconfig, err := kubeConfig.Marshal()
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, kubeconfig))
+}
+
+func withDefault(value string, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+
+	return value
+}
+
+func sortedKeys(attributes map[string]attr.Value) []string {
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func runKubeConfigEncode(t *testing.T, kubeconfig attr.Value) (string, *function.FuncError) {
+	t.Helper()
+
+	resp := &function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+	(&KubeConfigEncodeFunction{}).Run(context.Background(), function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{types.DynamicValue(kubeconfig)}),
+	}, resp)
+
+	result, _ := resp.Result.Value().(types.String)
+	return result.ValueString(), resp.Error
+}
+
+func TestKubeConfigEncodeFunction(t *testing.T) {
+	tests := []struct {
+		name       string
+		attributes map[string]attr.Value
+		want       map[string]attr.Value
+		wantError  string
+	}{
+		{
+			name:       "defaults",
+			attributes: map[string]attr.Value{"host": types.StringValue("https://test.k8s.meltcloud.io"), "token": types.StringValue("dummy")},
+			want: map[string]attr.Value{
+				"current_context": types.StringValue("default"),
+				"context_name":    types.StringValue("default"),
+				"cluster_name":    types.StringValue("default"),
+				"user_name":       types.StringValue("default"),
+				"host":            types.StringValue("https://test.k8s.meltcloud.io"),
+				"token":           types.StringValue("dummy"),
+			},
+		},
+		{
+			name:       "missing host",
+			attributes: map[string]attr.Value{"token": types.StringValue("dummy")},
+			wantError:  `attribute "host" is required`,
+		},
+		{
+			name:       "unsupported attribute",
+			attributes: map[string]attr.Value{"host": types.StringValue("https://test.k8s.meltcloud.io"), "server": types.StringValue("dummy")},
+			wantError:  `unsupported attribute "server"`,
+		},
+		{
+			name:       "certificate not base64 encoded",
+			attributes: map[string]attr.Value{"host": types.StringValue("https://test.k8s.meltcloud.io"), "client_certificate": types.StringValue("-----BEGIN")},
+			wantError:  `attribute "client_certificate" must be base64 encoded`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attributeTypes := map[string]attr.Type{}
+			for name := range tt.attributes {
+				attributeTypes[name] = types.StringType
+			}
+
+			kubeconfig, err := runKubeConfigEncode(t, types.ObjectValueMust(attributeTypes, tt.attributes))
+
+			if tt.wantError != "" {
+				if err == nil || !strings.Contains(err.Text, tt.wantError) {
+					t.Errorf("kubeconfig_encode() error = %v, want %q", err, tt.wantError)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("kubeconfig_encode() error = %v", err)
+			}
+
+			result, err := runKubeConfigDecode(t, kubeconfig)
+			if err != nil {
+				t.Fatalf("kubeconfig_decode() of the encoded kubeconfig error = %v", err)
+			}
+
+			attributes := result.Attributes()
+			for name, want := range tt.want {
+				if !attributes[name].Equal(want) {
+					t.Errorf("kubeconfig_encode() %s = %s, want %s", name, attributes[name], want)
+				}
+			}
+		})
+	}
+}
+
+func TestKubeConfigFunctionsRoundTrip(t *testing.T) {
+	decoded, err := runKubeConfigDecode(t, kubeConfigWithCertificates)
+	if err != nil {
+		t.Fatalf("kubeconfig_decode() error = %v", err)
+	}
+
+	// the decoded object is accepted as is, including current_context and contexts
+	encoded, err := runKubeConfigEncode(t, decoded)
+	if err != nil {
+		t.Fatalf("kubeconfig_encode() error = %v", err)
+	}
+
+	roundTripped, err := runKubeConfigDecode(t, encoded)
+	if err != nil {
+		t.Fatalf("kubeconfig_decode() of the encoded kubeconfig error = %v", err)
+	}
+
+	if !roundTripped.Equal(decoded) {
+		t.Errorf("kubeconfig_decode(kubeconfig_encode()) = %s, want %s", roundTripped, decoded)
+	}
+}
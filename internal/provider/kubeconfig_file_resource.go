@@ -0,0 +1,494 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"terraform-provider-meltcloud/internal/client"
+	"terraform-provider-meltcloud/internal/kubernetes"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &KubeConfigFileResource{}
+var _ resource.ResourceWithModifyPlan = &KubeConfigFileResource{}
+
+// kubeConfigFileMutex serializes all reads and writes of kubeconfig files, several resources may merge into the same file.
+var kubeConfigFileMutex sync.Mutex
+
+func NewKubeConfigFileResource() resource.Resource {
+	return &KubeConfigFileResource{}
+}
+
+// KubeConfigFileResource defines the resource implementation.
+type KubeConfigFileResource struct {
+	client *client.Client
+}
+
+// KubeConfigFileResourceModel describes the resource data model.
+type KubeConfigFileResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	ClusterID         types.Int64  `tfsdk:"cluster_id"`
+	Type              types.String `tfsdk:"type"`
+	Path              types.String `tfsdk:"path"`
+	ContextName       types.String `tfsdk:"context_name"`
+	ClusterName       types.String `tfsdk:"cluster_name"`
+	UserName          types.String `tfsdk:"user_name"`
+	Namespace         types.String `tfsdk:"namespace"`
+	FilePermission    types.String `tfsdk:"file_permission"`
+	SetCurrentContext types.Bool   `tfsdk:"set_current_context"`
+	Checksum          types.String `tfsdk:"checksum"`
+}
+
+func (r *KubeConfigFileResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_kubeconfig_file"
+}
+
+const kubeConfigFileDesc = "Writes the kubeconfig of a [Cluster](https://docs.meltcloud.io/tasks/clusters/create) into a local file, e.g. `~/.kube/config`. " +
+	"If the file already exists, the cluster, user and context are merged into it. " +
+	"Existing entries of the same name with different values are not overwritten, choose other names for them instead. On destroy, only these entries are removed again, other contexts are left intact."
+
+func (r *KubeConfigFileResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: kubeConfigFileDesc,
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Path and context name of the written entries",
+				Computed:            true,
+			},
+			"cluster_id": schema.Int64Attribute{
+				MarkdownDescription: clusterResourceAttributes()["id"].GetMarkdownDescription(),
+				Required:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Which kubeconfig to write: `admin` for the admin kubeconfig with a client certificate, `user` for the kubeconfig of regular (OIDC) users. Defaults to `admin`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(clusterCredentialsTypeAdmin),
+				Validators: []validator.String{
+					stringvalidator.OneOf(clusterCredentialsTypeAdmin, clusterCredentialsTypeUser),
+				},
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "Path of the kubeconfig file, e.g. `~/.kube/config`. A leading `~` is expanded to the home directory. If it is a symlink, the file it points to is updated.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"context_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the context in the kubeconfig file. Defaults to the name of the cluster.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cluster_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the cluster entry in the kubeconfig file. Defaults to the name of the cluster.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"user_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the user entry in the kubeconfig file. Defaults to `<cluster name>-admin` or `<cluster name>-user`, depending on `type`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"namespace": schema.StringAttribute{
+				MarkdownDescription: "Default namespace of the context",
+				Optional:            true,
+			},
+			"file_permission": schema.StringAttribute{
+				MarkdownDescription: "Permissions of the kubeconfig file in octal notation, also applied to existing files. Defaults to `0600`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("0600"),
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^0?[0-7]{3}$`), "must be an octal file mode like 0600"),
+				},
+			},
+			"set_current_context": schema.BoolAttribute{
+				MarkdownDescription: "Whether to make the context the `current-context` of the kubeconfig file. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"checksum": schema.StringAttribute{
+				MarkdownDescription: "SHA256 of the cluster and user entries in the file. Changes if the entries were modified outside of Terraform " +
+					"or the cluster's kubeconfig changed, e.g. after the admin credentials were rotated, so that the file is updated.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (r *KubeConfigFileResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *KubeConfigFileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data KubeConfigFileResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.write(ctx, &data, nil); err != nil {
+		resp.Diagnostics.AddError("Kubeconfig File Error", fmt.Sprintf("Unable to merge the cluster into %s, got error: %s", data.Path.ValueString(), err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *KubeConfigFileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data KubeConfigFileResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	kubeConfigFileMutex.Lock()
+	defer kubeConfigFileMutex.Unlock()
+
+	kubeConfig, _, err := readKubeConfigFile(data.Path.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Kubeconfig File Error", fmt.Sprintf("Unable to read %s, got error: %s", data.Path.ValueString(), err))
+		return
+	}
+
+	// entries were removed outside of Terraform, write them again
+	if kubeConfig == nil || !kubeConfig.HasContext(data.ContextName.ValueString()) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// a checksum differing from the cluster's kubeconfig is planned as an update by ModifyPlan
+	data.Checksum = types.StringNull()
+	if entries, ok := kubeConfig.Entries(data.ContextName.ValueString(), data.ClusterName.ValueString(), data.UserName.ValueString()); ok {
+		data.Checksum = types.StringValue(entries.Checksum())
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *KubeConfigFileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state KubeConfigFileResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.write(ctx, &data, &state); err != nil {
+		resp.Diagnostics.AddError("Kubeconfig File Error", fmt.Sprintf("Unable to merge the cluster into %s, got error: %s", data.Path.ValueString(), err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *KubeConfigFileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data KubeConfigFileResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	kubeConfigFileMutex.Lock()
+	defer kubeConfigFileMutex.Unlock()
+
+	file := data.Path.ValueString()
+	kubeConfig, mode, err := readKubeConfigFile(file)
+	if err != nil {
+		resp.Diagnostics.AddError("Kubeconfig File Error", fmt.Sprintf("Unable to read %s, got error: %s", file, err))
+		return
+	}
+
+	if kubeConfig == nil {
+		return
+	}
+
+	kubeConfig.Remove(data.ContextName.ValueString(), data.ClusterName.ValueString(), data.UserName.ValueString())
+
+	if kubeConfig.IsEmpty() {
+		if err := os.Remove(expandHome(file)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			resp.Diagnostics.AddError("Kubeconfig File Error", fmt.Sprintf("Unable to remove %s, got error: %s", file, err))
+		}
+		return
+	}
+
+	if err := writeKubeConfigFile(file, kubeConfig, mode); err != nil {
+		resp.Diagnostics.AddError("Kubeconfig File Error", fmt.Sprintf("Unable to remove the entries from %s, got error: %s", file, err))
+		return
+	}
+}
+
+// ModifyPlan plans an update if the entries in the file differ from the cluster's current kubeconfig.
+func (r *KubeConfigFileResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state KubeConfigFileResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.ClusterID.IsUnknown() || plan.ContextName.IsUnknown() || plan.ClusterName.IsUnknown() || plan.UserName.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("checksum"), types.StringUnknown())...)
+		return
+	}
+
+	entries, err := r.entries(ctx, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError("Kubeconfig File Error", fmt.Sprintf("Unable to read the kubeconfig of the cluster, got error: %s", err))
+		return
+	}
+
+	// an unknown checksum plans an update, which writes the current entries again
+	checksum := types.StringValue(entries.Checksum())
+	if !checksum.Equal(state.Checksum) {
+		checksum = types.StringUnknown()
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("checksum"), checksum)...)
+}
+
+// write merges the cluster's kubeconfig into the file. If previous is set, its entries are removed first, so that
+// renamed contexts, clusters or users do not linger in the file.
+func (r *KubeConfigFileResource) write(ctx context.Context, data *KubeConfigFileResourceModel, previous *KubeConfigFileResourceModel) error {
+	entries, err := r.entries(ctx, data)
+	if err != nil {
+		return err
+	}
+
+	data.ContextName = types.StringValue(entries.ContextName)
+	data.ClusterName = types.StringValue(entries.ClusterName)
+	data.UserName = types.StringValue(entries.UserName)
+	data.ID = types.StringValue(fmt.Sprintf("%s#%s", data.Path.ValueString(), data.ContextName.ValueString()))
+	data.Checksum = types.StringValue(entries.Checksum())
+
+	mode, mErr := strconv.ParseUint(data.FilePermission.ValueString(), 8, 32)
+	if mErr != nil {
+		return fmt.Errorf("invalid file_permission %s: %s", data.FilePermission.ValueString(), mErr)
+	}
+
+	kubeConfigFileMutex.Lock()
+	defer kubeConfigFileMutex.Unlock()
+
+	file := data.Path.ValueString()
+	kubeConfig, _, fErr := readKubeConfigFile(file)
+	if fErr != nil {
+		return fErr
+	}
+
+	if kubeConfig == nil {
+		kubeConfig = &kubernetes.KubeConfig{}
+	} else if previous != nil {
+		kubeConfig.Remove(previous.ContextName.ValueString(), previous.ClusterName.ValueString(), previous.UserName.ValueString())
+	}
+
+	var ours kubernetes.Entries
+	if previous != nil {
+		ours = kubernetes.Entries{
+			ContextName: previous.ContextName.ValueString(),
+			ClusterName: previous.ClusterName.ValueString(),
+			UserName:    previous.UserName.ValueString(),
+		}
+	}
+
+	if conflicts := kubeConfig.Conflicts(entries, ours); len(conflicts) > 0 {
+		return fmt.Errorf("%s already exist(s) with different values, remove or rename them, or choose other names with context_name, cluster_name and user_name",
+			strings.Join(conflicts, ", "))
+	}
+
+	kubeConfig.Upsert(entries)
+
+	if data.SetCurrentContext.ValueBool() {
+		kubeConfig.CurrentContext = data.ContextName.ValueString()
+	} else if previous != nil && previous.SetCurrentContext.ValueBool() && kubeConfig.CurrentContext == previous.ContextName.ValueString() {
+		kubeConfig.CurrentContext = ""
+	}
+
+	return writeKubeConfigFile(file, kubeConfig, fs.FileMode(mode))
+}
+
+// entries returns the entries to write for the cluster's current kubeconfig, names not set in data default to the name
+// of the cluster.
+func (r *KubeConfigFileResource) entries(ctx context.Context, data *KubeConfigFileResourceModel) (kubernetes.Entries, error) {
+	result, err := r.client.Cluster().Get(ctx, data.ClusterID.ValueInt64())
+	if err != nil {
+		return kubernetes.Entries{}, fmt.Errorf("failed to read cluster: %s", err)
+	}
+
+	raw := result.Cluster.KubeConfig
+	if data.Type.ValueString() == clusterCredentialsTypeUser {
+		raw = result.Cluster.KubeConfigUser
+	}
+
+	if raw == "" {
+		return kubernetes.Entries{}, fmt.Errorf("cluster %s has no %s kubeconfig (yet)", result.Cluster.Name, data.Type.ValueString())
+	}
+
+	source, pErr := kubernetes.ParseKubeConfig(raw)
+	if pErr != nil {
+		return kubernetes.Entries{}, fmt.Errorf("failed to parse kubeconfig: %s", pErr)
+	}
+
+	resolved, rErr := source.Resolve("")
+	if rErr != nil {
+		return kubernetes.Entries{}, fmt.Errorf("failed to parse kubeconfig: %s", rErr)
+	}
+
+	entries := kubernetes.Entries{
+		ContextName: result.Cluster.Name,
+		ClusterName: result.Cluster.Name,
+		UserName:    fmt.Sprintf("%s-%s", result.Cluster.Name, data.Type.ValueString()),
+		Namespace:   data.Namespace.ValueString(),
+		Cluster:     resolved.Cluster,
+		User:        resolved.User,
+	}
+
+	if !data.ContextName.IsUnknown() {
+		entries.ContextName = data.ContextName.ValueString()
+	}
+	if !data.ClusterName.IsUnknown() {
+		entries.ClusterName = data.ClusterName.ValueString()
+	}
+	if !data.UserName.IsUnknown() {
+		entries.UserName = data.UserName.ValueString()
+	}
+
+	return entries, nil
+}
+
+// readKubeConfigFile returns nil if the file does not exist, an empty file is read as an empty kubeconfig.
+func readKubeConfigFile(file string) (*kubernetes.KubeConfig, fs.FileMode, error) {
+	content, err := os.ReadFile(expandHome(file))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, 0, nil
+		}
+
+		return nil, 0, fmt.Errorf("failed to read file: %s", err)
+	}
+
+	info, err := os.Stat(expandHome(file))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read file: %s", err)
+	}
+
+	// the file is not validated, it may contain dangling references that kubectl tolerates, e.g. a current-context
+	// left behind by kubectl config delete-context
+	kubeConfig, err := kubernetes.UnmarshalKubeConfig(string(content))
+	if err != nil {
+		return nil, 0, fmt.Errorf("file is not a valid kubeconfig: %s", err)
+	}
+
+	return kubeConfig, info.Mode().Perm(), nil
+}
+
+// writeKubeConfigFile replaces the file atomically, so that kubectl never sees a partially written kubeconfig. If the
+// file is a symlink, e.g. to a kubeconfig managed by a dotfile manager, its target is replaced instead of the link.
+func writeKubeConfigFile(file string, kubeConfig *kubernetes.KubeConfig, mode fs.FileMode) error {
+	file = expandHome(file)
+
+	// the file does not exist yet if the symlinks cannot be resolved
+	if target, err := filepath.EvalSymlinks(file); err == nil {
+		file = target
+	}
+
+	content, err := kubeConfig.Marshal()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return fmt.Errorf("failed to create directory: %s", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(file), ".kubeconfig-*")
+	if err != nil {
+		return fmt.Errorf("failed to write file: %s", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.WriteString(content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write file: %s", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write file: %s", err)
+	}
+
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		return fmt.Errorf("failed to set file permissions: %s", err)
+	}
+
+	if err := os.Rename(tmp.Name(), file); err != nil {
+		return fmt.Errorf("failed to write file: %s", err)
+	}
+
+	return nil
+}
+
+func expandHome(file string) string {
+	if file != "~" && !strings.HasPrefix(file, "~/") {
+		return file
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return file
+	}
+
+	return filepath.Join(home, strings.TrimPrefix(file, "~"))
+}
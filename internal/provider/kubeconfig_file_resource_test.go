@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"terraform-provider-meltcloud/internal/kubernetes"
+	"testing"
+)
+
+func TestWriteKubeConfigFile(t *testing.T) {
+	kubeConfig := &kubernetes.KubeConfig{CurrentContext: "melt01"}
+
+	t.Run("new file", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), ".kube", "config")
+
+		if err := writeKubeConfigFile(file, kubeConfig, 0600); err != nil {
+			t.Fatalf("writeKubeConfigFile() error = %v", err)
+		}
+
+		info, err := os.Stat(file)
+		if err != nil {
+			t.Fatalf("Stat() error = %v", err)
+		}
+		if info.Mode().Perm() != 0600 {
+			t.Errorf("writeKubeConfigFile() mode = %s, want %s", info.Mode().Perm(), os.FileMode(0600))
+		}
+	})
+
+	t.Run("symlink", func(t *testing.T) {
+		dir := t.TempDir()
+		target := filepath.Join(dir, "dotfiles", "kubeconfig")
+		link := filepath.Join(dir, ".kube", "config")
+
+		for _, d := range []string{filepath.Dir(target), filepath.Dir(link)} {
+			if err := os.MkdirAll(d, 0700); err != nil {
+				t.Fatalf("MkdirAll() error = %v", err)
+			}
+		}
+		if err := os.WriteFile(target, []byte("apiVersion: v1\nkind: Config\n"), 0640); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+		if err := os.Symlink(target, link); err != nil {
+			t.Fatalf("Symlink() error = %v", err)
+		}
+
+		if err := writeKubeConfigFile(link, kubeConfig, 0640); err != nil {
+			t.Fatalf("writeKubeConfigFile() error = %v", err)
+		}
+
+		info, err := os.Lstat(link)
+		if err != nil {
+			t.Fatalf("Lstat() error = %v", err)
+		}
+		if info.Mode()&os.ModeSymlink == 0 {
+			t.Errorf("writeKubeConfigFile() replaced the symlink with a %s", info.Mode().Type())
+		}
+
+		content, err := os.ReadFile(target)
+		if err != nil {
+			t.Fatalf("ReadFile() error = %v", err)
+		}
+		if !strings.Contains(string(content), "current-context: melt01") {
+			t.Errorf("writeKubeConfigFile() did not update the target of the symlink, got:\n%s", content)
+		}
+
+		entries, err := os.ReadDir(filepath.Dir(link))
+		if err != nil {
+			t.Fatalf("ReadDir() error = %v", err)
+		}
+		if len(entries) != 1 {
+			t.Errorf("writeKubeConfigFile() left files next to the symlink: %v", entries)
+		}
+	})
+}
@@ -0,0 +1,189 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"terraform-provider-meltcloud/internal/client"
+	"terraform-provider-meltcloud/internal/kubernetes"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// kubernetesVersionEndOfSupportWarning is how long before the end of support of a minor version plans warn about it.
+const kubernetesVersionEndOfSupportWarning = 90 * 24 * time.Hour
+
+// sortedKubernetesVersions returns the versions sorted oldest first, unparsable versions are dropped.
+func sortedKubernetesVersions(versions []*client.KubernetesVersion) []*client.KubernetesVersion {
+	parsed := map[*client.KubernetesVersion]*kubernetes.Version{}
+	sorted := make([]*client.KubernetesVersion, 0, len(versions))
+	for _, version := range versions {
+		v, err := kubernetes.ParseVersion(version.Version)
+		if err != nil {
+			continue
+		}
+		parsed[version] = v
+		sorted = append(sorted, version)
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return parsed[sorted[i]].Compare(parsed[sorted[j]]) < 0
+	})
+
+	return sorted
+}
+
+// validateKubernetesVersion checks a planned Kubernetes version against the versions supported by meltcloud. A new or
+// changed version that is not supported is an error, a version nearing or past its end of support is a warning.
+// If the supported versions cannot be fetched, the version is not validated.
+func validateKubernetesVersion(ctx context.Context, c *client.Client, attribute path.Path, planned types.String, prior types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if c == nil || planned.IsNull() || planned.IsUnknown() {
+		return diags
+	}
+
+	plannedVersion, parseErr := kubernetes.ParseVersion(planned.ValueString())
+	if parseErr != nil {
+		if planned.Equal(prior) {
+			return diags
+		}
+		diags.AddAttributeError(attribute, "Invalid Kubernetes Version", parseErr.Error())
+		return diags
+	}
+
+	result, err := c.KubernetesVersion().ListCached(ctx)
+	if err != nil {
+		if err.HTTPStatusCode != 404 {
+			diags.AddAttributeWarning(attribute, "Unable to Validate Kubernetes Version",
+				fmt.Sprintf("Unable to read the supported Kubernetes versions, got error: %s", err))
+		}
+		return diags
+	}
+
+	var supported *client.KubernetesVersion
+	var supportedVersions []string
+	for _, version := range sortedKubernetesVersions(result.KubernetesVersions) {
+		supportedVersions = append(supportedVersions, version.Version)
+
+		v, _ := kubernetes.ParseVersion(version.Version)
+		if v.MinorVersion() == plannedVersion.MinorVersion() {
+			supported = version
+		}
+	}
+
+	if supported == nil {
+		summary := fmt.Sprintf("Kubernetes version %s is not supported by meltcloud, supported versions are: %s.",
+			planned.ValueString(), strings.Join(supportedVersions, ", "))
+
+		if planned.Equal(prior) {
+			// do not block changes to existing resources still running an unsupported version
+			diags.AddAttributeWarning(attribute, "Unsupported Kubernetes Version", summary+" Upgrade to a supported version.")
+		} else {
+			diags.AddAttributeError(attribute, "Unsupported Kubernetes Version", summary)
+		}
+
+		return diags
+	}
+
+	if supported.EndOfSupport.IsZero() {
+		return diags
+	}
+
+	untilEndOfSupport := time.Until(supported.EndOfSupport)
+	switch {
+	case untilEndOfSupport <= 0:
+		diags.AddAttributeWarning(attribute, "Kubernetes Version Past End of Support",
+			fmt.Sprintf("Kubernetes version %s reached its end of support on %s. Upgrade to a newer version.",
+				supported.Version, supported.EndOfSupport.Format(time.DateOnly)))
+	case untilEndOfSupport <= kubernetesVersionEndOfSupportWarning:
+		diags.AddAttributeWarning(attribute, "Kubernetes Version Nearing End of Support",
+			fmt.Sprintf("Kubernetes version %s reaches its end of support on %s (in %d days). Plan an upgrade to a newer version.",
+				supported.Version, supported.EndOfSupport.Format(time.DateOnly), int(untilEndOfSupport.Hours()/24)))
+	}
+
+	return diags
+}
+
+const skipUpgradeChecksHint = "To override this check in an emergency, set skip_upgrade_checks = true."
+
+// skipUpgradeChecksAttribute returns the schema attribute to override the checks of validateVersionChange and
+// validateKubeletVersion.
+func skipUpgradeChecksAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		MarkdownDescription: "Skip the plan-time checks of `version` changes: downgrades, upgrades skipping a minor version and " +
+			"kubelets newer than the control plane (or more than 3 minor versions older) are rejected unless set to `true`. " +
+			"Use `meltcloud_cluster_upgrade` to upgrade the control plane and the pools in one apply. " +
+			"Only meant for emergencies, unsupported version changes may break the cluster.",
+		Optional: true,
+	}
+}
+
+// validateVersionChange rejects downgrades and upgrades skipping a minor version of an existing resource.
+func validateVersionChange(attribute path.Path, planned types.String, prior types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if planned.IsNull() || planned.IsUnknown() || prior.IsNull() || prior.IsUnknown() || planned.Equal(prior) {
+		return diags
+	}
+
+	from, err := kubernetes.ParseVersion(prior.ValueString())
+	if err != nil {
+		// versions unknown to the provider are not checked
+		return diags
+	}
+
+	to, err := kubernetes.ParseVersion(planned.ValueString())
+	if err != nil {
+		return diags
+	}
+
+	if err := kubernetes.CheckUpgrade(from, to); err != nil {
+		diags.AddAttributeError(attribute, "Unsupported Version Change", fmt.Sprintf("The version cannot be changed: %s. %s", err, skipUpgradeChecksHint))
+	}
+
+	return diags
+}
+
+// validateKubeletVersion rejects kubelet versions not supported by the current control plane version of the cluster,
+// see kubernetes.CheckKubeletSkew. The cluster is looked up through the client, as its planned version is not known here.
+func validateKubeletVersion(ctx context.Context, c *client.Client, attribute path.Path, clusterID types.Int64, planned types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if c == nil || clusterID.IsNull() || clusterID.IsUnknown() || planned.IsNull() || planned.IsUnknown() {
+		return diags
+	}
+
+	kubelet, err := kubernetes.ParseVersion(planned.ValueString())
+	if err != nil {
+		return diags
+	}
+
+	result, clientErr := c.Cluster().Get(ctx, clusterID.ValueInt64())
+	if clientErr != nil {
+		if clientErr.HTTPStatusCode != 404 {
+			diags.AddAttributeWarning(attribute, "Unable to Validate Kubernetes Version",
+				fmt.Sprintf("Unable to read cluster %d to compare its version, got error: %s", clusterID.ValueInt64(), clientErr))
+		}
+		return diags
+	}
+
+	controlPlane, err := kubernetes.ParseVersion(result.Cluster.UserVersion)
+	if err != nil {
+		return diags
+	}
+
+	if err := kubernetes.CheckKubeletSkew(controlPlane, kubelet); err != nil {
+		diags.AddAttributeError(attribute, "Unsupported Kubelet Version",
+			fmt.Sprintf("Cluster %s runs Kubernetes %s: %s. Upgrade the control plane first and apply, then the pools, "+
+				"or upgrade both in one apply with a meltcloud_cluster_upgrade. %s",
+				result.Cluster.Name, controlPlane.MinorVersion(), err, skipUpgradeChecksHint))
+	}
+
+	return diags
+}
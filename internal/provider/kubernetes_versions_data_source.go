@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-meltcloud/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &KubernetesVersionsDataSource{}
+
+func NewKubernetesVersionsDataSource() datasource.DataSource {
+	return &KubernetesVersionsDataSource{}
+}
+
+// KubernetesVersionsDataSource defines the data source implementation.
+type KubernetesVersionsDataSource struct {
+	client *client.Client
+}
+
+// KubernetesVersionsDataSourceModel describes the data source data model.
+type KubernetesVersionsDataSourceModel struct {
+	DefaultVersion types.String                       `tfsdk:"default_version"`
+	LatestVersion  types.String                       `tfsdk:"latest_version"`
+	Versions       []KubernetesVersionDataSourceModel `tfsdk:"versions"`
+}
+
+type KubernetesVersionDataSourceModel struct {
+	Version            types.String      `tfsdk:"version"`
+	LatestPatchVersion types.String      `tfsdk:"latest_patch_version"`
+	EndOfSupport       timetypes.RFC3339 `tfsdk:"end_of_support"`
+}
+
+func (d *KubernetesVersionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_kubernetes_versions"
+}
+
+func (d *KubernetesVersionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Kubernetes minor versions supported by meltcloud for clusters, machine pools and elastic node pools. " +
+			"The `version` of these resources is validated against this list at plan time.",
+
+		Attributes: map[string]schema.Attribute{
+			"default_version": schema.StringAttribute{
+				MarkdownDescription: "Minor version recommended for new clusters",
+				Computed:            true,
+			},
+			"latest_version": schema.StringAttribute{
+				MarkdownDescription: "Newest supported minor version",
+				Computed:            true,
+			},
+			"versions": schema.ListNestedAttribute{
+				MarkdownDescription: "Supported minor versions, oldest first",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"version": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Kubernetes minor version, e.g. `1.30`",
+						},
+						"latest_patch_version": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Newest patch version of the minor version, rolled out to clusters and pools of this minor version",
+						},
+						"end_of_support": schema.StringAttribute{
+							CustomType:          timetypes.RFC3339Type{},
+							Computed:            true,
+							MarkdownDescription: "Timestamp after which the minor version is no longer supported",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *KubernetesVersionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *KubernetesVersionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data KubernetesVersionsDataSourceModel
+
+	result, err := d.client.KubernetesVersion().List(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read Kubernetes versions, got error: %s", err))
+		return
+	}
+
+	versions := sortedKubernetesVersions(result.KubernetesVersions)
+
+	data.DefaultVersion = types.StringValue(result.DefaultVersion)
+	data.LatestVersion = types.StringNull()
+	if len(versions) > 0 {
+		data.LatestVersion = types.StringValue(versions[len(versions)-1].Version)
+	}
+
+	data.Versions = []KubernetesVersionDataSourceModel{}
+	for _, version := range versions {
+		data.Versions = append(data.Versions, KubernetesVersionDataSourceModel{
+			Version:            types.StringValue(version.Version),
+			LatestPatchVersion: types.StringValue(version.LatestPatchVersion),
+			EndOfSupport:       timetypes.NewRFC3339TimeValue(version.EndOfSupport),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"terraform-provider-meltcloud/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ list.ListResource = &MachineListResource{}
+var _ list.ListResourceWithConfigure = &MachineListResource{}
+
+func NewMachineListResource() list.ListResource {
+	return &MachineListResource{}
+}
+
+// MachineListResource defines the list resource implementation.
+type MachineListResource struct {
+	client *client.Client
+}
+
+// MachineListResourceModel describes the list resource filter model.
+type MachineListResourceModel struct {
+	MachinePoolID types.Int64  `tfsdk:"machine_pool_id"`
+	Unassigned    types.Bool   `tfsdk:"unassigned"`
+	Status        types.String `tfsdk:"status"`
+}
+
+func (r *MachineListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_machine"
+}
+
+func (r *MachineListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: machineDesc,
+
+		Attributes: map[string]schema.Attribute{
+			"machine_pool_id": schema.Int64Attribute{
+				MarkdownDescription: "Only list machines assigned to this Machine Pool",
+				Optional:            true,
+			},
+			"unassigned": schema.BoolAttribute{
+				MarkdownDescription: "Only list machines which are not assigned to any Machine Pool",
+				Optional:            true,
+				Validators: []validator.Bool{
+					boolvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("machine_pool_id")),
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Only list machines with this status",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *MachineListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *MachineListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var data MachineListResourceModel
+	diags := req.Config.Get(ctx, &data)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	result, err := r.client.Machine().List(ctx)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to list machines, got error: %s", err))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for _, machine := range result.Machines {
+			if !r.matches(&data, machine) {
+				continue
+			}
+
+			if !push(r.listResult(ctx, req, machine)) {
+				return
+			}
+		}
+	}
+}
+
+// matches returns whether the machine passes all filters of data.
+func (r *MachineListResource) matches(data *MachineListResourceModel, machine *client.Machine) bool {
+	if !data.MachinePoolID.IsNull() && data.MachinePoolID.ValueInt64() != machine.MachinePoolID {
+		return false
+	}
+	if data.Unassigned.ValueBool() && machine.MachinePoolID != 0 {
+		return false
+	}
+	if !data.Status.IsNull() && !strings.EqualFold(data.Status.ValueString(), machine.Status) {
+		return false
+	}
+
+	return true
+}
+
+func (r *MachineListResource) listResult(ctx context.Context, req list.ListRequest, machine *client.Machine) list.ListResult {
+	result := req.NewListResult(ctx)
+	result.DisplayName = machine.Name
+	if result.DisplayName == "" {
+		result.DisplayName = machine.UUID.String()
+	}
+
+	result.Diagnostics.Append(result.Identity.Set(ctx, MachineResourceIdentityModel{UUID: types.StringValue(machine.UUID.String())})...)
+
+	if req.IncludeResource {
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("id"), machine.ID)...)
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("uuid"), machine.UUID.String())...)
+		if machine.Name != "" {
+			result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("name"), machine.Name)...)
+		}
+		if machine.MachinePoolID != 0 {
+			result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("machine_pool_id"), machine.MachinePoolID)...)
+		}
+
+		var labels []LabelResourceModel
+		for _, label := range machine.Labels {
+			labels = append(labels, LabelResourceModel{
+				Key:   types.StringValue(label.Key),
+				Value: types.StringValue(label.Value),
+			})
+		}
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("label"), labels)...)
+	}
+
+	return result
+}
@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"terraform-provider-meltcloud/internal/client"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestMachineListResourceMatches(t *testing.T) {
+	assigned := &client.Machine{ID: 1, MachinePoolID: 5, Status: "Ready"}
+	unassigned := &client.Machine{ID: 2, Status: "Unassigned"}
+
+	noFilter := MachineListResourceModel{MachinePoolID: types.Int64Null(), Unassigned: types.BoolNull(), Status: types.StringNull()}
+
+	tests := []struct {
+		name           string
+		filter         func(data *MachineListResourceModel)
+		wantAssigned   bool
+		wantUnassigned bool
+	}{
+		{name: "no filter", filter: func(data *MachineListResourceModel) {}, wantAssigned: true, wantUnassigned: true},
+		{name: "machine pool", filter: func(data *MachineListResourceModel) { data.MachinePoolID = types.Int64Value(5) }, wantAssigned: true},
+		{name: "other machine pool", filter: func(data *MachineListResourceModel) { data.MachinePoolID = types.Int64Value(6) }},
+		{name: "unassigned", filter: func(data *MachineListResourceModel) { data.Unassigned = types.BoolValue(true) }, wantUnassigned: true},
+		{name: "unassigned false", filter: func(data *MachineListResourceModel) { data.Unassigned = types.BoolValue(false) }, wantAssigned: true, wantUnassigned: true},
+		{name: "status", filter: func(data *MachineListResourceModel) { data.Status = types.StringValue("Ready") }, wantAssigned: true},
+		{name: "status in other case", filter: func(data *MachineListResourceModel) { data.Status = types.StringValue("ready") }, wantAssigned: true},
+		{
+			name: "machine pool and status",
+			filter: func(data *MachineListResourceModel) {
+				data.MachinePoolID = types.Int64Value(5)
+				data.Status = types.StringValue("Unassigned")
+			},
+		},
+	}
+
+	r := &MachineListResource{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := noFilter
+			tt.filter(&data)
+
+			if got := r.matches(&data, assigned); got != tt.wantAssigned {
+				t.Errorf("matches(assigned) = %t, want %t", got, tt.wantAssigned)
+			}
+			if got := r.matches(&data, unassigned); got != tt.wantUnassigned {
+				t.Errorf("matches(unassigned) = %t, want %t", got, tt.wantUnassigned)
+			}
+		})
+	}
+}
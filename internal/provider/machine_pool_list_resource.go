@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-meltcloud/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ list.ListResource = &MachinePoolListResource{}
+var _ list.ListResourceWithConfigure = &MachinePoolListResource{}
+
+func NewMachinePoolListResource() list.ListResource {
+	return &MachinePoolListResource{}
+}
+
+// MachinePoolListResource defines the list resource implementation.
+type MachinePoolListResource struct {
+	client *client.Client
+}
+
+// MachinePoolListResourceModel describes the list resource filter model.
+type MachinePoolListResourceModel struct {
+	ClusterID types.Int64  `tfsdk:"cluster_id"`
+	Version   types.String `tfsdk:"version"`
+}
+
+func (r *MachinePoolListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_machine_pool"
+}
+
+func (r *MachinePoolListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: machinePoolDesc,
+
+		Attributes: map[string]schema.Attribute{
+			"cluster_id": schema.Int64Attribute{
+				MarkdownDescription: "Only list machine pools of this Cluster, lists the machine pools of all clusters if not set",
+				Optional:            true,
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "Only list machine pools with this Kubernetes minor version",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *MachinePoolListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *MachinePoolListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var data MachinePoolListResourceModel
+	diags := req.Config.Get(ctx, &data)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var clusterIDs []int64
+	if !data.ClusterID.IsNull() {
+		clusterIDs = append(clusterIDs, data.ClusterID.ValueInt64())
+	} else {
+		result, err := r.client.Cluster().List(ctx)
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to list clusters, got error: %s", err))
+			stream.Results = list.ListResultsStreamDiagnostics(diags)
+			return
+		}
+
+		for _, cluster := range result.Clusters {
+			clusterIDs = append(clusterIDs, cluster.ID)
+		}
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for _, clusterID := range clusterIDs {
+			result, err := r.client.MachinePool().List(ctx, clusterID)
+			if err != nil {
+				errResult := list.ListResult{}
+				errResult.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list machine pools of cluster %d, got error: %s", clusterID, err))
+				push(errResult)
+				return
+			}
+
+			for _, machinePool := range result.MachinePools {
+				if !data.Version.IsNull() && data.Version.ValueString() != machinePool.UserVersion {
+					continue
+				}
+
+				if !push(r.listResult(ctx, req, clusterID, machinePool)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (r *MachinePoolListResource) listResult(ctx context.Context, req list.ListRequest, clusterID int64, machinePool *client.MachinePool) list.ListResult {
+	result := req.NewListResult(ctx)
+	result.DisplayName = machinePool.Name
+
+	result.Diagnostics.Append(result.Identity.Set(ctx, MachinePoolResourceIdentityModel{ClusterID: types.Int64Value(clusterID), MachinePoolID: types.Int64Value(machinePool.ID)})...)
+
+	if req.IncludeResource {
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("id"), machinePool.ID)...)
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("cluster_id"), clusterID)...)
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("name"), machinePool.Name)...)
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("version"), machinePool.UserVersion)...)
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("patch_version"), machinePool.PatchVersion)...)
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("network_profile_id"), machinePool.NetworkProfileID)...)
+	}
+
+	return result
+}
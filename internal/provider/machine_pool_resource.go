@@ -3,12 +3,12 @@ package provider
 import (
 	"context"
 	"fmt"
-	"regexp"
-	"strconv"
 	"terraform-provider-meltcloud/internal/client"
 
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -18,6 +18,8 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &MachinePoolResource{}
 var _ resource.ResourceWithImportState = &MachinePoolResource{}
+var _ resource.ResourceWithIdentity = &MachinePoolResource{}
+var _ resource.ResourceWithModifyPlan = &MachinePoolResource{}
 
 func NewMachinePoolResource() resource.Resource {
 	return &MachinePoolResource{}
@@ -36,6 +38,20 @@ type MachinePoolResourceModel struct {
 	Version          types.String `tfsdk:"version"`
 	PatchVersion     types.String `tfsdk:"patch_version"`
 	NetworkProfileID types.Int64  `tfsdk:"network_profile_id"`
+
+	SkipUpgradeChecks types.Bool `tfsdk:"skip_upgrade_checks"`
+
+	MaintenanceWindow *MaintenanceWindowModel `tfsdk:"maintenance_window"`
+	AutoUpgrade       *AutoUpgradeModel       `tfsdk:"auto_upgrade"`
+	NextMaintenanceAt timetypes.RFC3339       `tfsdk:"next_maintenance_at"`
+
+	DeletionProtection types.Bool `tfsdk:"deletion_protection"`
+}
+
+// MachinePoolResourceIdentityModel describes the resource identity data model.
+type MachinePoolResourceIdentityModel struct {
+	ClusterID     types.Int64 `tfsdk:"cluster_id"`
+	MachinePoolID types.Int64 `tfsdk:"machine_pool_id"`
 }
 
 func (r *MachinePoolResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -58,7 +74,7 @@ func machinePoolResourceAttributes() map[string]schema.Attribute {
 			MarkdownDescription: "ID of the associated cluster",
 			Required:            true,
 			PlanModifiers: []planmodifier.Int64{
-				int64planmodifier.RequiresReplace(),
+				requiresReplaceUnlessProtectedInt64("machine pool"),
 			},
 		},
 		"name": schema.StringAttribute{
@@ -66,13 +82,16 @@ func machinePoolResourceAttributes() map[string]schema.Attribute {
 			Required:            true,
 		},
 		"version": schema.StringAttribute{
-			MarkdownDescription: "Kubernetes minor version of the machine pool (Kubelet)",
+			MarkdownDescription: "Kubernetes minor version of the machine pool (Kubelet). Must be one of the versions of the `meltcloud_kubernetes_versions` data source, plans warn when it nears its end of support.",
 			Required:            true,
 		},
+		"skip_upgrade_checks": skipUpgradeChecksAttribute(),
+		"deletion_protection": deletionProtectionAttribute("machine pool"),
 		"patch_version": schema.StringAttribute{
-			MarkdownDescription: "Kubernetes patch version of the machine pool (Kubelet)",
+			MarkdownDescription: "Kubernetes patch version of the machine pool (Kubelet), rolled out by meltcloud according to `auto_upgrade` and `maintenance_window`",
 			Computed:            true,
 		},
+		"next_maintenance_at": nextMaintenanceAtAttribute(),
 		"network_profile_id": schema.Int64Attribute{
 			MarkdownDescription: "ID of the network profile",
 			Optional:            true,
@@ -86,6 +105,26 @@ func (r *MachinePoolResource) Schema(ctx context.Context, req resource.SchemaReq
 			"~> Be aware that changing the version or the network profile will cause a new [Revision that will be rolled out immediately, causing a reboot of all Machines](https://docs.meltcloud.io/tasks/machine-pools/upgrade).",
 
 		Attributes: machinePoolResourceAttributes(),
+
+		Blocks: map[string]schema.Block{
+			"maintenance_window": maintenanceWindowBlock("machine pool"),
+			"auto_upgrade":       autoUpgradeBlock("machine pool"),
+		},
+	}
+}
+
+func (r *MachinePoolResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"cluster_id": identityschema.Int64Attribute{
+				Description:       "Internal ID of the Cluster in meltcloud",
+				RequiredForImport: true,
+			},
+			"machine_pool_id": identityschema.Int64Attribute{
+				Description:       "Internal ID of the Machine Pool in meltcloud",
+				RequiredForImport: true,
+			},
+		},
 	}
 }
 
@@ -127,6 +166,11 @@ func (r *MachinePoolResource) Create(ctx context.Context, req resource.CreateReq
 		Name:             data.Name.ValueString(),
 		UserVersion:      data.Version.ValueString(),
 		NetworkProfileID: profileID,
+
+		MaintenanceWindow: maintenanceWindowInput(data.MaintenanceWindow),
+		AutoUpgrade:       autoUpgradeInput(data.AutoUpgrade),
+
+		DeletionProtection: data.DeletionProtection.ValueBoolPointer(),
 	}
 
 	result, err := r.client.MachinePool().Create(ctx, data.ClusterId.ValueInt64(), machinePoolCreateInput)
@@ -137,8 +181,10 @@ func (r *MachinePoolResource) Create(ctx context.Context, req resource.CreateReq
 
 	data.ID = types.Int64Value(result.MachinePool.ID)
 	data.PatchVersion = types.StringValue(result.MachinePool.PatchVersion)
+	data.NextMaintenanceAt = nextMaintenanceAtValue(result.MachinePool.NextMaintenanceAt)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, MachinePoolResourceIdentityModel{ClusterID: data.ClusterId, MachinePoolID: data.ID})...)
 }
 
 func (r *MachinePoolResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -168,8 +214,13 @@ func (r *MachinePoolResource) Read(ctx context.Context, req resource.ReadRequest
 	}
 	data.Version = types.StringValue(result.MachinePool.UserVersion)
 	data.PatchVersion = types.StringValue(result.MachinePool.PatchVersion)
+	data.MaintenanceWindow = maintenanceWindowModel(result.MachinePool.MaintenanceWindow)
+	data.AutoUpgrade = autoUpgradeModel(result.MachinePool.AutoUpgrade)
+	data.NextMaintenanceAt = nextMaintenanceAtValue(result.MachinePool.NextMaintenanceAt)
+	data.DeletionProtection = types.BoolValue(result.MachinePool.DeletionProtection)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, MachinePoolResourceIdentityModel{ClusterID: data.ClusterId, MachinePoolID: data.ID})...)
 }
 
 func (r *MachinePoolResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -189,6 +240,11 @@ func (r *MachinePoolResource) Update(ctx context.Context, req resource.UpdateReq
 		Name:             data.Name.ValueString(),
 		UserVersion:      data.Version.ValueString(),
 		NetworkProfileID: profileID,
+
+		MaintenanceWindow: maintenanceWindowUpdateInput(data.MaintenanceWindow),
+		AutoUpgrade:       autoUpgradeUpdateInput(data.AutoUpgrade),
+
+		DeletionProtection: data.DeletionProtection.ValueBoolPointer(),
 	}
 
 	result, err := r.client.MachinePool().Update(ctx, data.ClusterId.ValueInt64(), data.ID.ValueInt64(), machinePoolUpdateInput)
@@ -204,22 +260,55 @@ func (r *MachinePoolResource) Update(ctx context.Context, req resource.UpdateReq
 			return
 		}
 
-		_, err := r.client.MachinePool().Get(ctx, data.ClusterId.ValueInt64(), data.ID.ValueInt64())
+		result, err = r.client.MachinePool().Get(ctx, data.ClusterId.ValueInt64(), data.ID.ValueInt64())
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read machine pool, got error: %s", err))
 			return
 		}
-		data.PatchVersion = types.StringValue(result.MachinePool.PatchVersion)
-	} else {
-		data.PatchVersion = types.StringValue(result.MachinePool.PatchVersion)
 	}
 
+	data.PatchVersion = types.StringValue(result.MachinePool.PatchVersion)
+	data.NextMaintenanceAt = nextMaintenanceAtValue(result.MachinePool.NextMaintenanceAt)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, MachinePoolResourceIdentityModel{ClusterID: data.ClusterId, MachinePoolID: data.ID})...)
+}
+
+// ModifyPlan refuses to destroy the machine pool if deletion_protection is enabled, validates the Kubernetes version
+// against the versions supported by meltcloud and rejects version changes not supported by the control plane of the
+// cluster, unless skip_upgrade_checks is set. next_maintenance_at is recomputed when the maintenance settings change.
+func (r *MachinePoolResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		resp.Diagnostics.Append(checkDeletionProtection(ctx, req.State, "machine pool", "the plan destroys it")...)
+		return
+	}
+
+	var plan, state MachinePoolResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateKubernetesVersion(ctx, r.client, path.Root("version"), plan.Version, state.Version)...)
+
+	planNextMaintenanceAt(ctx, req, resp)
+
+	if plan.SkipUpgradeChecks.ValueBool() || plan.Version.Equal(state.Version) {
+		return
+	}
+
+	resp.Diagnostics.Append(validateVersionChange(path.Root("version"), plan.Version, state.Version)...)
+	resp.Diagnostics.Append(validateKubeletVersion(ctx, r.client, path.Root("version"), plan.ClusterId, plan.Version)...)
 }
 
 func (r *MachinePoolResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var data MachinePoolResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	resp.Diagnostics.Append(checkDeletionProtection(ctx, req.State, "machine pool", "it is being deleted")...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -232,29 +321,28 @@ func (r *MachinePoolResource) Delete(ctx context.Context, req resource.DeleteReq
 	}
 }
 
-var machinePoolImportIDPattern = regexp.MustCompile(`clusters/(\d+)/machine_pools/(\d+)`)
-
 func (r *MachinePoolResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	match := machinePoolImportIDPattern.FindStringSubmatch(req.ID)
-	if len(match) != 3 {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("ID does not follow format: %s", machinePoolImportIDPattern.String()))
-		return
-	}
+	if req.ID == "" {
+		var identity MachinePoolResourceIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 
-	clusterID, err := strconv.ParseInt(match[1], 10, 64)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Invalid cluster ID: %s", err))
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster_id"), identity.ClusterID)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), identity.MachinePoolID)...)
 		return
 	}
 
-	id, err := strconv.ParseInt(match[2], 10, 64)
+	clusterID, id, err := machinePoolImportID(ctx, r.client, req.ID)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Invalid ID: %s", err))
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import: %s", err))
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster_id"), clusterID)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, MachinePoolResourceIdentityModel{ClusterID: types.Int64Value(clusterID), MachinePoolID: types.Int64Value(id)})...)
 
 	if resp.Diagnostics.HasError() {
 		return
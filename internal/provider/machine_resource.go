@@ -3,13 +3,12 @@ package provider
 import (
 	"context"
 	"fmt"
-	"regexp"
-	"strconv"
 	"terraform-provider-meltcloud/internal/client"
 
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -20,6 +19,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &MachineResource{}
 var _ resource.ResourceWithImportState = &MachineResource{}
+var _ resource.ResourceWithIdentity = &MachineResource{}
 
 func NewMachineResource() resource.Resource {
 	return &MachineResource{}
@@ -39,6 +39,11 @@ type MachineResourceModel struct {
 	Labels        types.List   `tfsdk:"label"`
 }
 
+// MachineResourceIdentityModel describes the resource identity data model.
+type MachineResourceIdentityModel struct {
+	UUID types.String `tfsdk:"uuid"`
+}
+
 type LabelResourceModel struct {
 	Key   types.String `tfsdk:"key"`
 	Value types.String `tfsdk:"value"`
@@ -109,6 +114,17 @@ func (r *MachineResource) Schema(ctx context.Context, req resource.SchemaRequest
 	}
 }
 
+func (r *MachineResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"uuid": identityschema.StringAttribute{
+				Description:       "UUID of the Machine",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
 func (r *MachineResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -166,6 +182,7 @@ func (r *MachineResource) Create(ctx context.Context, req resource.CreateRequest
 	data.ID = types.Int64Value(result.Machine.ID)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, MachineResourceIdentityModel{UUID: data.UUID})...)
 }
 
 func (r *MachineResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -192,6 +209,7 @@ func (r *MachineResource) Read(ctx context.Context, req resource.ReadRequest, re
 	data.MachinePoolID = types.Int64Value(result.Machine.MachinePoolID)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, MachineResourceIdentityModel{UUID: data.UUID})...)
 
 	var labels []LabelResourceModel
 	for _, label := range result.Machine.Labels {
@@ -250,6 +268,7 @@ func (r *MachineResource) Update(ctx context.Context, req resource.UpdateRequest
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, MachineResourceIdentityModel{UUID: data.UUID})...)
 }
 
 func (r *MachineResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -267,18 +286,34 @@ func (r *MachineResource) Delete(ctx context.Context, req resource.DeleteRequest
 	}
 }
 
-var machineImportIDPattern = regexp.MustCompile(`machines/(\d+)`)
-
 func (r *MachineResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	match := machineImportIDPattern.FindStringSubmatch(req.ID)
-	if len(match) != 2 {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("ID does not follow format: %s", machineImportIDPattern.String()))
+	if req.ID == "" {
+		var identity MachineResourceIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		machineUUID, uErr := uuid.Parse(identity.UUID.ValueString())
+		if uErr != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("uuid"), "Invalid Identity",
+				fmt.Sprintf("The uuid %q of the identity is not a UUID: %s", identity.UUID.ValueString(), uErr))
+			return
+		}
+
+		id, err := machineIDByUUID(ctx, r.client, machineUUID)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import: %s", err))
+			return
+		}
+
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 		return
 	}
 
-	id, err := strconv.ParseInt(match[1], 10, 64)
+	id, err := machineImportID(ctx, r.client, req.ID)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Invalid ID: %s", err))
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import: %s", err))
 		return
 	}
 
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"terraform-provider-meltcloud/internal/client"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+)
+
+func TestMachineResourceIdentity(t *testing.T) {
+	api := newTestAPI(t)
+
+	config := testProviderConfig(api) + `
+resource "meltcloud_machine" "test" {
+  uuid            = "8e1bd1a6-5d52-4e1c-9b4a-3f7c2b6ad0f1"
+  name            = "worker01"
+  machine_pool_id = 5
+
+  label {
+    key   = "rack"
+    value = "a1"
+  }
+}
+`
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity("meltcloud_machine.test", map[string]knownvalue.Check{
+						"uuid": knownvalue.StringExact("8e1bd1a6-5d52-4e1c-9b4a-3f7c2b6ad0f1"),
+					}),
+				},
+			},
+			{
+				Config:          config,
+				ResourceName:    "meltcloud_machine.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
+func TestMachineResourceImportStateIdentity(t *testing.T) {
+	api := newTestAPI(t)
+	machineUUID := uuid.MustParse("8e1bd1a6-5d52-4e1c-9b4a-3f7c2b6ad0f1")
+	id := api.addMachine(machineUUID, "machines/5")
+
+	r := &MachineResource{client: client.New(api.URL, testOrganization, "dummy", nil)}
+
+	tests := []struct {
+		name      string
+		uuid      string
+		wantID    int64
+		wantError string
+	}{
+		{name: "UUID", uuid: machineUUID.String(), wantID: id},
+		{name: "UUID in upper case", uuid: strings.ToUpper(machineUUID.String()), wantID: id},
+		{name: "unknown UUID", uuid: "00000000-0000-0000-0000-000000000001", wantError: "Unable to import"},
+		{name: "path", uuid: "machines/5", wantError: "Invalid Identity"},
+		{name: "name", uuid: "name=machines/5", wantError: "Invalid Identity"},
+		{name: "console URL", uuid: "https://app.meltcloud.io/ui/orgs/" + testOrganization + "/machines/5", wantError: "Invalid Identity"},
+	}
+
+	ctx := context.Background()
+
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	identitySchemaResp := &fwresource.IdentitySchemaResponse{}
+	r.IdentitySchema(ctx, fwresource.IdentitySchemaRequest{}, identitySchemaResp)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identityType := identitySchemaResp.IdentitySchema.Type().TerraformType(ctx)
+			req := fwresource.ImportStateRequest{
+				Identity: &tfsdk.ResourceIdentity{
+					Schema: identitySchemaResp.IdentitySchema,
+					Raw:    tftypes.NewValue(identityType, map[string]tftypes.Value{"uuid": tftypes.NewValue(tftypes.String, tt.uuid)}),
+				},
+			}
+			resp := &fwresource.ImportStateResponse{
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+			}
+
+			r.ImportState(ctx, req, resp)
+
+			if tt.wantError != "" {
+				if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics.Errors()[0].Summary()+resp.Diagnostics.Errors()[0].Detail(), tt.wantError) {
+					t.Fatalf("ImportState(%q) diagnostics = %v, want error %q", tt.uuid, resp.Diagnostics, tt.wantError)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("ImportState(%q) unexpected error: %v", tt.uuid, resp.Diagnostics)
+			}
+
+			var gotID int64
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("id"), &gotID)...)
+			if gotID != tt.wantID {
+				t.Errorf("ImportState(%q) id = %d, want %d", tt.uuid, gotID, tt.wantID)
+			}
+		})
+	}
+}
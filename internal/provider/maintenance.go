@@ -0,0 +1,242 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"terraform-provider-meltcloud/internal/client"
+	"time"
+
+	// embed the time zone database, so that timezone is validated the same way on all platforms
+	_ "time/tzdata"
+
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var maintenanceWindowDays = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+
+var maintenanceWindowStartTimePattern = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+
+type MaintenanceWindowModel struct {
+	Day           types.String `tfsdk:"day"`
+	StartTime     types.String `tfsdk:"start_time"`
+	DurationHours types.Int64  `tfsdk:"duration_hours"`
+	Timezone      types.String `tfsdk:"timezone"`
+}
+
+type AutoUpgradeModel struct {
+	Patch types.Bool `tfsdk:"patch"`
+	Minor types.Bool `tfsdk:"minor"`
+}
+
+func maintenanceWindowBlock(subject string) schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: fmt.Sprintf("Weekly window in which meltcloud rolls out automatic upgrades of the %s. "+
+			"If not specified, meltcloud may roll out patches at any time.", subject),
+		Validators: []validator.Object{
+			// block attributes cannot be required, Terraform would require them also if the block is not specified
+			objectvalidator.AlsoRequires(path.MatchRelative().AtName("day"), path.MatchRelative().AtName("start_time")),
+		},
+		Attributes: map[string]schema.Attribute{
+			"day": schema.StringAttribute{
+				MarkdownDescription: "Day of the week the window starts, e.g. `saturday`. Required.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(maintenanceWindowDays...),
+				},
+			},
+			"start_time": schema.StringAttribute{
+				MarkdownDescription: "Start of the window in the form `HH:MM`, e.g. `02:00`. Required.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(maintenanceWindowStartTimePattern, "must be a time in the form HH:MM, e.g. 02:00"),
+				},
+			},
+			"duration_hours": schema.Int64Attribute{
+				MarkdownDescription: "Length of the window in hours, defaults to `4`",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(4),
+				Validators: []validator.Int64{
+					int64validator.Between(1, 24),
+				},
+			},
+			"timezone": schema.StringAttribute{
+				MarkdownDescription: "IANA time zone of `start_time`, e.g. `Europe/Zurich`, defaults to `UTC`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("UTC"),
+				Validators: []validator.String{
+					timezoneValidator{},
+				},
+			},
+		},
+	}
+}
+
+func autoUpgradeBlock(subject string) schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: fmt.Sprintf("Upgrades meltcloud rolls out automatically to the %s in the maintenance window.\n\n"+
+			"~> With `minor = true`, meltcloud changes the version. Add `lifecycle { ignore_changes = [version] }` so that Terraform does not change it back.", subject),
+		Attributes: map[string]schema.Attribute{
+			"patch": schema.BoolAttribute{
+				MarkdownDescription: "Roll out new patch versions of the Kubernetes minor version, defaults to `true`",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"minor": schema.BoolAttribute{
+				MarkdownDescription: "Upgrade to the next Kubernetes minor version before the current one reaches its end of support, defaults to `false`",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func nextMaintenanceAtAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: "Start of the next maintenance window in RFC3339 format, null if no automatic upgrades are scheduled",
+		CustomType:          timetypes.RFC3339Type{},
+		Computed:            true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+	}
+}
+
+// maintenanceWindowInput returns the maintenance window to create a resource with, nil if the block is not specified.
+func maintenanceWindowInput(model *MaintenanceWindowModel) *client.MaintenanceWindow {
+	if model == nil {
+		return nil
+	}
+
+	return &client.MaintenanceWindow{
+		Day:           model.Day.ValueString(),
+		StartTime:     model.StartTime.ValueString(),
+		DurationHours: model.DurationHours.ValueInt64(),
+		Timezone:      model.Timezone.ValueString(),
+	}
+}
+
+// autoUpgradeInput returns the automatic upgrades to create a resource with, nil if the block is not specified.
+func autoUpgradeInput(model *AutoUpgradeModel) *client.AutoUpgrade {
+	if model == nil {
+		return nil
+	}
+
+	return &client.AutoUpgrade{
+		Patch: model.Patch.ValueBoolPointer(),
+		Minor: model.Minor.ValueBoolPointer(),
+	}
+}
+
+// maintenanceWindowUpdateInput returns the maintenance window to update a resource with. If the block is not specified,
+// the empty value removes the maintenance window.
+func maintenanceWindowUpdateInput(model *MaintenanceWindowModel) *client.MaintenanceWindow {
+	if model == nil {
+		return &client.MaintenanceWindow{}
+	}
+
+	return maintenanceWindowInput(model)
+}
+
+// autoUpgradeUpdateInput returns the automatic upgrades to update a resource with. If the block is not specified, the
+// empty value removes the automatic upgrades.
+func autoUpgradeUpdateInput(model *AutoUpgradeModel) *client.AutoUpgrade {
+	if model == nil {
+		return &client.AutoUpgrade{}
+	}
+
+	return autoUpgradeInput(model)
+}
+
+func maintenanceWindowModel(window *client.MaintenanceWindow) *MaintenanceWindowModel {
+	if window == nil {
+		return nil
+	}
+
+	return &MaintenanceWindowModel{
+		Day:           types.StringValue(window.Day),
+		StartTime:     types.StringValue(window.StartTime),
+		DurationHours: types.Int64Value(window.DurationHours),
+		Timezone:      types.StringValue(window.Timezone),
+	}
+}
+
+func autoUpgradeModel(autoUpgrade *client.AutoUpgrade) *AutoUpgradeModel {
+	if autoUpgrade == nil {
+		return nil
+	}
+
+	return &AutoUpgradeModel{
+		Patch: types.BoolValue(autoUpgrade.Patch != nil && *autoUpgrade.Patch),
+		Minor: types.BoolValue(autoUpgrade.Minor != nil && *autoUpgrade.Minor),
+	}
+}
+
+func nextMaintenanceAtValue(nextMaintenanceAt *time.Time) timetypes.RFC3339 {
+	if nextMaintenanceAt == nil {
+		return timetypes.NewRFC3339Null()
+	}
+
+	return timetypes.NewRFC3339TimeValue(nextMaintenanceAt.UTC())
+}
+
+// planNextMaintenanceAt marks next_maintenance_at as unknown if the maintenance window or the automatic upgrades change.
+func planNextMaintenanceAt(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	for _, name := range []string{"maintenance_window", "auto_upgrade"} {
+		var planned, prior types.Object
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(name), &planned)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(name), &prior)...)
+
+		if !planned.Equal(prior) {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("next_maintenance_at"), timetypes.NewRFC3339Unknown())...)
+			return
+		}
+	}
+}
+
+var _ validator.String = timezoneValidator{}
+
+// timezoneValidator validates that a string is an IANA time zone like Europe/Zurich.
+type timezoneValidator struct{}
+
+func (v timezoneValidator) Description(ctx context.Context) string {
+	return "value must be an IANA time zone like Europe/Zurich"
+}
+
+func (v timezoneValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v timezoneValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+
+	if _, err := time.LoadLocation(value); err != nil || value == "" || value == "Local" {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Time Zone",
+			fmt.Sprintf("%q is not an IANA time zone like Europe/Zurich.", value))
+	}
+}
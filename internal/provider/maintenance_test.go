@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-timetypes/timetypes"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestTimezoneValidator(t *testing.T) {
+	runStringValidatorTests(t, timezoneValidator{}, []stringValidatorTest{
+		{name: "null", value: types.StringNull()},
+		{name: "unknown", value: types.StringUnknown()},
+		{name: "UTC", value: types.StringValue("UTC")},
+		{name: "region", value: types.StringValue("Europe/Zurich")},
+		{name: "empty", value: types.StringValue(""), wantError: "Invalid Time Zone"},
+		{name: "local", value: types.StringValue("Local"), wantError: "Invalid Time Zone"},
+		{name: "unknown zone", value: types.StringValue("Mars/Olympus_Mons"), wantError: "Invalid Time Zone"},
+		{name: "offset", value: types.StringValue("+02:00"), wantError: "Invalid Time Zone"},
+	})
+}
+
+func TestMaintenanceInput(t *testing.T) {
+	window := &MaintenanceWindowModel{
+		Day:           types.StringValue("saturday"),
+		StartTime:     types.StringValue("02:00"),
+		DurationHours: types.Int64Value(4),
+		Timezone:      types.StringValue("Europe/Zurich"),
+	}
+	disabled := &AutoUpgradeModel{Patch: types.BoolValue(false), Minor: types.BoolValue(false)}
+
+	tests := []struct {
+		name  string
+		input interface{}
+		want  string
+	}{
+		// nil leaves the settings unchanged, or creates the resource without them
+		{name: "create without maintenance window", input: maintenanceWindowInput(nil), want: `null`},
+		{name: "create without auto upgrade", input: autoUpgradeInput(nil), want: `null`},
+		// the empty value removes the settings
+		{name: "update without maintenance window", input: maintenanceWindowUpdateInput(nil), want: `{}`},
+		{name: "update without auto upgrade", input: autoUpgradeUpdateInput(nil), want: `{}`},
+		{
+			name:  "maintenance window",
+			input: maintenanceWindowUpdateInput(window),
+			want:  `{"day":"saturday","start_time":"02:00","duration_hours":4,"timezone":"Europe/Zurich"}`,
+		},
+		// disabled automatic upgrades are sent, unlike the empty value
+		{name: "auto upgrade disabled", input: autoUpgradeUpdateInput(disabled), want: `{"patch":false,"minor":false}`},
+		{name: "create with auto upgrade disabled", input: autoUpgradeInput(disabled), want: `{"patch":false,"minor":false}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.input)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("input = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanNextMaintenanceAt(t *testing.T) {
+	ctx := context.Background()
+
+	s := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"next_maintenance_at": nextMaintenanceAtAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"maintenance_window": maintenanceWindowBlock("pool"),
+			"auto_upgrade":       autoUpgradeBlock("pool"),
+		},
+	}
+
+	typ := s.Type().TerraformType(ctx).(tftypes.Object)
+	windowType := typ.AttributeTypes["maintenance_window"].(tftypes.Object)
+	autoUpgradeType := typ.AttributeTypes["auto_upgrade"].(tftypes.Object)
+
+	window := func(day string) tftypes.Value {
+		if day == "" {
+			return tftypes.NewValue(windowType, nil)
+		}
+
+		return tftypes.NewValue(windowType, map[string]tftypes.Value{
+			"day":            tftypes.NewValue(tftypes.String, day),
+			"start_time":     tftypes.NewValue(tftypes.String, "02:00"),
+			"duration_hours": tftypes.NewValue(tftypes.Number, 4),
+			"timezone":       tftypes.NewValue(tftypes.String, "UTC"),
+		})
+	}
+	autoUpgrade := func(minor bool) tftypes.Value {
+		return tftypes.NewValue(autoUpgradeType, map[string]tftypes.Value{
+			"patch": tftypes.NewValue(tftypes.Bool, true),
+			"minor": tftypes.NewValue(tftypes.Bool, minor),
+		})
+	}
+	value := func(window tftypes.Value, autoUpgrade tftypes.Value) tftypes.Value {
+		return tftypes.NewValue(typ, map[string]tftypes.Value{
+			"next_maintenance_at": tftypes.NewValue(tftypes.String, "2026-10-24T00:00:00Z"),
+			"maintenance_window":  window,
+			"auto_upgrade":        autoUpgrade,
+		})
+	}
+
+	tests := []struct {
+		name        string
+		state       tftypes.Value
+		plan        tftypes.Value
+		wantUnknown bool
+	}{
+		{name: "create", state: tftypes.NewValue(typ, nil), plan: value(window("saturday"), autoUpgrade(false))},
+		{name: "unchanged", state: value(window("saturday"), autoUpgrade(false)), plan: value(window("saturday"), autoUpgrade(false))},
+		{name: "maintenance window changed", state: value(window("saturday"), autoUpgrade(false)), plan: value(window("sunday"), autoUpgrade(false)), wantUnknown: true},
+		{name: "maintenance window added", state: value(window(""), autoUpgrade(false)), plan: value(window("sunday"), autoUpgrade(false)), wantUnknown: true},
+		{name: "maintenance window removed", state: value(window("saturday"), autoUpgrade(false)), plan: value(window(""), autoUpgrade(false)), wantUnknown: true},
+		{name: "auto upgrade changed", state: value(window("saturday"), autoUpgrade(false)), plan: value(window("saturday"), autoUpgrade(true)), wantUnknown: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := resource.ModifyPlanRequest{
+				State: tfsdk.State{Schema: s, Raw: tt.state},
+				Plan:  tfsdk.Plan{Schema: s, Raw: tt.plan},
+			}
+			resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+
+			planNextMaintenanceAt(ctx, req, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("planNextMaintenanceAt() diagnostics = %v", resp.Diagnostics)
+			}
+
+			var nextMaintenanceAt timetypes.RFC3339
+			resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("next_maintenance_at"), &nextMaintenanceAt)...)
+			if nextMaintenanceAt.IsUnknown() != tt.wantUnknown {
+				t.Errorf("planNextMaintenanceAt() next_maintenance_at = %s, want unknown %t", nextMaintenanceAt, tt.wantUnknown)
+			}
+		})
+	}
+}
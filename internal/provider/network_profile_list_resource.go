@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-meltcloud/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ list.ListResource = &NetworkProfileListResource{}
+var _ list.ListResourceWithConfigure = &NetworkProfileListResource{}
+
+func NewNetworkProfileListResource() list.ListResource {
+	return &NetworkProfileListResource{}
+}
+
+// NetworkProfileListResource defines the list resource implementation.
+type NetworkProfileListResource struct {
+	client *client.Client
+}
+
+func (r *NetworkProfileListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_network_profile"
+}
+
+func (r *NetworkProfileListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: networkProfileDesc,
+	}
+}
+
+func (r *NetworkProfileListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *NetworkProfileListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var diags diag.Diagnostics
+
+	result, err := r.client.NetworkProfile().List(ctx)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to list network profiles, got error: %s", err))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for _, networkProfile := range result.NetworkProfiles {
+			if !push(r.listResult(ctx, req, networkProfile)) {
+				return
+			}
+		}
+	}
+}
+
+func (r *NetworkProfileListResource) listResult(ctx context.Context, req list.ListRequest, networkProfile *client.NetworkProfile) list.ListResult {
+	result := req.NewListResult(ctx)
+	result.DisplayName = networkProfile.Name
+
+	result.Diagnostics.Append(result.Identity.Set(ctx, NetworkProfileResourceIdentityModel{NetworkProfileID: types.Int64Value(networkProfile.ID)})...)
+
+	if req.IncludeResource {
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("id"), networkProfile.ID)...)
+		result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("name"), networkProfile.Name)...)
+
+		var links []LinkResourceModel
+		for _, link := range networkProfile.Links {
+			interfacesList, diags := types.ListValueFrom(ctx, types.StringType, link.Interfaces)
+			result.Diagnostics.Append(diags...)
+
+			vlansList, diags := types.ListValueFrom(ctx, types.Int64Type, link.VLANs)
+			result.Diagnostics.Append(diags...)
+
+			links = append(links, LinkResourceModel{
+				Name:           types.StringValue(link.Name),
+				Interfaces:     interfacesList,
+				VLANs:          vlansList,
+				HostNetworking: types.BoolValue(link.HostNetworking),
+				LACP:           types.BoolValue(link.LACP),
+				NativeVLAN:     types.BoolValue(link.NativeVLAN),
+			})
+		}
+		if !result.Diagnostics.HasError() {
+			result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root("link"), links)...)
+		}
+	}
+
+	return result
+}
@@ -3,14 +3,13 @@ package provider
 import (
 	"context"
 	"fmt"
-	"regexp"
-	"strconv"
 	"terraform-provider-meltcloud/internal/client"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -19,6 +18,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &NetworkProfileResource{}
 var _ resource.ResourceWithImportState = &NetworkProfileResource{}
+var _ resource.ResourceWithIdentity = &NetworkProfileResource{}
 
 func NewNetworkProfileResource() resource.Resource {
 	return &NetworkProfileResource{}
@@ -36,6 +36,11 @@ type NetworkProfileResourceModel struct {
 	Links types.List   `tfsdk:"link"`
 }
 
+// NetworkProfileResourceIdentityModel describes the resource identity data model.
+type NetworkProfileResourceIdentityModel struct {
+	NetworkProfileID types.Int64 `tfsdk:"network_profile_id"`
+}
+
 type LinkResourceModel struct {
 	Name           types.String `tfsdk:"name"`
 	Interfaces     types.List   `tfsdk:"interfaces"`
@@ -114,6 +119,17 @@ func (r *NetworkProfileResource) Schema(ctx context.Context, req resource.Schema
 	}
 }
 
+func (r *NetworkProfileResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"network_profile_id": identityschema.Int64Attribute{
+				Description:       "Internal ID of the Network Profile in meltcloud",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
 func (r *NetworkProfileResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -163,6 +179,7 @@ func (r *NetworkProfileResource) Create(ctx context.Context, req resource.Create
 	data.ID = types.Int64Value(result.NetworkProfile.ID)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, NetworkProfileResourceIdentityModel{NetworkProfileID: data.ID})...)
 }
 
 func (r *NetworkProfileResource) linksInput(ctx context.Context, links []LinkResourceModel) []client.Link {
@@ -207,6 +224,7 @@ func (r *NetworkProfileResource) Read(ctx context.Context, req resource.ReadRequ
 
 	data.Name = types.StringValue(result.NetworkProfile.Name)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, NetworkProfileResourceIdentityModel{NetworkProfileID: data.ID})...)
 
 	var links []LinkResourceModel
 	for _, link := range result.NetworkProfile.Links {
@@ -279,6 +297,7 @@ func (r *NetworkProfileResource) Update(ctx context.Context, req resource.Update
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, NetworkProfileResourceIdentityModel{NetworkProfileID: data.ID})...)
 }
 
 func (r *NetworkProfileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -296,22 +315,26 @@ func (r *NetworkProfileResource) Delete(ctx context.Context, req resource.Delete
 	}
 }
 
-var networkProfileImportIDPattern = regexp.MustCompile(`network_profiles/(\d+)`)
-
 func (r *NetworkProfileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	match := networkProfileImportIDPattern.FindStringSubmatch(req.ID)
-	if len(match) != 2 {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("ID does not follow format: %s", networkProfileImportIDPattern.String()))
+	if req.ID == "" {
+		var identity NetworkProfileResourceIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), identity.NetworkProfileID)...)
 		return
 	}
 
-	id, err := strconv.ParseInt(match[1], 10, 64)
+	id, err := networkProfileImportID(ctx, r.client, req.ID)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Invalid ID: %s", err))
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import: %s", err))
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, NetworkProfileResourceIdentityModel{NetworkProfileID: types.Int64Value(id)})...)
 
 	if resp.Diagnostics.HasError() {
 		return
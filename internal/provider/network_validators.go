@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// dns1123SubdomainPattern matches DNS-1123 subdomains like example.com, see
+// https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#dns-subdomain-names.
+var dns1123SubdomainPattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+
+const dns1123SubdomainMaxLength = 253
+
+var _ validator.String = cidrValidator{}
+
+// cidrValidator validates that a string is a CIDR in canonical form (host bits cleared) with at most the given
+// prefix lengths, i.e. that the range is at least as large as required.
+type cidrValidator struct {
+	maxPrefixLength4 int
+	maxPrefixLength6 int
+}
+
+func (v cidrValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be a CIDR like 10.96.0.0/16 of at most /%d for IPv4 or /%d for IPv6", v.maxPrefixLength4, v.maxPrefixLength6)
+}
+
+func (v cidrValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v cidrValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+
+	prefix, err := netip.ParsePrefix(value)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid CIDR",
+			fmt.Sprintf("%q is not a valid CIDR like 10.96.0.0/16: %s", value, err))
+		return
+	}
+
+	if prefix != prefix.Masked() {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid CIDR",
+			fmt.Sprintf("%q has host bits set, use the network address %s instead.", value, prefix.Masked()))
+		return
+	}
+
+	maxPrefixLength := v.maxPrefixLength6
+	if prefix.Addr().Is4() {
+		maxPrefixLength = v.maxPrefixLength4
+	}
+
+	if prefix.Bits() > maxPrefixLength {
+		resp.Diagnostics.AddAttributeError(req.Path, "CIDR Too Small",
+			fmt.Sprintf("%q is too small, the prefix length must be at most /%d.", value, maxPrefixLength))
+	}
+}
+
+var _ validator.String = ipAddressValidator{}
+
+// ipAddressValidator validates that a string is an IPv4 or IPv6 address.
+type ipAddressValidator struct{}
+
+func (v ipAddressValidator) Description(ctx context.Context) string {
+	return "value must be an IP address like 10.96.0.10"
+}
+
+func (v ipAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v ipAddressValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+
+	if _, err := netip.ParseAddr(value); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid IP Address",
+			fmt.Sprintf("%q is not a valid IP address like 10.96.0.10: %s", value, err))
+	}
+}
+
+var _ validator.String = subjectAlternativeNameValidator{}
+
+// subjectAlternativeNameValidator validates that a string is a DNS name or an IP address usable as a certificate SAN.
+type subjectAlternativeNameValidator struct{}
+
+func (v subjectAlternativeNameValidator) Description(ctx context.Context) string {
+	return "value must be a DNS name like k8s.example.com or an IP address"
+}
+
+func (v subjectAlternativeNameValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v subjectAlternativeNameValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+
+	if _, err := netip.ParseAddr(value); err == nil {
+		return
+	}
+
+	if len(value) > dns1123SubdomainMaxLength || !dns1123SubdomainPattern.MatchString(value) {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Subject Alternative Name",
+			fmt.Sprintf("%q is neither a lowercase DNS name like k8s.example.com nor an IP address.", value))
+	}
+}
@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+type stringValidatorTest struct {
+	name      string
+	value     types.String
+	wantError string
+}
+
+func runStringValidatorTests(t *testing.T, v validator.String, tests []stringValidatorTest) {
+	t.Helper()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validator.StringRequest{Path: path.Root("test"), ConfigValue: tt.value}
+			resp := &validator.StringResponse{}
+
+			v.ValidateString(context.Background(), req, resp)
+
+			if tt.wantError == "" {
+				if resp.Diagnostics.HasError() {
+					t.Errorf("ValidateString(%s) unexpected diagnostics: %v", tt.value, resp.Diagnostics)
+				}
+				return
+			}
+
+			if resp.Diagnostics.ErrorsCount() != 1 || !strings.Contains(resp.Diagnostics.Errors()[0].Summary(), tt.wantError) {
+				t.Errorf("ValidateString(%s) diagnostics = %v, want error %q", tt.value, resp.Diagnostics, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestCIDRValidator(t *testing.T) {
+	runStringValidatorTests(t, cidrValidator{maxPrefixLength4: 28, maxPrefixLength6: 124}, []stringValidatorTest{
+		{name: "null", value: types.StringNull()},
+		{name: "unknown", value: types.StringUnknown()},
+		{name: "IPv4", value: types.StringValue("10.96.0.0/16")},
+		{name: "IPv4 at max prefix length", value: types.StringValue("10.96.0.0/28")},
+		{name: "IPv6", value: types.StringValue("fd00:10:96::/112")},
+		{name: "IPv6 at max prefix length", value: types.StringValue("fd00:10:96::/124")},
+		{name: "empty", value: types.StringValue(""), wantError: "Invalid CIDR"},
+		{name: "address without prefix", value: types.StringValue("10.96.0.0"), wantError: "Invalid CIDR"},
+		{name: "host bits set", value: types.StringValue("10.96.0.1/16"), wantError: "Invalid CIDR"},
+		{name: "IPv6 host bits set", value: types.StringValue("fd00:10:96::1/112"), wantError: "Invalid CIDR"},
+		{name: "IPv4 too small", value: types.StringValue("10.96.0.0/29"), wantError: "CIDR Too Small"},
+		{name: "IPv6 too small", value: types.StringValue("fd00:10:96::/125"), wantError: "CIDR Too Small"},
+	})
+}
+
+func TestIPAddressValidator(t *testing.T) {
+	runStringValidatorTests(t, ipAddressValidator{}, []stringValidatorTest{
+		{name: "null", value: types.StringNull()},
+		{name: "unknown", value: types.StringUnknown()},
+		{name: "IPv4", value: types.StringValue("10.96.0.10")},
+		{name: "IPv6", value: types.StringValue("fd00:10:96::a")},
+		{name: "empty", value: types.StringValue(""), wantError: "Invalid IP Address"},
+		{name: "CIDR", value: types.StringValue("10.96.0.10/32"), wantError: "Invalid IP Address"},
+		{name: "DNS name", value: types.StringValue("dns.example.com"), wantError: "Invalid IP Address"},
+	})
+}
+
+// clusterConfig returns a meltcloud_cluster configuration with the given attribute values, all other attributes are null.
+func clusterConfig(t *testing.T, values map[string]interface{}) tfsdk.Config {
+	t.Helper()
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	(&ClusterResource{}).Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	objectType, ok := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		t.Fatalf("schema type is not an object")
+	}
+
+	attributes := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, values[name])
+	}
+
+	return tfsdk.Config{
+		Raw:    tftypes.NewValue(objectType, attributes),
+		Schema: schemaResp.Schema,
+	}
+}
+
+func TestClusterResourceValidateConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		values     map[string]interface{}
+		wantErrors []string
+	}{
+		{name: "defaults", values: map[string]interface{}{"name": "melt01"}},
+		{name: "separate CIDRs", values: map[string]interface{}{"pod_cidr": "10.36.0.0/16", "service_cidr": "10.96.0.0/16", "dns_service_ip": "10.96.0.10"}},
+		{name: "IPv6", values: map[string]interface{}{"pod_cidr": "fd00:10:36::/56", "service_cidr": "fd00:10:96::/112", "dns_service_ip": "fd00:10:96::a"}},
+		{name: "pod CIDR containing the service CIDR", values: map[string]interface{}{"pod_cidr": "10.0.0.0/8", "service_cidr": "10.96.0.0/16"}, wantErrors: []string{"Overlapping CIDRs"}},
+		{name: "identical CIDRs", values: map[string]interface{}{"pod_cidr": "10.96.0.0/16", "service_cidr": "10.96.0.0/16"}, wantErrors: []string{"Overlapping CIDRs"}},
+		{name: "adjacent CIDRs", values: map[string]interface{}{"pod_cidr": "10.96.0.0/16", "service_cidr": "10.97.0.0/16"}},
+		{name: "pod CIDR unknown", values: map[string]interface{}{"pod_cidr": tftypes.UnknownValue, "service_cidr": "10.96.0.0/16", "dns_service_ip": "10.96.0.10"}},
+		{name: "DNS service IP outside the service CIDR", values: map[string]interface{}{"service_cidr": "10.96.0.0/16", "dns_service_ip": "10.97.0.10"}, wantErrors: []string{"Invalid DNS Service IP"}},
+		{name: "IPv6 DNS service IP in an IPv4 service CIDR", values: map[string]interface{}{"service_cidr": "10.96.0.0/16", "dns_service_ip": "fd00:10:96::a"}, wantErrors: []string{"Invalid DNS Service IP"}},
+		{name: "DNS service IP is the network address", values: map[string]interface{}{"service_cidr": "10.96.0.0/16", "dns_service_ip": "10.96.0.0"}, wantErrors: []string{"Invalid DNS Service IP"}},
+		{name: "DNS service IP is the last address", values: map[string]interface{}{"service_cidr": "10.96.0.0/28", "dns_service_ip": "10.96.0.15"}},
+		{name: "DNS service IP without service CIDR", values: map[string]interface{}{"dns_service_ip": "10.97.0.10"}},
+		{name: "DNS service IP unknown", values: map[string]interface{}{"service_cidr": "10.96.0.0/16", "dns_service_ip": tftypes.UnknownValue}},
+		{name: "invalid values are left to the attribute validators", values: map[string]interface{}{"pod_cidr": "10.36.0.0", "service_cidr": "10.96.0.1/16", "dns_service_ip": "dns"}},
+		{name: "overlap and DNS service IP outside", values: map[string]interface{}{"pod_cidr": "10.96.0.0/12", "service_cidr": "10.96.0.0/16", "dns_service_ip": "10.97.0.10"},
+			wantErrors: []string{"Overlapping CIDRs", "Invalid DNS Service IP"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := resource.ValidateConfigRequest{Config: clusterConfig(t, tt.values)}
+			resp := &resource.ValidateConfigResponse{}
+
+			(&ClusterResource{}).ValidateConfig(context.Background(), req, resp)
+
+			errors := resp.Diagnostics.Errors()
+			if len(errors) != len(tt.wantErrors) {
+				t.Fatalf("ValidateConfig() diagnostics = %v, want errors %v", resp.Diagnostics, tt.wantErrors)
+			}
+			for i, err := range errors {
+				if err.Summary() != tt.wantErrors[i] {
+					t.Errorf("ValidateConfig() error %d = %q, want %q", i, err.Summary(), tt.wantErrors[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSubjectAlternativeNameValidator(t *testing.T) {
+	runStringValidatorTests(t, subjectAlternativeNameValidator{}, []stringValidatorTest{
+		{name: "null", value: types.StringNull()},
+		{name: "unknown", value: types.StringUnknown()},
+		{name: "IPv4", value: types.StringValue("192.0.2.10")},
+		{name: "IPv6", value: types.StringValue("2001:db8::10")},
+		{name: "DNS name", value: types.StringValue("k8s.example.com")},
+		{name: "single label", value: types.StringValue("kubernetes")},
+		{name: "empty", value: types.StringValue(""), wantError: "Invalid Subject Alternative Name"},
+		{name: "uppercase", value: types.StringValue("K8s.example.com"), wantError: "Invalid Subject Alternative Name"},
+		{name: "wildcard", value: types.StringValue("*.example.com"), wantError: "Invalid Subject Alternative Name"},
+		{name: "trailing dot", value: types.StringValue("k8s.example.com."), wantError: "Invalid Subject Alternative Name"},
+		{name: "leading hyphen", value: types.StringValue("-k8s.example.com"), wantError: "Invalid Subject Alternative Name"},
+		{name: "URL", value: types.StringValue("https://k8s.example.com"), wantError: "Invalid Subject Alternative Name"},
+		{name: "too long", value: types.StringValue(strings.Repeat("a.", 127) + "a"), wantError: "Invalid Subject Alternative Name"},
+	})
+}
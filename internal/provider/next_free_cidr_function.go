@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"context"
+	"terraform-provider-meltcloud/internal/kubernetes"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &NextFreeCIDRFunction{}
+
+func NewNextFreeCIDRFunction() function.Function {
+	return &NextFreeCIDRFunction{}
+}
+
+// NextFreeCIDRFunction defines the function implementation.
+type NextFreeCIDRFunction struct{}
+
+func (f *NextFreeCIDRFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "next_free_cidr"
+}
+
+func (f *NextFreeCIDRFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Allocate a free CIDR from a pool",
+		MarkdownDescription: "Returns the first CIDR with the given prefix length in `pool` that overlaps none of `used_cidrs`, " +
+			"e.g. to allocate non-overlapping `pod_cidr` and `service_cidr` ranges for many clusters from a supernet. " +
+			"Fails if the pool is exhausted.",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:        "used_cidrs",
+				Description: "CIDRs already in use, they may lie partially or entirely outside the pool",
+				ElementType: types.StringType,
+			},
+			function.StringParameter{
+				Name:        "pool",
+				Description: "CIDR to allocate from, e.g. 10.0.0.0/8",
+			},
+			function.Int64Parameter{
+				Name:        "prefix",
+				Description: "Prefix length of the CIDR to allocate, e.g. 16",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *NextFreeCIDRFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var usedCIDRs []string
+	var pool string
+	var prefix int64
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &usedCIDRs, &pool, &prefix))
+	if resp.Error != nil {
+		return
+	}
+
+	used, err := parseCIDRs(usedCIDRs)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	poolPrefix, err := kubernetes.ParseCIDR(pool)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	if prefix < int64(poolPrefix.Bits()) || prefix > int64(poolPrefix.Addr().BitLen()) {
+		resp.Error = function.NewArgumentFuncError(2, "prefix must be between the prefix length of the pool and the address length")
+		return
+	}
+
+	cidr, err := kubernetes.NextFreeCIDR(used, poolPrefix, int(prefix))
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, cidr.String()))
+}
@@ -0,0 +1,226 @@
+package provider
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/url"
+	"terraform-provider-meltcloud/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type ClusterOIDCModel struct {
+	IssuerURL      types.String `tfsdk:"issuer_url"`
+	ClientID       types.String `tfsdk:"client_id"`
+	UsernameClaim  types.String `tfsdk:"username_claim"`
+	UsernamePrefix types.String `tfsdk:"username_prefix"`
+	GroupsClaim    types.String `tfsdk:"groups_claim"`
+	GroupsPrefix   types.String `tfsdk:"groups_prefix"`
+	RequiredClaims types.Map    `tfsdk:"required_claims"`
+	CA             types.String `tfsdk:"ca"`
+}
+
+func oidcBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "OpenID Connect identity provider the API server authenticates users with, e.g. Entra ID, Okta or Dex. " +
+			"`kubeconfig_user` logs in with this provider. If not specified, users log in with their meltcloud account. Can be changed on an existing cluster.",
+		Validators: []validator.Object{
+			// block attributes cannot be required, Terraform would require them also if the block is not specified
+			objectvalidator.AlsoRequires(path.MatchRelative().AtName("issuer_url"), path.MatchRelative().AtName("client_id")),
+		},
+		Attributes: map[string]schema.Attribute{
+			"issuer_url": schema.StringAttribute{
+				MarkdownDescription: "HTTPS URL of the issuer, must match the `iss` claim of the ID tokens, e.g. `https://login.microsoftonline.com/<tenant>/v2.0`. Required.",
+				Optional:            true,
+				Validators: []validator.String{
+					httpsURLValidator{},
+				},
+			},
+			"client_id": schema.StringAttribute{
+				MarkdownDescription: "Client ID the ID tokens must be issued for (`aud` claim). Required.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"username_claim": schema.StringAttribute{
+				MarkdownDescription: "Claim used as the Kubernetes username, e.g. `email`, defaults to `sub`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("sub"),
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"username_prefix": schema.StringAttribute{
+				MarkdownDescription: "Prefix added to usernames to avoid clashes with other users, e.g. `oidc:`. " +
+					"If not specified, usernames other than `email` are prefixed with the issuer URL, `-` disables the prefix.",
+				Optional: true,
+			},
+			"groups_claim": schema.StringAttribute{
+				MarkdownDescription: "Claim with the groups of the user, e.g. `groups`. If not specified, users have no groups from the identity provider.",
+				Optional:            true,
+			},
+			"groups_prefix": schema.StringAttribute{
+				MarkdownDescription: "Prefix added to groups to avoid clashes with other groups, e.g. `oidc:`",
+				Optional:            true,
+			},
+			"required_claims": schema.MapAttribute{
+				MarkdownDescription: "Claims that must be present in the ID token with the given value, e.g. `{ hd = \"example.com\" }`",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				Default:             mapdefault.StaticValue(types.MapValueMust(types.StringType, map[string]attr.Value{})),
+			},
+			"ca": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded CA certificate of the issuer, e.g. `file(\"oidc-ca.pem\")`. If not specified, the issuer certificate must be publicly trusted.",
+				Optional:            true,
+				Validators: []validator.String{
+					pemCertificateValidator{},
+				},
+			},
+		},
+	}
+}
+
+func serviceAccountIssuerAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: "Issuer of the service account tokens (`iss` claim), e.g. `https://oidc.example.com/melt01`, for workload identity federation with AWS IAM, " +
+			"Entra ID or GCP. Publish the OpenID discovery document and the JWKS of the cluster at this URL, the cloud providers fetch them from there. " +
+			"If not specified, the tokens are issued by meltcloud. Pods receive tokens with a new issuer when their tokens are refreshed, " +
+			"update the trust configuration of the cloud providers before changing it.",
+		Optional: true,
+		Validators: []validator.String{
+			httpsURLValidator{},
+		},
+	}
+}
+
+func oidcInput(ctx context.Context, model *ClusterOIDCModel) (*client.ClusterOIDC, diag.Diagnostics) {
+	if model == nil {
+		return nil, nil
+	}
+
+	requiredClaims := map[string]string{}
+	diags := model.RequiredClaims.ElementsAs(ctx, &requiredClaims, false)
+
+	return &client.ClusterOIDC{
+		IssuerURL:      model.IssuerURL.ValueString(),
+		ClientID:       model.ClientID.ValueString(),
+		UsernameClaim:  model.UsernameClaim.ValueString(),
+		UsernamePrefix: model.UsernamePrefix.ValueString(),
+		GroupsClaim:    model.GroupsClaim.ValueString(),
+		GroupsPrefix:   model.GroupsPrefix.ValueString(),
+		RequiredClaims: requiredClaims,
+		CA:             model.CA.ValueString(),
+	}, diags
+}
+
+func oidcModel(ctx context.Context, oidc *client.ClusterOIDC) (*ClusterOIDCModel, diag.Diagnostics) {
+	if oidc == nil {
+		return nil, nil
+	}
+
+	requiredClaims, diags := types.MapValueFrom(ctx, types.StringType, oidc.RequiredClaims)
+	if oidc.RequiredClaims == nil {
+		requiredClaims = types.MapValueMust(types.StringType, map[string]attr.Value{})
+	}
+
+	return &ClusterOIDCModel{
+		IssuerURL:      types.StringValue(oidc.IssuerURL),
+		ClientID:       types.StringValue(oidc.ClientID),
+		UsernameClaim:  types.StringValue(oidc.UsernameClaim),
+		UsernamePrefix: optionalStringValue(oidc.UsernamePrefix),
+		GroupsClaim:    optionalStringValue(oidc.GroupsClaim),
+		GroupsPrefix:   optionalStringValue(oidc.GroupsPrefix),
+		RequiredClaims: requiredClaims,
+		CA:             optionalStringValue(oidc.CA),
+	}, diags
+}
+
+var _ validator.String = httpsURLValidator{}
+
+// httpsURLValidator validates that a string is an HTTPS URL without query and fragment, as required for the issuers of
+// OIDC and service account tokens.
+type httpsURLValidator struct{}
+
+func (v httpsURLValidator) Description(ctx context.Context) string {
+	return "value must be an HTTPS URL like https://login.example.com"
+}
+
+func (v httpsURLValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v httpsURLValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+
+	u, err := url.Parse(value)
+	if err != nil || u.Scheme != "https" || u.Host == "" || u.RawQuery != "" || u.Fragment != "" {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid HTTPS URL",
+			fmt.Sprintf("%q is not an HTTPS URL without query and fragment like https://login.example.com.", value))
+	}
+}
+
+var _ validator.String = pemCertificateValidator{}
+
+// pemCertificateValidator validates that a string contains PEM encoded certificates.
+type pemCertificateValidator struct{}
+
+func (v pemCertificateValidator) Description(ctx context.Context) string {
+	return "value must be a PEM encoded certificate"
+}
+
+func (v pemCertificateValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v pemCertificateValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	rest := []byte(req.ConfigValue.ValueString())
+	found := false
+
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			resp.Diagnostics.AddAttributeError(req.Path, "Invalid CA Certificate",
+				fmt.Sprintf("The PEM block %q is not a certificate.", block.Type))
+			return
+		}
+
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			resp.Diagnostics.AddAttributeError(req.Path, "Invalid CA Certificate",
+				fmt.Sprintf("Unable to parse the certificate: %s", err))
+			return
+		}
+
+		found = true
+	}
+
+	if !found {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid CA Certificate", "No PEM encoded certificate found.")
+	}
+}
@@ -0,0 +1,213 @@
+package provider
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"reflect"
+	"terraform-provider-meltcloud/internal/client"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func testCACertificate(t *testing.T) string {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "oidc-ca"},
+		NotBefore:             time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:              time.Date(2036, 1, 1, 0, 0, 0, 0, time.UTC),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate() error = %v", err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func TestHTTPSURLValidator(t *testing.T) {
+	runStringValidatorTests(t, httpsURLValidator{}, []stringValidatorTest{
+		{name: "null", value: types.StringNull()},
+		{name: "unknown", value: types.StringUnknown()},
+		{name: "host", value: types.StringValue("https://login.example.com")},
+		{name: "path", value: types.StringValue("https://login.microsoftonline.com/tenant/v2.0")},
+		{name: "port", value: types.StringValue("https://oidc.example.com:8443/melt01")},
+		{name: "empty", value: types.StringValue(""), wantError: "Invalid HTTPS URL"},
+		{name: "HTTP", value: types.StringValue("http://login.example.com"), wantError: "Invalid HTTPS URL"},
+		{name: "without scheme", value: types.StringValue("login.example.com"), wantError: "Invalid HTTPS URL"},
+		{name: "without host", value: types.StringValue("https:///v2.0"), wantError: "Invalid HTTPS URL"},
+		{name: "query", value: types.StringValue("https://login.example.com?tenant=1"), wantError: "Invalid HTTPS URL"},
+		{name: "fragment", value: types.StringValue("https://login.example.com#tenant"), wantError: "Invalid HTTPS URL"},
+		{name: "unparsable", value: types.StringValue("https://login.example.com/%zz"), wantError: "Invalid HTTPS URL"},
+	})
+}
+
+func TestPEMCertificateValidator(t *testing.T) {
+	ca := testCACertificate(t)
+	key := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("dummy")}))
+	invalid := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("dummy")}))
+
+	runStringValidatorTests(t, pemCertificateValidator{}, []stringValidatorTest{
+		{name: "null", value: types.StringNull()},
+		{name: "unknown", value: types.StringUnknown()},
+		{name: "certificate", value: types.StringValue(ca)},
+		{name: "bundle", value: types.StringValue(ca + ca)},
+		{name: "empty", value: types.StringValue(""), wantError: "Invalid CA Certificate"},
+		{name: "not PEM", value: types.StringValue("oidc-ca"), wantError: "Invalid CA Certificate"},
+		{name: "private key", value: types.StringValue(key), wantError: "Invalid CA Certificate"},
+		{name: "certificate and private key", value: types.StringValue(ca + key), wantError: "Invalid CA Certificate"},
+		{name: "unparsable certificate", value: types.StringValue(invalid), wantError: "Invalid CA Certificate"},
+	})
+}
+
+func TestOIDCInput(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		model *ClusterOIDCModel
+		want  *client.ClusterOIDC
+	}{
+		{name: "not specified"},
+		{
+			name: "defaults",
+			model: &ClusterOIDCModel{
+				IssuerURL:      types.StringValue("https://login.example.com"),
+				ClientID:       types.StringValue("kubernetes"),
+				UsernameClaim:  types.StringValue("sub"),
+				UsernamePrefix: types.StringNull(),
+				GroupsClaim:    types.StringNull(),
+				GroupsPrefix:   types.StringNull(),
+				RequiredClaims: types.MapValueMust(types.StringType, map[string]attr.Value{}),
+				CA:             types.StringNull(),
+			},
+			want: &client.ClusterOIDC{
+				IssuerURL:      "https://login.example.com",
+				ClientID:       "kubernetes",
+				UsernameClaim:  "sub",
+				RequiredClaims: map[string]string{},
+			},
+		},
+		{
+			name: "all attributes",
+			model: &ClusterOIDCModel{
+				IssuerURL:      types.StringValue("https://login.example.com"),
+				ClientID:       types.StringValue("kubernetes"),
+				UsernameClaim:  types.StringValue("email"),
+				UsernamePrefix: types.StringValue("oidc:"),
+				GroupsClaim:    types.StringValue("groups"),
+				GroupsPrefix:   types.StringValue("oidc:"),
+				RequiredClaims: types.MapValueMust(types.StringType, map[string]attr.Value{"hd": types.StringValue("example.com")}),
+				CA:             types.StringValue("ca"),
+			},
+			want: &client.ClusterOIDC{
+				IssuerURL:      "https://login.example.com",
+				ClientID:       "kubernetes",
+				UsernameClaim:  "email",
+				UsernamePrefix: "oidc:",
+				GroupsClaim:    "groups",
+				GroupsPrefix:   "oidc:",
+				RequiredClaims: map[string]string{"hd": "example.com"},
+				CA:             "ca",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, diags := oidcInput(ctx, tt.model)
+			if diags.HasError() {
+				t.Fatalf("oidcInput() diagnostics = %v", diags)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("oidcInput() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOIDCModel(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		oidc *client.ClusterOIDC
+		want *ClusterOIDCModel
+	}{
+		{name: "not configured"},
+		{
+			// the optional attributes are null if empty, the required claims an empty map if not returned
+			name: "defaults",
+			oidc: &client.ClusterOIDC{
+				IssuerURL:     "https://login.example.com",
+				ClientID:      "kubernetes",
+				UsernameClaim: "sub",
+			},
+			want: &ClusterOIDCModel{
+				IssuerURL:      types.StringValue("https://login.example.com"),
+				ClientID:       types.StringValue("kubernetes"),
+				UsernameClaim:  types.StringValue("sub"),
+				UsernamePrefix: types.StringNull(),
+				GroupsClaim:    types.StringNull(),
+				GroupsPrefix:   types.StringNull(),
+				RequiredClaims: types.MapValueMust(types.StringType, map[string]attr.Value{}),
+				CA:             types.StringNull(),
+			},
+		},
+		{
+			name: "all attributes",
+			oidc: &client.ClusterOIDC{
+				IssuerURL:      "https://login.example.com",
+				ClientID:       "kubernetes",
+				UsernameClaim:  "email",
+				UsernamePrefix: "oidc:",
+				GroupsClaim:    "groups",
+				GroupsPrefix:   "oidc:",
+				RequiredClaims: map[string]string{"hd": "example.com"},
+				CA:             "ca",
+			},
+			want: &ClusterOIDCModel{
+				IssuerURL:      types.StringValue("https://login.example.com"),
+				ClientID:       types.StringValue("kubernetes"),
+				UsernameClaim:  types.StringValue("email"),
+				UsernamePrefix: types.StringValue("oidc:"),
+				GroupsClaim:    types.StringValue("groups"),
+				GroupsPrefix:   types.StringValue("oidc:"),
+				RequiredClaims: types.MapValueMust(types.StringType, map[string]attr.Value{"hd": types.StringValue("example.com")}),
+				CA:             types.StringValue("ca"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, diags := oidcModel(ctx, tt.oidc)
+			if diags.HasError() {
+				t.Fatalf("oidcModel() diagnostics = %v", diags)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("oidcModel() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,12 +2,13 @@ package provider
 
 import (
 	"context"
-	"fmt"
 	"os"
 	"terraform-provider-meltcloud/internal/client"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -17,6 +18,8 @@ import (
 // Ensure MeltcloudProvider satisfies various provider interfaces.
 var _ provider.Provider = &MeltcloudProvider{}
 var _ provider.ProviderWithFunctions = &MeltcloudProvider{}
+var _ provider.ProviderWithListResources = &MeltcloudProvider{}
+var _ provider.ProviderWithEphemeralResources = &MeltcloudProvider{}
 
 // MeltcloudProvider defines the provider implementation.
 type MeltcloudProvider struct {
@@ -84,7 +87,7 @@ func (p *MeltcloudProvider) Configure(ctx context.Context, req provider.Configur
 	var apiKey string
 	if data.APIKey.IsNull() {
 		var found bool
-		if apiKey, found = os.LookupEnv("MELTCLOUD_API_KEY"); !found {
+		if apiKey, found = os.LookupEnv(client.EnvAPIKey); !found {
 			resp.Diagnostics.AddError("Config Error", "either MELTCLOUD_API_KEY or api_key in provider config must be set")
 			return
 		}
@@ -95,8 +98,8 @@ func (p *MeltcloudProvider) Configure(ctx context.Context, req provider.Configur
 	var endpoint string
 	if data.Endpoint.IsNull() {
 		var found bool
-		if endpoint, found = os.LookupEnv("MELTCLOUD_ENDPOINT"); !found {
-			endpoint = "https://app.meltcloud.io"
+		if endpoint, found = os.LookupEnv(client.EnvEndpoint); !found {
+			endpoint = client.DefaultEndpoint
 		}
 	} else {
 		endpoint = data.Endpoint.ValueString()
@@ -105,7 +108,7 @@ func (p *MeltcloudProvider) Configure(ctx context.Context, req provider.Configur
 	var organization string
 	if data.Organization.IsNull() {
 		var found bool
-		if organization, found = os.LookupEnv("MELTCLOUD_ORGANIZATION"); !found {
+		if organization, found = os.LookupEnv(client.EnvOrganization); !found {
 			resp.Diagnostics.AddError("Config Error", "either MELTCLOUD_ORGANIZATION or organization in provider config must be set")
 			return
 		}
@@ -113,36 +116,25 @@ func (p *MeltcloudProvider) Configure(ctx context.Context, req provider.Configur
 		organization = data.Organization.ValueString()
 	}
 
-	var tlsConfig *client.TLSConfig
-
-	caFile := stringAttrOrEnv(data.CACertFile, "MELTCLOUD_CACERT")
-	caPEM := stringAttrOrEmpty(data.CACertPEM)
-	skipTLS := boolAttrOrEnv(data.SkipTLSVerify, "MELTCLOUD_SKIP_VERIFY")
-
-	if caFile != "" && caPEM != "" {
-		resp.Diagnostics.AddError("Config Error", "ca_cert_file and ca_cert_pem are mutually exclusive")
-		return
-	}
-
-	if caFile != "" {
-		pemBytes, err := os.ReadFile(caFile)
-		if err != nil {
-			resp.Diagnostics.AddError("Config Error", fmt.Sprintf("failed to read CA certificate file: %s", err))
-			return
-		}
-		caPEM = string(pemBytes)
+	config := &client.Config{
+		Endpoint:      endpoint,
+		Organization:  organization,
+		APIKey:        apiKey,
+		CACertFile:    stringAttrOrEnv(data.CACertFile, client.EnvCACert),
+		CACertPEM:     stringAttrOrEmpty(data.CACertPEM),
+		SkipTLSVerify: boolAttrOrEnv(data.SkipTLSVerify, client.EnvSkipTLSVerify),
 	}
 
-	if caPEM != "" || skipTLS {
-		tlsConfig = &client.TLSConfig{
-			CACertPEM:     caPEM,
-			SkipTLSVerify: skipTLS,
-		}
+	apiClient, err := client.NewFromConfig(config)
+	if err != nil {
+		resp.Diagnostics.AddError("Config Error", err.Error())
+		return
 	}
 
-	apiClient := client.New(endpoint, organization, apiKey, tlsConfig)
 	resp.DataSourceData = apiClient
 	resp.ResourceData = apiClient
+	resp.ListResourceData = apiClient
+	resp.EphemeralResourceData = apiClient
 }
 
 func (p *MeltcloudProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -155,6 +147,8 @@ func (p *MeltcloudProvider) Resources(ctx context.Context) []func() resource.Res
 		NewElasticFleetResource,
 		NewElasticQuotaResource,
 		NewElasticNodePoolResource,
+		NewKubeConfigFileResource,
+		NewClusterUpgradeResource,
 	}
 }
 
@@ -168,11 +162,41 @@ func (p *MeltcloudProvider) DataSources(ctx context.Context) []func() datasource
 		NewElasticFleetDataSource,
 		NewElasticQuotaDataSource,
 		NewElasticNodePoolDataSource,
+		NewAddonsDataSource,
+		NewKubernetesVersionsDataSource,
+	}
+}
+
+func (p *MeltcloudProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewClusterCredentialsEphemeralResource,
+	}
+}
+
+func (p *MeltcloudProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewClusterListResource,
+		NewMachinePoolListResource,
+		NewMachineListResource,
+		NewEnrollmentImageListResource,
+		NewNetworkProfileListResource,
+		NewElasticFleetListResource,
+		NewElasticQuotaListResource,
+		NewElasticNodePoolListResource,
 	}
 }
 
 func (p *MeltcloudProvider) Functions(ctx context.Context) []func() function.Function {
-	return []func() function.Function{}
+	return []func() function.Function{
+		NewKubeConfigDecodeFunction,
+		NewKubeConfigEncodeFunction,
+		NewVersionCompareFunction,
+		NewVersionMinorFunction,
+		NewVersionSkewOKFunction,
+		NewClusterDNSIPFunction,
+		NewCIDRsOverlapFunction,
+		NewNextFreeCIDRFunction,
+	}
 }
 
 func New(version string) func() provider.Provider {
@@ -201,6 +225,5 @@ func boolAttrOrEnv(attr types.Bool, envVar string) bool {
 	if !attr.IsNull() {
 		return attr.ValueBool()
 	}
-	v := os.Getenv(envVar)
-	return v == "1" || v == "true"
+	return client.EnvBool(envVar)
 }
@@ -1,18 +1,264 @@
 package provider
 
-// currently unused
-//const (
-//	providerConfig = `
-//provider "meltcloud" {
-//  endpoint     = "https://app.meltcloud.io"
-//  organization = "deadbeef-0000-0000-0000-000000000000"
-//  api_key      = "dummy"
-//}
-//`
-//)
-
-//var (
-//	testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-//		"meltcloud": providerserver.NewProtocol6WithError(New("test")()),
-//	}
-//)
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"terraform-provider-meltcloud/internal/client"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+const testOrganization = "deadbeef-0000-0000-0000-000000000000"
+
+// testKubeConfig is the admin kubeconfig returned by testAPI for all clusters.
+const testKubeConfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: https://test.k8s.meltcloud.io
+users:
+- name: test-admin
+  user:
+    token: dummy
+contexts:
+- name: test
+  context:
+    cluster: test
+    user: test-admin
+current-context: test
+`
+
+var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"meltcloud": providerserver.NewProtocol6WithError(New("test")()),
+}
+
+// testProviderConfig returns the provider configuration for the API of the test server.
+func testProviderConfig(api *testAPI) string {
+	return fmt.Sprintf(`
+provider "meltcloud" {
+  endpoint     = %q
+  organization = %q
+  api_key      = "dummy"
+}
+`, api.URL, testOrganization)
+}
+
+// testAPI is an in-memory meltcloud API with the endpoints needed to create, read, import and delete clusters and
+// machines, to list the machine pools and elastic node pools of clusters, and to issue and revoke cluster credentials.
+// All operations succeed immediately.
+type testAPI struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	nextID      int64
+	clusters    map[int64]*client.Cluster
+	machines    map[int64]*client.Machine
+	credentials map[int64]*client.ClusterCredential
+
+	// machinePools and elasticNodePools are the pools by cluster ID
+	machinePools     map[int64][]*client.MachinePool
+	elasticNodePools map[int64][]*client.ElasticNodePool
+
+	// credentialKubeConfig is the kubeconfig of issued cluster credentials
+	credentialKubeConfig string
+	// revoked are the IDs of the revoked cluster credentials
+	revoked []int64
+}
+
+func newTestAPI(t *testing.T) *testAPI {
+	t.Helper()
+
+	api := &testAPI{
+		nextID:      1,
+		clusters:    map[int64]*client.Cluster{},
+		machines:    map[int64]*client.Machine{},
+		credentials: map[int64]*client.ClusterCredential{},
+
+		machinePools:     map[int64][]*client.MachinePool{},
+		elasticNodePools: map[int64][]*client.ElasticNodePool{},
+
+		credentialKubeConfig: testKubeConfig,
+	}
+	api.Server = httptest.NewServer(http.HandlerFunc(api.handle))
+	t.Cleanup(api.Close)
+
+	return api
+}
+
+func (a *testAPI) handle(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	_, resourcePath, found := strings.Cut(r.URL.Path, "/orgs/"+testOrganization+"/")
+	if !found {
+		http.NotFound(w, r)
+		return
+	}
+
+	collection, rest, _ := strings.Cut(resourcePath, "/")
+	idPart, subPath, _ := strings.Cut(rest, "/")
+	id, _ := strconv.ParseInt(idPart, 10, 64)
+	subCollection, subIDPart, _ := strings.Cut(subPath, "/")
+	subID, _ := strconv.ParseInt(subIDPart, 10, 64)
+
+	switch {
+	case collection == "kubernetes_versions" && r.Method == http.MethodGet:
+		a.respond(w, client.KubernetesVersionsResult{
+			KubernetesVersions: []*client.KubernetesVersion{{Version: "1.31", LatestPatchVersion: "1.31.4"}},
+			DefaultVersion:     "1.31",
+		})
+	case collection == "operations" && r.Method == http.MethodGet:
+		a.respond(w, client.OperationResult{Operation: &client.Operation{ID: id, Status: client.OperationStatusSucceeded}})
+	case collection == "clusters" && idPart == "" && r.Method == http.MethodGet:
+		result := client.ClustersResult{Clusters: []*client.Cluster{}}
+		for _, cluster := range a.clusters {
+			result.Clusters = append(result.Clusters, cluster)
+		}
+		a.respond(w, result)
+	case collection == "clusters" && idPart == "" && r.Method == http.MethodPost:
+		var input client.ClusterCreateInput
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		cluster := &client.Cluster{
+			ID:             a.newID(),
+			Name:           input.Name,
+			UserVersion:    input.UserVersion,
+			PatchVersion:   input.UserVersion + ".4",
+			KubeConfig:     testKubeConfig,
+			PodCIDR:        "10.36.0.0/16",
+			ServiceCIDR:    "10.96.0.0/12",
+			DNSServiceIP:   "10.96.0.10",
+			AddonKubeProxy: true,
+			AddonCoreDNS:   true,
+		}
+		a.clusters[cluster.ID] = cluster
+		a.respond(w, client.ClusterResult{Cluster: cluster, Operation: &client.Operation{ID: a.newID()}})
+	case collection == "clusters" && a.clusters[id] != nil && subCollection == "credentials" && subIDPart == "" && r.Method == http.MethodPost:
+		var input client.ClusterCredentialCreateInput
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var clusterRole string
+		if input.ClusterRole != nil {
+			clusterRole = *input.ClusterRole
+		}
+
+		credential := &client.ClusterCredential{
+			ID:          a.newID(),
+			Username:    "terraform",
+			Groups:      input.Groups,
+			ClusterRole: clusterRole,
+			KubeConfig:  a.credentialKubeConfig,
+			ExpiresAt:   time.Now().Add(time.Duration(input.TTLSeconds) * time.Second).UTC().Truncate(time.Second),
+		}
+		a.credentials[credential.ID] = credential
+		a.respond(w, client.ClusterCredentialResult{ClusterCredential: credential})
+	case collection == "clusters" && subCollection == "credentials" && a.credentials[subID] != nil && r.Method == http.MethodDelete:
+		credential := a.credentials[subID]
+		delete(a.credentials, subID)
+		a.revoked = append(a.revoked, subID)
+		a.respond(w, client.ClusterCredentialResult{ClusterCredential: credential})
+	case collection == "clusters" && a.clusters[id] != nil && subPath == "machine_pools" && r.Method == http.MethodGet:
+		a.respond(w, client.MachinePoolsResult{MachinePools: append([]*client.MachinePool{}, a.machinePools[id]...)})
+	case collection == "clusters" && a.clusters[id] != nil && subPath == "elastic_node_pools" && r.Method == http.MethodGet:
+		a.respond(w, client.ElasticNodePoolsResult{ElasticNodePools: append([]*client.ElasticNodePool{}, a.elasticNodePools[id]...)})
+	case collection == "clusters" && a.clusters[id] != nil && subPath == "" && r.Method == http.MethodGet:
+		a.respond(w, client.ClusterResult{Cluster: a.clusters[id]})
+	case collection == "clusters" && a.clusters[id] != nil && subPath == "" && r.Method == http.MethodDelete:
+		cluster := a.clusters[id]
+		delete(a.clusters, id)
+		a.respond(w, client.ClusterResult{Cluster: cluster, Operation: &client.Operation{ID: a.newID()}})
+	case collection == "machines" && idPart == "" && r.Method == http.MethodGet:
+		result := client.MachinesResult{Machines: []*client.Machine{}}
+		for _, machine := range a.machines {
+			result.Machines = append(result.Machines, machine)
+		}
+		a.respond(w, result)
+	case collection == "machines" && idPart == "" && r.Method == http.MethodPost:
+		var input client.MachineCreateInput
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		machine := &client.Machine{ID: a.newID(), UUID: input.UUID, Name: input.Name, MachinePoolID: input.MachinePoolID, Labels: input.Labels}
+		a.machines[machine.ID] = machine
+		a.respond(w, client.MachineResult{Machine: machine})
+	case collection == "machines" && a.machines[id] != nil && r.Method == http.MethodGet:
+		a.respond(w, client.MachineResult{Machine: a.machines[id]})
+	case collection == "machines" && a.machines[id] != nil && r.Method == http.MethodDelete:
+		machine := a.machines[id]
+		delete(a.machines, id)
+		a.respond(w, client.MachineResult{Machine: machine})
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"status":404,"error":"not found"}`))
+	}
+}
+
+func (a *testAPI) newID() int64 {
+	id := a.nextID
+	a.nextID++
+	return id
+}
+
+func (a *testAPI) respond(w http.ResponseWriter, result interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(result)
+}
+
+// addCluster adds a cluster created outside of Terraform.
+func (a *testAPI) addCluster(name string) int64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	cluster := &client.Cluster{ID: a.newID(), Name: name, UserVersion: "1.31", PatchVersion: "1.31.4", KubeConfig: testKubeConfig}
+	a.clusters[cluster.ID] = cluster
+	return cluster.ID
+}
+
+// addMachine adds a machine created outside of Terraform.
+func (a *testAPI) addMachine(machineUUID uuid.UUID, name string) int64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	machine := &client.Machine{ID: a.newID(), UUID: machineUUID, Name: name}
+	a.machines[machine.ID] = machine
+	return machine.ID
+}
+
+// addMachinePool adds a machine pool of the cluster created outside of Terraform.
+func (a *testAPI) addMachinePool(clusterID int64, name string) int64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	pool := &client.MachinePool{ID: a.newID(), Name: name, UserVersion: "1.31", PatchVersion: "1.31.4"}
+	a.machinePools[clusterID] = append(a.machinePools[clusterID], pool)
+	return pool.ID
+}
+
+// addElasticNodePool adds an elastic node pool of the cluster created outside of Terraform.
+func (a *testAPI) addElasticNodePool(clusterID int64, name string, nodeCount int64) int64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	pool := &client.ElasticNodePool{ID: a.newID(), ClusterID: clusterID, Name: name, NodeCount: nodeCount}
+	a.elasticNodePools[clusterID] = append(a.elasticNodePools[clusterID], pool)
+	return pool.ID
+}
@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"terraform-provider-meltcloud/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// attributeChange is the planned and prior value of an attribute that requires replacement.
+type attributeChange struct {
+	name    string
+	planned attr.Value
+	prior   attr.Value
+}
+
+// replacingAttributes returns the names of the attributes whose change requires replacement, like
+// RequiresReplace an unknown planned value counts as a change.
+func replacingAttributes(changes ...attributeChange) []string {
+	var names []string
+	for _, change := range changes {
+		if !change.planned.Equal(change.prior) {
+			names = append(names, change.name)
+		}
+	}
+
+	return names
+}
+
+// clusterReplacementWarning warns that replacing the cluster also rebuilds its machine pools and elastic node pools.
+// The counts are left out if they cannot be read, the warning must not fail the plan.
+func clusterReplacementWarning(ctx context.Context, c *client.Client, state *ClusterResourceModel, attributes []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	detail := fmt.Sprintf("Changing %s replaces cluster %s: its control plane is deleted and created again",
+		strings.Join(attributes, ", "), state.Name.ValueString())
+
+	if dependents, err := clusterDependents(ctx, c, state.ID.ValueInt64()); err != nil {
+		detail += ", together with all its machine pools and elastic node pools"
+	} else if dependents != "" {
+		detail += ", " + dependents
+	}
+
+	diags.AddWarning("Cluster Will Be Replaced", detail+". All workloads and data stored in the cluster are lost.")
+
+	return diags
+}
+
+// clusterDependents describes the machine pools with their machines and the elastic node pools with their nodes
+// rebuilt with the cluster, e.g. "rebuilding 3 machine pools with 42 machines".
+func clusterDependents(ctx context.Context, c *client.Client, clusterID int64) (string, *client.Error) {
+	machinePools, err := c.MachinePool().List(ctx, clusterID)
+	if err != nil {
+		return "", err
+	}
+
+	elasticNodePools, err := c.ElasticNodePool().List(ctx, clusterID)
+	if err != nil {
+		return "", err
+	}
+
+	var parts []string
+
+	if len(machinePools.MachinePools) > 0 {
+		machines, err := c.Machine().List(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		poolIDs := map[int64]bool{}
+		for _, pool := range machinePools.MachinePools {
+			poolIDs[pool.ID] = true
+		}
+
+		machineCount := 0
+		for _, machine := range machines.Machines {
+			if poolIDs[machine.MachinePoolID] {
+				machineCount++
+			}
+		}
+
+		parts = append(parts, fmt.Sprintf("%s with %s", countNoun(len(machinePools.MachinePools), "machine pool"), countNoun(machineCount, "machine")))
+	}
+
+	if len(elasticNodePools.ElasticNodePools) > 0 {
+		nodeCount := 0
+		for _, pool := range elasticNodePools.ElasticNodePools {
+			nodeCount += int(pool.NodeCount)
+		}
+
+		parts = append(parts, fmt.Sprintf("%s with %s", countNoun(len(elasticNodePools.ElasticNodePools), "elastic node pool"), countNoun(nodeCount, "node")))
+	}
+
+	if len(parts) == 0 {
+		return "", nil
+	}
+
+	return "rebuilding " + strings.Join(parts, " and "), nil
+}
+
+// elasticNodePoolReplacementWarning warns that replacing the elastic node pool deletes all of its nodes.
+func elasticNodePoolReplacementWarning(state *ElasticNodePoolResourceModel, attributes []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	diags.AddWarning("Elastic Node Pool Will Be Replaced",
+		fmt.Sprintf("Changing %s replaces elastic node pool %s and recreates its %s. The pods running on the pool are evicted.",
+			strings.Join(attributes, ", "), state.Name.ValueString(), countNoun(int(state.NodeCount.ValueInt64()), "node")))
+
+	return diags
+}
+
+// countNoun returns the count with the noun in singular or plural, e.g. "1 machine" or "3 machines".
+func countNoun(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+
+	return fmt.Sprintf("%d %ss", count, noun)
+}
@@ -0,0 +1,189 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"terraform-provider-meltcloud/internal/client"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestReplacingAttributes(t *testing.T) {
+	tests := []struct {
+		name    string
+		changes []attributeChange
+		want    []string
+	}{
+		{name: "no attributes"},
+		{
+			name: "unchanged",
+			changes: []attributeChange{
+				{name: "pod_cidr", planned: types.StringValue("10.36.0.0/16"), prior: types.StringValue("10.36.0.0/16")},
+				{name: "network_profile_id", planned: types.Int64Value(1), prior: types.Int64Value(1)},
+			},
+		},
+		{
+			name: "changed",
+			changes: []attributeChange{
+				{name: "pod_cidr", planned: types.StringValue("10.37.0.0/16"), prior: types.StringValue("10.36.0.0/16")},
+				{name: "network_profile_id", planned: types.Int64Value(1), prior: types.Int64Value(1)},
+			},
+			want: []string{"pod_cidr"},
+		},
+		{
+			name: "unknown",
+			changes: []attributeChange{
+				{name: "network_profile_id", planned: types.Int64Unknown(), prior: types.Int64Value(1)},
+			},
+			want: []string{"network_profile_id"},
+		},
+		{
+			name: "removed",
+			changes: []attributeChange{
+				{name: "network_profile_id", planned: types.Int64Null(), prior: types.Int64Value(1)},
+			},
+			want: []string{"network_profile_id"},
+		},
+		{
+			name: "in order",
+			changes: []attributeChange{
+				{name: "pod_cidr", planned: types.StringValue("10.37.0.0/16"), prior: types.StringValue("10.36.0.0/16")},
+				{name: "service_cidr", planned: types.StringValue("10.97.0.0/12"), prior: types.StringValue("10.96.0.0/12")},
+			},
+			want: []string{"pod_cidr", "service_cidr"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := replacingAttributes(tt.changes...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("replacingAttributes() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountNoun(t *testing.T) {
+	tests := []struct {
+		count int
+		noun  string
+		want  string
+	}{
+		{count: 0, noun: "machine", want: "0 machines"},
+		{count: 1, noun: "machine", want: "1 machine"},
+		{count: 2, noun: "machine", want: "2 machines"},
+		{count: 1, noun: "elastic node pool", want: "1 elastic node pool"},
+		{count: 3, noun: "elastic node pool", want: "3 elastic node pools"},
+	}
+
+	for _, tt := range tests {
+		if got := countNoun(tt.count, tt.noun); got != tt.want {
+			t.Errorf("countNoun(%d, %q) = %q, want %q", tt.count, tt.noun, got, tt.want)
+		}
+	}
+}
+
+func TestClusterDependents(t *testing.T) {
+	api := newTestAPI(t)
+
+	// addPoolMachines adds the machines of a machine pool
+	addPoolMachines := func(poolID int64, count int) {
+		for i := 0; i < count; i++ {
+			id := api.addMachine(uuid.New(), "machine")
+			api.machines[id].MachinePoolID = poolID
+		}
+	}
+
+	empty := api.addCluster("empty")
+
+	singular := api.addCluster("singular")
+	addPoolMachines(api.addMachinePool(singular, "pool"), 1)
+	api.addElasticNodePool(singular, "elastic", 1)
+
+	plural := api.addCluster("plural")
+	addPoolMachines(api.addMachinePool(plural, "pool-a"), 2)
+	addPoolMachines(api.addMachinePool(plural, "pool-b"), 1)
+	api.addElasticNodePool(plural, "elastic-a", 3)
+	api.addElasticNodePool(plural, "elastic-b", 2)
+
+	machinesOnly := api.addCluster("machines-only")
+	api.addMachinePool(machinesOnly, "empty-pool")
+	// machines without a pool or in the pools of other clusters are not rebuilt
+	addPoolMachines(0, 1)
+
+	elasticOnly := api.addCluster("elastic-only")
+	api.addElasticNodePool(elasticOnly, "elastic", 0)
+
+	c := client.New(api.URL, testOrganization, "dummy", nil)
+
+	tests := []struct {
+		name      string
+		clusterID int64
+		want      string
+		wantError bool
+	}{
+		{name: "no pools", clusterID: empty},
+		{name: "singular", clusterID: singular, want: "rebuilding 1 machine pool with 1 machine and 1 elastic node pool with 1 node"},
+		{name: "plural", clusterID: plural, want: "rebuilding 2 machine pools with 3 machines and 2 elastic node pools with 5 nodes"},
+		{name: "machine pools only", clusterID: machinesOnly, want: "rebuilding 1 machine pool with 0 machines"},
+		{name: "elastic node pools only", clusterID: elasticOnly, want: "rebuilding 1 elastic node pool with 0 nodes"},
+		{name: "unknown cluster", clusterID: 999, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := clusterDependents(context.Background(), c, tt.clusterID)
+			if (err != nil) != tt.wantError {
+				t.Fatalf("clusterDependents() error = %v, wantError %t", err, tt.wantError)
+			}
+
+			if got != tt.want {
+				t.Errorf("clusterDependents() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClusterReplacementWarning(t *testing.T) {
+	api := newTestAPI(t)
+	clusterID := api.addCluster("prod")
+	api.addElasticNodePool(clusterID, "elastic", 1)
+
+	c := client.New(api.URL, testOrganization, "dummy", nil)
+
+	tests := []struct {
+		name      string
+		clusterID int64
+		want      string
+	}{
+		{
+			name:      "dependents",
+			clusterID: clusterID,
+			want:      "Changing pod_cidr, service_cidr replaces cluster prod: its control plane is deleted and created again, rebuilding 1 elastic node pool with 1 node.",
+		},
+		// the counts cannot be read, the warning falls back to all pools
+		{
+			name:      "unreadable dependents",
+			clusterID: 999,
+			want:      "Changing pod_cidr, service_cidr replaces cluster prod: its control plane is deleted and created again, together with all its machine pools and elastic node pools.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := &ClusterResourceModel{ID: types.Int64Value(tt.clusterID), Name: types.StringValue("prod")}
+
+			diags := clusterReplacementWarning(context.Background(), c, state, []string{"pod_cidr", "service_cidr"})
+			if diags.HasError() || diags.WarningsCount() != 1 {
+				t.Fatalf("clusterReplacementWarning() diagnostics = %v, want one warning", diags)
+			}
+
+			if detail := diags.Warnings()[0].Detail(); !strings.HasPrefix(detail, tt.want) {
+				t.Errorf("clusterReplacementWarning() detail = %q, want prefix %q", detail, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"context"
+	"terraform-provider-meltcloud/internal/kubernetes"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &VersionCompareFunction{}
+
+func NewVersionCompareFunction() function.Function {
+	return &VersionCompareFunction{}
+}
+
+// VersionCompareFunction defines the function implementation.
+type VersionCompareFunction struct{}
+
+func (f *VersionCompareFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "version_compare"
+}
+
+func (f *VersionCompareFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Compare two Kubernetes versions",
+		MarkdownDescription: "Compares two Kubernetes versions like `1.30` or `1.30.2` and returns `-1` if the first is older, `1` if it is newer and `0` if both are equal. " +
+			"A leading `v` and pre-release or build suffixes are ignored, a missing patch version counts as `0`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "a",
+				Description: "Kubernetes version",
+			},
+			function.StringParameter{
+				Name:        "b",
+				Description: "Kubernetes version to compare with",
+			},
+		},
+		Return: function.Int64Return{},
+	}
+}
+
+func (f *VersionCompareFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var a, b string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &a, &b))
+	if resp.Error != nil {
+		return
+	}
+
+	versionA, err := kubernetes.ParseVersion(a)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	versionB, err := kubernetes.ParseVersion(b)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, int64(versionA.Compare(versionB))))
+}
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"context"
+	"terraform-provider-meltcloud/internal/kubernetes"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &VersionMinorFunction{}
+
+func NewVersionMinorFunction() function.Function {
+	return &VersionMinorFunction{}
+}
+
+// VersionMinorFunction defines the function implementation.
+type VersionMinorFunction struct{}
+
+func (f *VersionMinorFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "version_minor"
+}
+
+func (f *VersionMinorFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Minor version of a Kubernetes version",
+		MarkdownDescription: "Returns the minor version of a Kubernetes version, e.g. `1.30` for `v1.30.2`. Useful to compare a `patch_version` with the `version` of a cluster or machine pool.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "version",
+				Description: "Kubernetes version",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *VersionMinorFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var version string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &version))
+	if resp.Error != nil {
+		return
+	}
+
+	v, err := kubernetes.ParseVersion(version)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, v.MinorVersion()))
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-meltcloud/internal/kubernetes"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &VersionSkewOKFunction{}
+
+func NewVersionSkewOKFunction() function.Function {
+	return &VersionSkewOKFunction{}
+}
+
+// VersionSkewOKFunction defines the function implementation.
+type VersionSkewOKFunction struct{}
+
+func (f *VersionSkewOKFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "version_skew_ok"
+}
+
+func (f *VersionSkewOKFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Check the Kubernetes version skew policy",
+		MarkdownDescription: fmt.Sprintf("Returns whether kubelets of the given version may run against a control plane of the given version according to the "+
+			"[version skew policy](https://kubernetes.io/releases/version-skew-policy/#kubelet): the kubelet must not be newer than the kube-apiserver "+
+			"and at most %d minor versions older. Use it in `precondition` or `check` blocks to enforce that the control plane is upgraded before its machine pools.",
+			kubernetes.MaxKubeletSkew),
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "control_plane",
+				Description: "Kubernetes version of the control plane",
+			},
+			function.StringParameter{
+				Name:        "kubelet",
+				Description: "Kubernetes version of the kubelets, e.g. of a machine pool",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *VersionSkewOKFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var controlPlane, kubelet string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &controlPlane, &kubelet))
+	if resp.Error != nil {
+		return
+	}
+
+	controlPlaneVersion, err := kubernetes.ParseVersion(controlPlane)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	kubeletVersion, err := kubernetes.ParseVersion(kubelet)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	skewErr := kubernetes.CheckKubeletSkew(controlPlaneVersion, kubeletVersion)
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, skewErr == nil))
+}